@@ -0,0 +1,283 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fnd/blob"
+	"fnd/config"
+	"fnd/protocol"
+	"fnd/store"
+	"fnd/util"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the archive format written by
+	// Write.
+	FormatVersion = 1
+
+	DefaultLockTimeout = time.Minute
+
+	manifestEntry = "manifest.json"
+	configEntry   = "config.toml"
+	dbEntryDir    = "db"
+	blobsEntryDir = "blobs"
+	blobEntry     = "blob"
+	headerEntry   = "header.kv"
+	dbChunkSize   = 4 * 1024 * 1024
+)
+
+var (
+	ErrNameLockTimeout = errors.New("timed out waiting for name lock")
+)
+
+// Manifest describes an archive. It is the first entry of every
+// archive.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	SchemaVersion int       `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	HeaderCount   int       `json:"header_count"`
+}
+
+type Opts struct {
+	DB         *leveldb.DB
+	BlobStore  blob.Store
+	NameLocker util.MultiLocker
+	// HomeDir is searched for the config file, which is archived if
+	// it exists. The node identity is never archived by Write, since
+	// archives can be fetched over RPC. See AddIdentity.
+	HomeDir string
+	// LockTimeout is how long to wait for a name that is being
+	// updated. It defaults to DefaultLockTimeout.
+	LockTimeout time.Duration
+}
+
+// Write writes a gzipped tar archive of a running node's database and
+// blobs to w. The database is read from a snapshot. Each blob is read
+// while holding its name's lock, along with the name's current header,
+// which takes precedence over the snapshot on restore. Blobs that don't
+// match their headers fail the backup.
+func Write(w io.Writer, opts *Opts) (*Manifest, error) {
+	lockTimeout := opts.LockTimeout
+	if lockTimeout == 0 {
+		lockTimeout = DefaultLockTimeout
+	}
+
+	snap, err := opts.DB.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening database snapshot")
+	}
+	defer snap.Release()
+
+	var names []string
+	iter := snap.NewIterator(nil, nil)
+	for iter.Next() {
+		if name, ok := store.HeaderNameFromKey(iter.Key()); ok {
+			names = append(names, name)
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error listing headers")
+	}
+	schemaVersion, err := store.GetSchemaVersion(opts.DB)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now(),
+		HeaderCount:   len(names),
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifestB, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding manifest")
+	}
+	if err := writeEntry(tw, manifestEntry, manifestB); err != nil {
+		return nil, err
+	}
+	configB, err := ioutil.ReadFile(path.Join(opts.HomeDir, configEntry))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "error reading %s", configEntry)
+	}
+	if err == nil {
+		if err := writeEntry(tw, configEntry, configB); err != nil {
+			return nil, err
+		}
+	}
+
+	var chunk bytes.Buffer
+	var chunkCount int
+	flushChunk := func() error {
+		if chunk.Len() == 0 {
+			return nil
+		}
+		chunkCount++
+		name := path.Join(dbEntryDir, paddedIndex(chunkCount)+".kv")
+		if err := writeEntry(tw, name, chunk.Bytes()); err != nil {
+			return err
+		}
+		chunk.Reset()
+		return nil
+	}
+	iter = snap.NewIterator(nil, nil)
+	for iter.Next() {
+		writeKV(&chunk, iter.Key(), iter.Value())
+		if chunk.Len() < dbChunkSize {
+			continue
+		}
+		if err := flushChunk(); err != nil {
+			iter.Release()
+			return nil, err
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error reading database snapshot")
+	}
+	if err := flushChunk(); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if err := writeBlob(tw, opts, name, lockTimeout); err != nil {
+			return nil, errors.Wrapf(err, "error backing up %s", name)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing archive")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing archive")
+	}
+	return manifest, nil
+}
+
+// AddIdentity copies the archive read from src to dst, adding the node
+// identity found in homeDir. The identity holds the node's private key,
+// so it must only be read from disk by the node's operator.
+func AddIdentity(dst io.Writer, src io.Reader, homeDir string) error {
+	identity, err := ioutil.ReadFile(path.Join(homeDir, config.IdentityFilename))
+	if err != nil {
+		return errors.Wrap(err, "error reading node identity")
+	}
+	gzr, err := gzip.NewReader(src)
+	if err != nil {
+		return errors.Wrap(err, "error opening archive")
+	}
+	tr := tar.NewReader(gzr)
+	gz := gzip.NewWriter(dst)
+	tw := tar.NewWriter(gz)
+	for i := 0; ; i++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "error reading archive")
+		}
+		if hdr.Name == config.IdentityFilename {
+			return errors.New("archive already contains an identity")
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrapf(err, "error writing %s", hdr.Name)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return errors.Wrapf(err, "error writing %s", hdr.Name)
+		}
+		// the manifest stays first
+		if i == 0 {
+			if err := writeEntry(tw, config.IdentityFilename, identity); err != nil {
+				return err
+			}
+		}
+	}
+	// reads the rest of the stream, verifying its checksum
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return errors.Wrap(err, "error reading archive")
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "error closing archive")
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(err, "error closing archive")
+	}
+	return nil
+}
+
+func writeBlob(tw *tar.Writer, opts *Opts, name string, lockTimeout time.Duration) error {
+	if !util.RLockTimeout(opts.NameLocker, name, lockTimeout) {
+		return ErrNameLockTimeout
+	}
+	defer opts.NameLocker.RUnlock(name)
+
+	res, err := protocol.VerifyBlob(opts.DB, opts.BlobStore, name)
+	if err != nil {
+		return err
+	}
+	if res.Corrupt() {
+		return errors.New("blob does not match its header, repair it with fnd-cli blob verify --repair")
+	}
+
+	var header bytes.Buffer
+	for _, key := range store.HeaderKeys(name) {
+		val, err := opts.DB.Get(key, nil)
+		if errors.Is(err, leveldb.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "error reading header")
+		}
+		writeKV(&header, key, val)
+	}
+
+	exists, err := opts.BlobStore.Exists(name)
+	if err != nil {
+		return errors.Wrap(err, "error checking blob existence")
+	}
+	if exists {
+		bl, err := opts.BlobStore.Open(name)
+		if err != nil {
+			return errors.Wrap(err, "error opening blob")
+		}
+		data, err := ioutil.ReadAll(blob.NewReader(bl))
+		bl.Close()
+		if err != nil {
+			return errors.Wrap(err, "error reading blob")
+		}
+		if err := writeEntry(tw, path.Join(blobsEntryDir, name, blobEntry), data); err != nil {
+			return err
+		}
+	}
+	return writeEntry(tw, path.Join(blobsEntryDir, name, headerEntry), header.Bytes())
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error writing %s", name)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrapf(err, "error writing %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"bytes"
+	"fnd/blob"
+	"fnd/config"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/testutil/testcrypto"
+	"fnd/testutil/testfs"
+	"fnd/util"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io/ioutil"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestBackupRestore(t *testing.T) {
+	srcHome, srcHomeDone := testfs.NewTempDir(t)
+	defer srcHomeDone()
+	require.NoError(t, config.InitHomeDir(srcHome))
+	storage, storageDone := mockapp.CreateStorage(t)
+	defer storageDone()
+
+	signer := testcrypto.FixedSigner(t)
+	names := []string{"foo", "bar"}
+	for _, name := range names {
+		mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, name, time.Now(), time.Now())
+		require.NoError(t, store.WithTx(storage.DB, func(tx *leveldb.Transaction) error {
+			return store.SetNameInfoTx(tx, name, signer.Pub(), 10)
+		}))
+	}
+	_, err := store.Migrate(storage.DB)
+	require.NoError(t, err)
+
+	locker := util.NewMultiLocker()
+	var archive bytes.Buffer
+	manifest, err := Write(&archive, &Opts{
+		DB:         storage.DB,
+		BlobStore:  storage.BlobStore,
+		NameLocker: locker,
+		HomeDir:    srcHome,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(names), manifest.HeaderCount)
+	require.Equal(t, store.LatestSchemaVersion(), manifest.SchemaVersion)
+
+	destParent, destParentDone := testfs.NewTempDir(t)
+	defer destParentDone()
+	destHome := path.Join(destParent, "home")
+	require.NoError(t, config.InitHomeDir(destHome))
+
+	t.Run("rejects truncated archives without touching the home directory", func(t *testing.T) {
+		truncated := archive.Bytes()[:archive.Len()/2]
+		_, err := Restore(bytes.NewReader(truncated), destHome)
+		require.Error(t, err)
+		exists, err := config.HomeDirExists(destHome + ".restore")
+		require.NoError(t, err)
+		require.False(t, exists)
+		_, err = config.ReadNodeIdentity(destHome)
+		require.NoError(t, err)
+	})
+
+	srcID, err := ioutil.ReadFile(path.Join(srcHome, config.IdentityFilename))
+	require.NoError(t, err)
+	prevDestID, err := ioutil.ReadFile(path.Join(destHome, config.IdentityFilename))
+	require.NoError(t, err)
+
+	t.Run("restores the archive and keeps the previous home directory", func(t *testing.T) {
+		res, err := Restore(bytes.NewReader(archive.Bytes()), destHome)
+		require.NoError(t, err)
+		require.Equal(t, manifest.HeaderCount, res.Manifest.HeaderCount)
+		require.NotEmpty(t, res.PreviousHome)
+		_, err = config.ReadNodeIdentity(res.PreviousHome)
+		require.NoError(t, err)
+
+		// archives leave out the identity, so the current one is kept
+		destID, err := ioutil.ReadFile(path.Join(destHome, config.IdentityFilename))
+		require.NoError(t, err)
+		require.Equal(t, prevDestID, destID)
+
+		db, err := store.Open(config.ExpandDBPath(destHome))
+		require.NoError(t, err)
+		defer db.Close()
+		version, err := store.GetSchemaVersion(db)
+		require.NoError(t, err)
+		require.Equal(t, store.LatestSchemaVersion(), version)
+		bs := blob.NewStore(config.ExpandBlobsPath(destHome))
+		for _, name := range names {
+			srcHeader, err := store.GetHeader(storage.DB, name)
+			require.NoError(t, err)
+			destHeader, err := store.GetHeader(db, name)
+			require.NoError(t, err)
+			require.Equal(t, srcHeader.MerkleRoot, destHeader.MerkleRoot)
+			_, err = store.GetNameInfo(db, name)
+			require.NoError(t, err)
+			mockapp.RequireBlobsEqual(t, bs, storage.BlobStore, name)
+		}
+	})
+
+	t.Run("restores identities added from disk", func(t *testing.T) {
+		var withID bytes.Buffer
+		require.NoError(t, AddIdentity(&withID, bytes.NewReader(archive.Bytes()), srcHome))
+		require.Error(t, AddIdentity(ioutil.Discard, bytes.NewReader(withID.Bytes()), srcHome))
+		otherHome := path.Join(destParent, "other")
+		require.NoError(t, config.InitHomeDir(otherHome))
+		_, err := Restore(bytes.NewReader(withID.Bytes()), otherHome)
+		require.NoError(t, err)
+		destID, err := ioutil.ReadFile(path.Join(otherHome, config.IdentityFilename))
+		require.NoError(t, err)
+		require.Equal(t, srcID, destID)
+	})
+
+	t.Run("refuses to back up corrupted blobs", func(t *testing.T) {
+		bl, err := storage.BlobStore.Open("foo")
+		require.NoError(t, err)
+		tx, err := bl.Transaction()
+		require.NoError(t, err)
+		var sector blob.Sector
+		sector[0] = 0xff
+		require.NoError(t, tx.WriteSector(0, sector))
+		require.NoError(t, tx.Commit())
+		require.NoError(t, bl.Close())
+
+		_, err = Write(ioutil.Discard, &Opts{
+			DB:         storage.DB,
+			BlobStore:  storage.BlobStore,
+			NameLocker: locker,
+			HomeDir:    srcHome,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("times out on locked names", func(t *testing.T) {
+		require.True(t, locker.TryLock("bar"))
+		defer locker.Unlock("bar")
+		_, err := Write(ioutil.Discard, &Opts{
+			DB:          storage.DB,
+			BlobStore:   storage.BlobStore,
+			NameLocker:  locker,
+			HomeDir:     srcHome,
+			LockTimeout: 50 * time.Millisecond,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrNameLockTimeout.Error())
+	})
+}
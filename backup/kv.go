@@ -0,0 +1,58 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// database entries are archived as a sequence of uvarint length
+// prefixed keys and values.
+
+func writeKV(buf *bytes.Buffer, key []byte, val []byte) {
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(key)))
+	buf.Write(lenBuf[:n])
+	buf.Write(key)
+	n = binary.PutUvarint(lenBuf[:], uint64(len(val)))
+	buf.Write(lenBuf[:n])
+	buf.Write(val)
+}
+
+func readKVs(data []byte, cb func(key []byte, val []byte) error) error {
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		key, err := readField(r)
+		if err != nil {
+			return errors.Wrap(err, "error reading key")
+		}
+		val, err := readField(r)
+		if err != nil {
+			return errors.Wrap(err, "error reading value")
+		}
+		if err := cb(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readField(r *bytes.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if l > uint64(r.Len()) {
+		return nil, errors.New("field is truncated")
+	}
+	field := make([]byte, l)
+	if _, err := r.Read(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func paddedIndex(i int) string {
+	return fmt.Sprintf("%06d", i)
+}
@@ -0,0 +1,339 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"fnd.localhost/handshake/primitives"
+	"fnd/blob"
+	"fnd/config"
+	"fnd/protocol"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+type RestoreResult struct {
+	Manifest *Manifest
+	// PreviousHome is where the replaced home directory was moved.
+	// It is empty if there was no home directory to replace.
+	PreviousHome string
+}
+
+// Restore extracts the archive read from r next to homeDir and
+// validates it. Only once the archive is known to be good is homeDir
+// moved aside and replaced with it. The current node identity is kept
+// if the archive doesn't include one. fnd must not be running.
+func Restore(r io.Reader, homeDir string) (*RestoreResult, error) {
+	exists, err := config.HomeDirExists(homeDir)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		// fails if fnd holds the database lock
+		db, err := store.Open(config.ExpandDBPath(homeDir))
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening current database, is fnd running?")
+		}
+		db.Close()
+	}
+
+	staging := homeDir + ".restore"
+	manifest, err := Stage(r, staging)
+	if err != nil {
+		os.RemoveAll(staging)
+		return nil, err
+	}
+
+	res := &RestoreResult{
+		Manifest: manifest,
+	}
+	if exists {
+		if err := keepIdentity(homeDir, staging); err != nil {
+			os.RemoveAll(staging)
+			return nil, err
+		}
+		res.PreviousHome = fmt.Sprintf("%s.old-%d", homeDir, time.Now().Unix())
+		if err := os.Rename(homeDir, res.PreviousHome); err != nil {
+			return nil, errors.Wrap(err, "error moving current home directory")
+		}
+	}
+	if err := os.Rename(staging, homeDir); err != nil {
+		return nil, errors.Wrap(err, "error moving restored home directory")
+	}
+	return res, nil
+}
+
+// keepIdentity copies the current node identity into the restored
+// home directory if the archive didn't include one.
+func keepIdentity(homeDir string, staging string) error {
+	_, err := os.Stat(path.Join(staging, config.IdentityFilename))
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return errors.Wrap(err, "error checking restored identity")
+	}
+	identity, err := ioutil.ReadFile(path.Join(homeDir, config.IdentityFilename))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error reading current identity")
+	}
+	if err := ioutil.WriteFile(path.Join(staging, config.IdentityFilename), identity, 0600); err != nil {
+		return errors.Wrap(err, "error writing identity")
+	}
+	return nil
+}
+
+// Stage extracts the archive read from r into dir, which must not
+// exist yet, and validates it. Every header must be accompanied by a
+// blob that matches it.
+func Stage(r io.Reader, dir string) (*Manifest, error) {
+	if err := os.MkdirAll(path.Dir(dir), 0700); err != nil {
+		return nil, errors.Wrap(err, "error creating staging directory")
+	}
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "error creating staging directory")
+	}
+	s := &stager{
+		dir: dir,
+	}
+	defer s.close()
+	manifest, err := s.extract(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid archive")
+	}
+	if err := s.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid archive")
+	}
+	return manifest, nil
+}
+
+type stager struct {
+	dir         string
+	db          *leveldb.DB
+	bs          blob.Store
+	headerCount int
+}
+
+func (s *stager) extract(r io.Reader) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening archive")
+	}
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading manifest")
+	}
+	if hdr.Name != manifestEntry {
+		return nil, errors.New("archive does not start with a manifest")
+	}
+	manifest := new(Manifest)
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, errors.Wrap(err, "error decoding manifest")
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, errors.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+	if manifest.SchemaVersion > store.LatestSchemaVersion() {
+		return nil, store.ErrSchemaTooNew
+	}
+
+	db, err := store.Open(config.ExpandDBPath(s.dir))
+	if err != nil {
+		return nil, err
+	}
+	s.db = db
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading archive")
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %s", hdr.Name)
+		}
+		if err := s.extractEntry(hdr.Name, data); err != nil {
+			return nil, errors.Wrapf(err, "error extracting %s", hdr.Name)
+		}
+	}
+	if s.headerCount != manifest.HeaderCount {
+		return nil, errors.Errorf("archive has %d headers, expected %d", s.headerCount, manifest.HeaderCount)
+	}
+	return manifest, nil
+}
+
+func (s *stager) extractEntry(name string, data []byte) error {
+	if name == configEntry || name == config.IdentityFilename {
+		return ioutil.WriteFile(path.Join(s.dir, name), data, 0600)
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) == 2 && parts[0] == dbEntryDir {
+		batch := new(leveldb.Batch)
+		err := readKVs(data, func(key []byte, val []byte) error {
+			batch.Put(key, val)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return s.db.Write(batch, nil)
+	}
+
+	if len(parts) != 3 || parts[0] != blobsEntryDir {
+		return errors.New("unknown archive entry")
+	}
+	blobName := parts[1]
+	if err := primitives.ValidateName(blobName); err != nil {
+		return errors.Wrap(err, "invalid blob name")
+	}
+	switch parts[2] {
+	case blobEntry:
+		return s.extractBlob(blobName, data)
+	case headerEntry:
+		return s.extractHeader(blobName, data)
+	default:
+		return errors.New("unknown archive entry")
+	}
+}
+
+func (s *stager) extractBlob(name string, data []byte) error {
+	if len(data) != blob.Size {
+		return errors.New("blob has the wrong size")
+	}
+	bs, err := s.blobStore()
+	if err != nil {
+		return err
+	}
+	bl, err := bs.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error opening transaction")
+	}
+	for i := 0; i < blob.SectorCount; i++ {
+		var sector blob.Sector
+		copy(sector[:], data[i*blob.SectorLen:])
+		if sector == blob.ZeroSector {
+			continue
+		}
+		if err := tx.WriteSector(uint8(i), sector); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "error writing sector")
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *stager) extractHeader(name string, data []byte) error {
+	allowed := make(map[string]bool)
+	for _, key := range store.HeaderKeys(name) {
+		allowed[string(key)] = true
+	}
+	batch := new(leveldb.Batch)
+	err := readKVs(data, func(key []byte, val []byte) error {
+		if !allowed[string(key)] {
+			return errors.New("header entry contains unrelated keys")
+		}
+		batch.Put(key, val)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.headerCount++
+	return s.db.Write(batch, nil)
+}
+
+// blobStore opens the staged blob store with the backend set in the
+// archived config file, which comes before any blobs.
+func (s *stager) blobStore() (blob.Store, error) {
+	if s.bs != nil {
+		return s.bs, nil
+	}
+	backend := config.DefaultConfig.Storage.Backend
+	if _, err := os.Stat(path.Join(s.dir, configEntry)); err == nil {
+		cfg, err := config.ReadConfigFile(s.dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading archived config")
+		}
+		backend = cfg.Storage.Backend
+	}
+	blobsPath := config.ExpandBlobsPath(s.dir)
+	if err := os.MkdirAll(blobsPath, 0700); err != nil {
+		return nil, errors.Wrap(err, "error creating blobs directory")
+	}
+	bs, err := blob.OpenStore(backend, blobsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening blob store")
+	}
+	s.bs = bs
+	return bs, nil
+}
+
+func (s *stager) validate() error {
+	if _, err := os.Stat(path.Join(s.dir, configEntry)); err == nil {
+		if _, err := config.ReadConfigFile(s.dir); err != nil {
+			return errors.Wrap(err, "error reading archived config")
+		}
+	}
+	if _, err := os.Stat(path.Join(s.dir, config.IdentityFilename)); err == nil {
+		if _, err := config.ReadNodeIdentity(s.dir); err != nil {
+			return errors.Wrap(err, "error reading archived identity")
+		}
+	}
+
+	bs, err := s.blobStore()
+	if err != nil {
+		return err
+	}
+	stream, err := store.StreamHeaders(s.db)
+	if err != nil {
+		return errors.Wrap(err, "error opening header stream")
+	}
+	defer stream.Close()
+	for {
+		header, err := stream.Next()
+		if err != nil {
+			return errors.Wrap(err, "error reading header")
+		}
+		if header == nil {
+			return nil
+		}
+		res, err := protocol.VerifyBlob(s.db, bs, header.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error verifying %s", header.Name)
+		}
+		if res.Corrupt() {
+			return errors.Errorf("blob %s does not match its header", header.Name)
+		}
+	}
+}
+
+func (s *stager) close() {
+	if closer, ok := s.bs.(io.Closer); ok {
+		closer.Close()
+	}
+	if s.db != nil {
+		s.db.Close()
+	}
+}
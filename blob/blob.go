@@ -3,7 +3,6 @@ package blob
 import (
 	"github.com/pkg/errors"
 	"io"
-	"io/ioutil"
 	"os"
 	"sync"
 )
@@ -56,9 +55,10 @@ func (b *blobImpl) ReadAt(p []byte, off int64) (int, error) {
 func (b *blobImpl) Transaction() (Transaction, error) {
 	return &txImpl{
 		name:      b.name,
-		cloner:    b.txCloner,
+		reader:    b.ReadSector,
 		committer: b.txCommitter,
 		remover:   b.txRemover,
+		dirty:     make(map[uint8]*Sector),
 	}, nil
 }
 
@@ -68,33 +68,21 @@ func (b *blobImpl) Close() error {
 	return b.f.Close()
 }
 
-func (b *blobImpl) txCloner() (*os.File, error) {
+func (b *blobImpl) txCommitter(dirty map[uint8]*Sector, truncated bool) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	clone, err := ioutil.TempFile("", "blobtx_*")
-	if err != nil {
-		return nil, errors.Wrap(err, "error cloning blob")
+	if truncated {
+		if err := b.f.Truncate(0); err != nil {
+			return errors.Wrap(err, "error committing blob")
+		}
+		if err := b.f.Truncate(Size); err != nil {
+			return errors.Wrap(err, "error committing blob")
+		}
 	}
-	if _, err := b.f.Seek(0, io.SeekStart); err != nil {
-		return nil, errors.Wrap(err, "error cloning blob")
-	}
-	if _, err := io.Copy(clone, b.f); err != nil {
-		return nil, errors.Wrap(err, "error cloning blob")
-	}
-	return clone, nil
-}
-
-func (b *blobImpl) txCommitter(clone *os.File) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if _, err := clone.Seek(0, io.SeekStart); err != nil {
-		return errors.Wrap(err, "error committing blob")
-	}
-	if _, err := b.f.Seek(0, io.SeekStart); err != nil {
-		return errors.Wrap(err, "error committing blob")
-	}
-	if _, err := io.Copy(b.f, clone); err != nil {
-		return errors.Wrap(err, "error committing blob")
+	for id, sector := range dirty {
+		if err := WriteSector(b.f, id, *sector); err != nil {
+			return errors.Wrap(err, "error committing blob")
+		}
 	}
 	return nil
 }
@@ -202,6 +202,67 @@ func newMerkleTreeFromHashedLeaves(base []crypto.Hash) (MerkleTree, error) {
 	return tree, nil
 }
 
+// UpdateMerkleTree returns a copy of prev with the transaction's
+// dirty sectors applied. Only the paths from dirty sectors to the
+// root are rehashed. A nil prev is treated as an empty blob.
+func UpdateMerkleTree(prev MerkleTree, tx Transaction) (MerkleTree, error) {
+	dirty, truncated := tx.DirtySectors()
+	var tree MerkleTree
+	if prev == nil || truncated {
+		tree = EmptyBlobMerkleTree()
+	} else {
+		tree = prev.Clone()
+	}
+	leaves := make(map[uint8]crypto.Hash)
+	for _, id := range dirty {
+		sector, err := tx.ReadSector(id)
+		if err != nil {
+			return nil, err
+		}
+		leaves[id] = HashSector(sector)
+	}
+	tree.UpdateBase(leaves)
+	return tree, nil
+}
+
+func EmptyBlobMerkleTree() MerkleTree {
+	var base MerkleBase
+	for i := 0; i < len(base); i++ {
+		base[i] = zero4kSectorHash
+	}
+	return MakeTreeFromBase(base)
+}
+
+// UpdateBase replaces the given protocol base hashes in place and
+// rehashes their paths to the root.
+func (t MerkleTree) UpdateBase(leaves map[uint8]crypto.Hash) {
+	if len(leaves) == 0 {
+		return
+	}
+	base := len(t) - 1
+	dirty := make(map[int]bool)
+	for id, hash := range leaves {
+		t[base][id] = hash
+		dirty[int(id)/2] = true
+	}
+	for i := base - 1; i >= 0; i-- {
+		next := make(map[int]bool)
+		for pos := range dirty {
+			t[i][pos] = hashLevel(t[i+1][pos*2], t[i+1][pos*2+1])
+			next[pos/2] = true
+		}
+		dirty = next
+	}
+}
+
+func (t MerkleTree) Clone() MerkleTree {
+	out := make(MerkleTree, len(t))
+	for i := 0; i < len(t); i++ {
+		out[i] = t.Level(i)
+	}
+	return out
+}
+
 func (t MerkleTree) Root() crypto.Hash {
 	return t[0][0]
 }
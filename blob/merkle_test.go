@@ -98,3 +98,43 @@ func TestProtocolBase(t *testing.T) {
 		require.Equal(t, "532a12f09febf8521419959973ad5346944c2b22bf764d0e1a34255b6564fe4b", hex.EncodeToString(base[i][:]))
 	}
 }
+
+func TestUpdateMerkleTree(t *testing.T) {
+	f, done := newTempBlobFile(t)
+	defer done()
+
+	blob := newFromFile("foobar", f)
+	tx, err := blob.Transaction()
+	require.NoError(t, err)
+	_, err = io.CopyN(NewWriter(tx), rand.Reader, Size)
+	require.NoError(t, err)
+	tree, err := UpdateMerkleTree(nil, tx)
+	require.NoError(t, err)
+	expTree, err := Merkleize(NewReader(tx))
+	require.NoError(t, err)
+	require.Equal(t, expTree, tree)
+	require.NoError(t, tx.Commit())
+
+	tx, err = blob.Transaction()
+	require.NoError(t, err)
+	var sector Sector
+	_, err = rand.Read(sector[:])
+	require.NoError(t, err)
+	require.NoError(t, tx.WriteSector(17, sector))
+	require.NoError(t, tx.WriteSector(200, ZeroSector))
+	updated, err := UpdateMerkleTree(tree, tx)
+	require.NoError(t, err)
+	expTree, err = Merkleize(NewReader(tx))
+	require.NoError(t, err)
+	require.Equal(t, expTree, updated)
+	require.NotEqual(t, tree.Root(), updated.Root())
+
+	require.NoError(t, tx.Truncate())
+	updated, err = UpdateMerkleTree(tree, tx)
+	require.NoError(t, err)
+	expTree, err = Merkleize(NewReader(tx))
+	require.NoError(t, err)
+	require.Equal(t, expTree, updated)
+	require.Equal(t, EmptyBlobMerkleTree(), updated)
+	require.NoError(t, tx.Rollback())
+}
@@ -0,0 +1,297 @@
+package blob
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"golang.org/x/crypto/blake2b"
+	"io"
+	"path"
+	"sync"
+)
+
+const (
+	PackedStorePath = "packed"
+
+	sectorHashLen  = 32
+	sectorIndexLen = SectorCount * sectorHashLen
+)
+
+var (
+	packedIndexPrefix  = []byte("index/")
+	packedSectorPrefix = []byte("sector/")
+	packedRefsPrefix   = []byte("refs/")
+)
+
+// sectorIndex maps each of a blob's sectors to the hash of its
+// content. An all-zero hash denotes ZeroSector, which is never
+// stored.
+type sectorIndex [SectorCount][sectorHashLen]byte
+
+var zeroSectorHash [sectorHashLen]byte
+
+// packedStore is a content-addressed blob store. Sectors are
+// deduplicated across names and reference counted, and zero
+// sectors are stored implicitly.
+type packedStore struct {
+	db   *leveldb.DB
+	pool *Pool
+	mu   sync.Mutex
+}
+
+func NewPackedStore(blobsPath string) (*packedStore, error) {
+	db, err := leveldb.OpenFile(path.Join(blobsPath, PackedStorePath), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening packed store")
+	}
+	s := &packedStore{
+		db: db,
+	}
+	s.pool = NewPool(func(name string) (Blob, error) {
+		return &packedBlob{
+			name:  name,
+			store: s,
+		}, nil
+	})
+	return s, nil
+}
+
+func (s *packedStore) Open(name string) (Blob, error) {
+	blob, err := s.pool.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedBlob{
+		pool: s.pool,
+		blob: blob,
+	}, nil
+}
+
+func (s *packedStore) Exists(name string) (bool, error) {
+	return s.db.Has(packedIndexKey(name), nil)
+}
+
+func (s *packedStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *packedStore) readSector(name string, id uint8) (Sector, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, err := s.readIndex(name)
+	if err != nil {
+		return ZeroSector, err
+	}
+	hash := idx[id]
+	if hash == zeroSectorHash {
+		return ZeroSector, nil
+	}
+	data, err := s.db.Get(packedSectorKey(hash), nil)
+	if err != nil {
+		return ZeroSector, errors.Wrap(err, "error reading packed sector")
+	}
+	var sector Sector
+	copy(sector[:], data)
+	return sector, nil
+}
+
+func (s *packedStore) commit(name string, dirty map[uint8]*Sector, truncated bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oldIdx, err := s.readIndex(name)
+	if err != nil {
+		return err
+	}
+	newIdx := oldIdx
+	if truncated {
+		newIdx = sectorIndex{}
+	}
+	sectors := make(map[[sectorHashLen]byte]*Sector)
+	for id, sector := range dirty {
+		if *sector == ZeroSector {
+			newIdx[id] = zeroSectorHash
+			continue
+		}
+		hash := blake2b.Sum256(sector[:])
+		newIdx[id] = hash
+		sectors[hash] = sector
+	}
+
+	deltas := make(map[[sectorHashLen]byte]int)
+	for i := 0; i < SectorCount; i++ {
+		if oldIdx[i] == newIdx[i] {
+			continue
+		}
+		deltas[oldIdx[i]]--
+		deltas[newIdx[i]]++
+	}
+
+	batch := new(leveldb.Batch)
+	if err := s.applyRefDeltas(batch, deltas, sectors); err != nil {
+		return err
+	}
+	batch.Put(packedIndexKey(name), encodeSectorIndex(&newIdx))
+	if err := s.db.Write(batch, nil); err != nil {
+		return errors.Wrap(err, "error writing packed sectors")
+	}
+	return nil
+}
+
+func (s *packedStore) remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, err := s.readIndex(name)
+	if err != nil {
+		return err
+	}
+	deltas := make(map[[sectorHashLen]byte]int)
+	for i := 0; i < SectorCount; i++ {
+		deltas[idx[i]]--
+	}
+	batch := new(leveldb.Batch)
+	if err := s.applyRefDeltas(batch, deltas, nil); err != nil {
+		return err
+	}
+	batch.Delete(packedIndexKey(name))
+	if err := s.db.Write(batch, nil); err != nil {
+		return errors.Wrap(err, "error removing packed blob")
+	}
+	return nil
+}
+
+func (s *packedStore) applyRefDeltas(batch *leveldb.Batch, deltas map[[sectorHashLen]byte]int, sectors map[[sectorHashLen]byte]*Sector) error {
+	for hash, delta := range deltas {
+		if hash == zeroSectorHash || delta == 0 {
+			continue
+		}
+		refs, err := s.readRefs(hash)
+		if err != nil {
+			return err
+		}
+		newRefs := int(refs) + delta
+		if newRefs < 0 {
+			return errors.New("negative sector reference count")
+		}
+		if newRefs == 0 {
+			batch.Delete(packedRefsKey(hash))
+			batch.Delete(packedSectorKey(hash))
+			continue
+		}
+		if refs == 0 {
+			sector := sectors[hash]
+			if sector == nil {
+				return errors.New("missing data for new sector")
+			}
+			batch.Put(packedSectorKey(hash), sector[:])
+		}
+		refsB := make([]byte, 4)
+		binary.BigEndian.PutUint32(refsB, uint32(newRefs))
+		batch.Put(packedRefsKey(hash), refsB)
+	}
+	return nil
+}
+
+func (s *packedStore) readIndex(name string) (sectorIndex, error) {
+	var idx sectorIndex
+	data, err := s.db.Get(packedIndexKey(name), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return idx, nil
+	}
+	if err != nil {
+		return idx, errors.Wrap(err, "error reading sector index")
+	}
+	if len(data) != sectorIndexLen {
+		return idx, errors.New("invalid sector index length")
+	}
+	for i := 0; i < SectorCount; i++ {
+		copy(idx[i][:], data[i*sectorHashLen:])
+	}
+	return idx, nil
+}
+
+func (s *packedStore) readRefs(hash [sectorHashLen]byte) (uint32, error) {
+	data, err := s.db.Get(packedRefsKey(hash), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error reading sector references")
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+
+func encodeSectorIndex(idx *sectorIndex) []byte {
+	out := make([]byte, 0, sectorIndexLen)
+	for i := 0; i < SectorCount; i++ {
+		out = append(out, idx[i][:]...)
+	}
+	return out
+}
+
+func packedIndexKey(name string) []byte {
+	return append(append([]byte{}, packedIndexPrefix...), name...)
+}
+
+func packedSectorKey(hash [sectorHashLen]byte) []byte {
+	return append(append([]byte{}, packedSectorPrefix...), hash[:]...)
+}
+
+func packedRefsKey(hash [sectorHashLen]byte) []byte {
+	return append(append([]byte{}, packedRefsPrefix...), hash[:]...)
+}
+
+type packedBlob struct {
+	name  string
+	store *packedStore
+}
+
+func (p *packedBlob) Name() string {
+	return p.name
+}
+
+func (p *packedBlob) ReadSector(id uint8) (Sector, error) {
+	return p.store.readSector(p.name, id)
+}
+
+func (p *packedBlob) ReadAt(b []byte, off int64) (int, error) {
+	return ReadBlobAt(&sectorReaderAt{p}, b, off)
+}
+
+func (p *packedBlob) Transaction() (Transaction, error) {
+	return &txImpl{
+		name:   p.name,
+		reader: p.ReadSector,
+		committer: func(dirty map[uint8]*Sector, truncated bool) error {
+			return p.store.commit(p.name, dirty, truncated)
+		},
+		remover: func() error {
+			return p.store.remove(p.name)
+		},
+		dirty: make(map[uint8]*Sector),
+	}, nil
+}
+
+func (p *packedBlob) Close() error {
+	return nil
+}
+
+// sectorReaderAt adapts a SectorReader to io.ReaderAt.
+type sectorReaderAt struct {
+	r SectorReader
+}
+
+func (s *sectorReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= Size {
+			return n, io.EOF
+		}
+		sector, err := s.r.ReadSector(uint8(pos / SectorLen))
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], sector[pos%SectorLen:])
+	}
+	return n, nil
+}
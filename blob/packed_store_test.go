@@ -0,0 +1,114 @@
+package blob
+
+import (
+	"crypto/rand"
+	"fnd/testutil/testfs"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"golang.org/x/crypto/blake2b"
+	"io"
+	"testing"
+)
+
+func TestPackedStore(t *testing.T) {
+	dir, done := testfs.NewTempDir(t)
+	defer done()
+
+	store, err := NewPackedStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	exists, err := store.Exists("fooname")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	blob, err := store.Open("fooname")
+	require.NoError(t, err)
+	h, _ := blake2b.New256(nil)
+	tx, err := blob.Transaction()
+	require.NoError(t, err)
+	_, err = io.CopyN(NewWriter(tx), io.TeeReader(rand.Reader, h), Size)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	hash := h.Sum(nil)
+	require.NoError(t, blob.Close())
+
+	exists, err = store.Exists("fooname")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	h.Reset()
+	blob, err = store.Open("fooname")
+	require.NoError(t, err)
+	_, err = io.Copy(h, NewReader(blob))
+	require.NoError(t, err)
+	require.Equal(t, hash, h.Sum(nil))
+	require.NoError(t, blob.Close())
+}
+
+func TestPackedStore_Dedupe(t *testing.T) {
+	dir, done := testfs.NewTempDir(t)
+	defer done()
+
+	store, err := NewPackedStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	var sector Sector
+	_, err = rand.Read(sector[:])
+	require.NoError(t, err)
+
+	for _, name := range []string{"foo", "bar"} {
+		blob, err := store.Open(name)
+		require.NoError(t, err)
+		tx, err := blob.Transaction()
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteSector(0, sector))
+		require.NoError(t, tx.WriteSector(1, sector))
+		require.NoError(t, tx.WriteSector(2, ZeroSector))
+		require.NoError(t, tx.Commit())
+		require.NoError(t, blob.Close())
+	}
+	require.Equal(t, 1, countKeys(t, store, packedSectorPrefix))
+	refs, err := store.readRefs(blake2b.Sum256(sector[:]))
+	require.NoError(t, err)
+	require.EqualValues(t, 4, refs)
+
+	blob, err := store.Open("foo")
+	require.NoError(t, err)
+	tx, err := blob.Transaction()
+	require.NoError(t, err)
+	require.NoError(t, tx.Remove())
+	require.NoError(t, tx.Commit())
+	require.NoError(t, blob.Close())
+	refs, err = store.readRefs(blake2b.Sum256(sector[:]))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, refs)
+
+	blob, err = store.Open("bar")
+	require.NoError(t, err)
+	actSector, err := blob.ReadSector(1)
+	require.NoError(t, err)
+	require.Equal(t, sector, actSector)
+	tx, err = blob.Transaction()
+	require.NoError(t, err)
+	require.NoError(t, tx.Truncate())
+	require.NoError(t, tx.Commit())
+	actSector, err = blob.ReadSector(1)
+	require.NoError(t, err)
+	require.Equal(t, ZeroSector, actSector)
+	require.NoError(t, blob.Close())
+	require.Equal(t, 0, countKeys(t, store, packedSectorPrefix))
+	require.Equal(t, 0, countKeys(t, store, packedRefsPrefix))
+}
+
+func countKeys(t *testing.T, store *packedStore, prefix []byte) int {
+	iter := store.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	var count int
+	for iter.Next() {
+		count++
+	}
+	require.NoError(t, iter.Error())
+	return count
+}
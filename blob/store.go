@@ -2,6 +2,7 @@ package blob
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -12,6 +13,26 @@ type Store interface {
 	Exists(name string) (bool, error)
 }
 
+const (
+	BackendFile   = "file"
+	BackendPacked = "packed"
+)
+
+func OpenStore(backend string, blobsPath string) (Store, error) {
+	switch backend {
+	case "", BackendFile:
+		return NewStore(blobsPath), nil
+	case BackendPacked:
+		s, err := NewPackedStore(blobsPath)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown blob store backend %s", backend)
+	}
+}
+
 type storeImpl struct {
 	blobsPath string
 	pool      *Pool
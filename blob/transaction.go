@@ -3,8 +3,7 @@ package blob
 import (
 	"github.com/pkg/errors"
 	"io"
-	"io/ioutil"
-	"os"
+	"sort"
 	"sync"
 )
 
@@ -18,21 +17,26 @@ type Transaction interface {
 	io.WriterAt
 	WriteSector(id uint8, sector Sector) error
 	Truncate() error
+	DirtySectors() ([]uint8, bool)
 	Commit() error
 	Rollback() error
 	Remove() error
 }
 
+// txImpl stages writes in an in-memory overlay of dirty
+// sectors. Reads of clean sectors fall through to the
+// underlying blob, and commits only write back the sectors
+// that were actually changed.
 type txImpl struct {
-	name        string
-	f           *os.File
-	mu          sync.Mutex
-	cloner      func() (*os.File, error)
-	committer   func(clone *os.File) error
-	remover     func() error
-	initialized bool
-	closed      bool
-	removed     bool
+	name      string
+	mu        sync.Mutex
+	reader    func(id uint8) (Sector, error)
+	committer func(dirty map[uint8]*Sector, truncated bool) error
+	remover   func() error
+	dirty     map[uint8]*Sector
+	truncated bool
+	closed    bool
+	removed   bool
 }
 
 func (t *txImpl) Name() string {
@@ -48,10 +52,7 @@ func (t *txImpl) ReadSector(id uint8) (Sector, error) {
 	if t.removed {
 		return ZeroSector, ErrTransactionRemoved
 	}
-	if err := t.lazyInitialize(); err != nil {
-		return ZeroSector, errors.Wrap(err, "error initializing transaction")
-	}
-	return ReadSector(t.f, id)
+	return t.readSector(id)
 }
 
 func (t *txImpl) ReadAt(p []byte, off int64) (int, error) {
@@ -63,10 +64,7 @@ func (t *txImpl) ReadAt(p []byte, off int64) (int, error) {
 	if t.removed {
 		return 0, ErrTransactionRemoved
 	}
-	if err := t.lazyInitialize(); err != nil {
-		return 0, errors.Wrap(err, "error initializing transaction")
-	}
-	return ReadBlobAt(t.f, p, off)
+	return ReadBlobAt(&overlayIO{t}, p, off)
 }
 
 func (t *txImpl) WriteSector(id uint8, sector Sector) error {
@@ -78,10 +76,8 @@ func (t *txImpl) WriteSector(id uint8, sector Sector) error {
 	if t.removed {
 		return ErrTransactionRemoved
 	}
-	if err := t.lazyInitialize(); err != nil {
-		return errors.Wrap(err, "error initializing transaction")
-	}
-	return WriteSector(t.f, id, sector)
+	t.dirty[id] = &sector
+	return nil
 }
 
 func (t *txImpl) WriteAt(p []byte, off int64) (int, error) {
@@ -93,10 +89,7 @@ func (t *txImpl) WriteAt(p []byte, off int64) (int, error) {
 	if t.removed {
 		return 0, ErrTransactionRemoved
 	}
-	if err := t.lazyInitialize(); err != nil {
-		return 0, errors.Wrap(err, "error initializing transaction")
-	}
-	return WriteBlobAt(t.f, p, off)
+	return WriteBlobAt(&overlayIO{t}, p, off)
 }
 
 func (t *txImpl) Truncate() error {
@@ -105,23 +98,24 @@ func (t *txImpl) Truncate() error {
 	if t.removed {
 		return ErrTransactionRemoved
 	}
-	if t.initialized {
-		if err := os.Remove(t.f.Name()); err != nil {
-			return err
-		}
-	}
-	clone, err := ioutil.TempFile("", "blobtx_*")
-	if err != nil {
-		return errors.Wrap(err, "error creating temporary file")
-	}
-	if err := clone.Truncate(Size); err != nil {
-		return errors.Wrap(err, "error truncating file")
-	}
-	t.f = clone
-	t.initialized = true
+	t.dirty = make(map[uint8]*Sector)
+	t.truncated = true
 	return nil
 }
 
+func (t *txImpl) DirtySectors() ([]uint8, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]uint8, 0, len(t.dirty))
+	for id := range t.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids, t.truncated
+}
+
 func (t *txImpl) Commit() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -134,19 +128,13 @@ func (t *txImpl) Commit() error {
 		if err := t.remover(); err != nil {
 			panic(err)
 		}
-	} else if t.initialized {
-		if err := t.committer(t.f); err != nil {
-			panic(err)
-		}
-		if err := t.f.Close(); err != nil {
-			panic(err)
-		}
-		if err := os.Remove(t.f.Name()); err != nil {
+	} else if len(t.dirty) > 0 || t.truncated {
+		if err := t.committer(t.dirty, t.truncated); err != nil {
 			panic(err)
 		}
 	}
 	// end atomic section
-	t.closed = true
+	t.release()
 	return nil
 }
 
@@ -156,17 +144,7 @@ func (t *txImpl) Rollback() error {
 	if t.closed {
 		return ErrTransactionClosed
 	}
-	if !t.initialized || t.removed {
-		t.closed = true
-		return nil
-	}
-	if err := t.f.Close(); err != nil {
-		panic(err)
-	}
-	if err := os.Remove(t.f.Name()); err != nil {
-		panic(err)
-	}
-	t.closed = true
+	t.release()
 	return nil
 }
 
@@ -177,27 +155,62 @@ func (t *txImpl) Remove() error {
 		return ErrTransactionClosed
 	}
 	t.removed = true
-	if !t.initialized {
-		return nil
+	t.dirty = nil
+	return nil
+}
+
+func (t *txImpl) readSector(id uint8) (Sector, error) {
+	if sector, ok := t.dirty[id]; ok {
+		return *sector, nil
 	}
-	if err := t.f.Close(); err != nil {
-		panic(err)
+	if t.truncated {
+		return ZeroSector, nil
 	}
-	if err := os.Remove(t.f.Name()); err != nil {
-		panic(err)
+	sector, err := t.reader(id)
+	if err != nil {
+		return ZeroSector, errors.Wrap(err, "error reading base sector")
 	}
-	return nil
+	return sector, nil
 }
 
-func (t *txImpl) lazyInitialize() error {
-	if t.initialized {
-		return nil
+func (t *txImpl) release() {
+	t.dirty = nil
+	t.closed = true
+}
+
+// overlayIO adapts a txImpl's overlay to io.ReaderAt and
+// io.WriterAt. Callers must hold the transaction's lock.
+type overlayIO struct {
+	t *txImpl
+}
+
+func (o *overlayIO) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		id := uint8(pos / SectorLen)
+		sectorOff := int(pos % SectorLen)
+		sector, err := o.t.readSector(id)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], sector[sectorOff:])
 	}
-	clone, err := t.cloner()
-	if err != nil {
-		return errors.Wrap(err, "error initializing transaction")
+	return n, nil
+}
+
+func (o *overlayIO) WriteAt(p []byte, off int64) (int, error) {
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		id := uint8(pos / SectorLen)
+		sectorOff := int(pos % SectorLen)
+		sector, err := o.t.readSector(id)
+		if err != nil {
+			return n, err
+		}
+		n += copy(sector[sectorOff:], p[n:])
+		o.t.dirty[id] = &sector
 	}
-	t.f = clone
-	t.initialized = true
-	return nil
+	return n, nil
 }
@@ -77,6 +77,46 @@ func TestBlob_Transaction_Truncation_Uninitialized(t *testing.T) {
 	require.Equal(t, ZeroSector, sector)
 }
 
+func TestBlob_Transaction_Overlay(t *testing.T) {
+	f, done := newTempBlobFile(t)
+	defer done()
+
+	_, err := io.CopyN(f, rand.Reader, Size)
+	require.NoError(t, err)
+
+	blob := newFromFile("whatever", f)
+	baseSector, err := blob.ReadSector(1)
+	require.NoError(t, err)
+
+	tx, err := blob.Transaction()
+	require.NoError(t, err)
+	var sector Sector
+	_, err = rand.Read(sector[:])
+	require.NoError(t, err)
+	require.NoError(t, tx.WriteSector(0, sector))
+	_, err = tx.WriteAt([]byte{0x01, 0x02}, SectorLen*2+SectorLen-1)
+	require.NoError(t, err)
+
+	actSector, err := tx.ReadSector(1)
+	require.NoError(t, err)
+	require.Equal(t, baseSector, actSector)
+	impl := tx.(*txImpl)
+	require.Len(t, impl.dirty, 3)
+
+	baseSector, err = blob.ReadSector(0)
+	require.NoError(t, err)
+	require.NotEqual(t, sector, baseSector)
+
+	require.NoError(t, tx.Commit())
+	actSector, err = blob.ReadSector(0)
+	require.NoError(t, err)
+	require.Equal(t, sector, actSector)
+	buf := make([]byte, 2)
+	_, err = blob.ReadAt(buf, SectorLen*2+SectorLen-1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, buf)
+}
+
 func TestBlob_Transaction_Remove(t *testing.T) {
 	// source file gets removed by tx.Commit(), so no need
 	// to defer done()
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"fnd/backup"
+	"fnd/cli"
+	"fnd/config"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+var (
+	backupFndHome         string
+	backupIncludeIdentity bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <file>",
+	Short: "Writes an archive of a running node's database, blobs, and config.",
+	Long: `Writes an archive of a running node's database, blobs, and config.
+The node's identity holds its private key, so it is only archived with
+--include-identity, which reads it from fnd's home directory on this machine.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		// write to a temporary file so that a failed backup doesn't
+		// leave a partial archive behind
+		tmpPath := args[0] + ".partial"
+		f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return errors.Wrap(err, "error creating archive")
+		}
+		if backupIncludeIdentity {
+			err = backupWithIdentity(grpcClient, f, config.ExpandHomePath(backupFndHome))
+		} else {
+			err = rpc.Backup(grpcClient, f)
+		}
+		if err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+		if err := f.Close(); err != nil {
+			os.Remove(tmpPath)
+			return errors.Wrap(err, "error closing archive")
+		}
+		if err := os.Rename(tmpPath, args[0]); err != nil {
+			return errors.Wrap(err, "error moving archive into place")
+		}
+		fmt.Printf("Wrote backup to %s.\n", args[0])
+		return nil
+	},
+}
+
+// backupWithIdentity adds the node identity to the archive on its way
+// to w.
+func backupWithIdentity(client apiv1.Footnotev1Client, w io.Writer, homeDir string) error {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := backup.AddIdentity(w, pr, homeDir)
+		// unblocks the backup if adding the identity fails
+		pr.CloseWithError(err)
+		done <- err
+	}()
+	err := rpc.Backup(client, pw)
+	pw.CloseWithError(err)
+	if identityErr := <-done; identityErr != nil {
+		return identityErr
+	}
+	return err
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupFndHome, "fnd-home", "~/.fnd", "Path to FootnoteD's home directory.")
+	backupCmd.Flags().BoolVar(&backupIncludeIdentity, "include-identity", false, "Adds the node's identity, including its private key, to the archive.")
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <name> <file>",
+	Short: "Writes a blob and its signed header to a bundle file.",
+	Long: `Writes a blob and its signed header to a bundle file. Bundles can
+be imported with fnd-cli blob import by nodes that cannot sync the
+blob from the network.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		bundle, err := rpc.ExportBundle(apiv1.NewFootnotev1Client(conn), args[0])
+		if err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(args[1], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return errors.Wrap(err, "error creating bundle")
+		}
+		if err := bundle.Encode(f); err != nil {
+			f.Close()
+			os.Remove(args[1])
+			return errors.Wrap(err, "error writing bundle")
+		}
+		if err := f.Close(); err != nil {
+			return errors.Wrap(err, "error closing bundle")
+		}
+		fmt.Printf("Exported %s with %d sectors to %s.\n", bundle.Name, len(bundle.Sectors), args[1])
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(exportCmd)
+}
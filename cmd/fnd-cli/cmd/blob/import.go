@@ -0,0 +1,50 @@
+package blob
+
+import (
+	"bufio"
+	"fmt"
+	"fnd/cli"
+	"fnd/protocol"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var importBroadcast bool
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Imports a bundle written by fnd-cli blob export.",
+	Long: `Imports a bundle written by fnd-cli blob export. The bundle must be
+signed by the name's current public key and is subject to the same
+timestamp and timebank checks as updates received from peers.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error opening bundle")
+		}
+		defer f.Close()
+		bundle := new(protocol.Bundle)
+		if err := bundle.Decode(bufio.NewReader(f)); err != nil {
+			return err
+		}
+
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		if err := rpc.ImportBundle(apiv1.NewFootnotev1Client(conn), bundle, importBroadcast); err != nil {
+			return err
+		}
+		fmt.Printf("Imported %s.\n", bundle.Name)
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importBroadcast, BroadcastFlag, false, "Broadcast the imported update to the network")
+	cmd.AddCommand(importCmd)
+}
@@ -0,0 +1,40 @@
+package blob
+
+import (
+	"fmt"
+	"fnd/cli"
+	"github.com/spf13/cobra"
+)
+
+var signCmd = &cobra.Command{
+	Use:   "sign <request-file>",
+	Short: "Signs a commit request written by fnd-cli blob stage.",
+	Long: `Signs a commit request written by fnd-cli blob stage with the
+identity in --fnd-home, and writes the signature back to the file.
+This command doesn't connect to a node, so it can run on an
+air-gapped machine.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		signer, err := cli.GetSigner(cli.GetHomeDir(cmd))
+		if err != nil {
+			return err
+		}
+		req, err := readCommitRequest(args[0])
+		if err != nil {
+			return err
+		}
+		if err := req.Sign(signer); err != nil {
+			return err
+		}
+		if err := writeCommitRequest(args[0], req); err != nil {
+			return err
+		}
+
+		fmt.Printf("Signed commit request for %s.\n", req.Name)
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(signCmd)
+}
@@ -0,0 +1,105 @@
+package blob
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+var (
+	stageTruncate bool
+	stageTTL      time.Duration
+)
+
+var stageCmd = &cobra.Command{
+	Use:   "stage <name> <request-file> <data?>",
+	Short: "Writes data to the specified blob without committing it.",
+	Long: `Writes data to the specified blob without committing it, and
+writes an unsigned commit request to request-file. Sign the request
+with fnd-cli blob sign, which doesn't need access to the node, then
+commit it with fnd-cli blob submit.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+
+		name := args[0]
+		// the writer only signs on Commit, which is never called
+		wr := rpc.NewBlobWriter(apiv1.NewFootnotev1Client(conn), nil, name)
+		wr.TTL = stageTTL
+		if err := wr.Open(); err != nil {
+			return err
+		}
+		if stageTruncate {
+			if err := wr.Truncate(); err != nil {
+				return err
+			}
+		}
+		var rd io.Reader
+		if len(args) < 3 {
+			if isatty.IsTerminal(os.Stdin.Fd()) {
+				rd = bufio.NewReader(bytes.NewReader(readDataTTY()))
+			} else {
+				rd = os.Stdin
+			}
+		} else {
+			rd = bufio.NewReader(bytes.NewReader([]byte(args[2])))
+		}
+		if _, err := io.Copy(wr, rd); err != nil {
+			return err
+		}
+		req, err := wr.Stage()
+		if err != nil {
+			return err
+		}
+		if err := writeCommitRequest(args[1], req); err != nil {
+			return err
+		}
+
+		fmt.Printf("Wrote commit request to %s.\n", args[1])
+		fmt.Printf("Seal hash: %s\n", req.SealHash)
+		return nil
+	},
+}
+
+func readCommitRequest(path string) (*rpc.CommitRequest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading commit request")
+	}
+	req := new(rpc.CommitRequest)
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, errors.Wrap(err, "error decoding commit request")
+	}
+	return req, nil
+}
+
+func writeCommitRequest(path string, req *rpc.CommitRequest) error {
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error encoding commit request")
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "error writing commit request")
+	}
+	return nil
+}
+
+func init() {
+	stageCmd.Flags().BoolVar(&stageTruncate, TruncateFlag, false, "Truncate the blob before writing")
+	stageCmd.Flags().DurationVar(&stageTTL, TTLFlag, 0, "How long the node keeps the transaction without activity")
+	cmd.AddCommand(stageCmd)
+}
@@ -0,0 +1,40 @@
+package blob
+
+import (
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+)
+
+var submitBroadcast bool
+
+var submitCmd = &cobra.Command{
+	Use:   "submit <request-file>",
+	Short: "Commits a blob staged with fnd-cli blob stage.",
+	Long: `Commits a blob staged with fnd-cli blob stage, using the commit
+request signed by fnd-cli blob sign.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := readCommitRequest(args[0])
+		if err != nil {
+			return err
+		}
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		if err := rpc.SubmitCommit(apiv1.NewFootnotev1Client(conn), req, submitBroadcast); err != nil {
+			return err
+		}
+
+		fmt.Println("Success.")
+		return nil
+	},
+}
+
+func init() {
+	submitCmd.Flags().BoolVar(&submitBroadcast, BroadcastFlag, true, "Broadcast data to the network upon completion")
+	cmd.AddCommand(submitCmd)
+}
@@ -0,0 +1,138 @@
+package blob
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd.localhost/handshake/primitives"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var verifyRepair bool
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <names?>",
+	Short: "Checks stored blobs for corruption, bad signatures, and orphans.",
+	Long: `Checks stored blobs for corruption, bad signatures, and orphans.
+Verifies every stored blob unless a comma-separated list of names is
+given. With --repair, corrupted blobs are re-synced from peers.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var names []string
+		if len(args) == 1 {
+			names = strings.Split(args[0], ",")
+			for _, name := range names {
+				if err := primitives.ValidateName(name); err != nil {
+					return errors.Wrap(err, fmt.Sprintf("invalid name %s", name))
+				}
+			}
+		}
+
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		encoder := json.NewEncoder(os.Stdout)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Name",
+			"Status",
+			"Corrupt Sectors",
+			"Repair Queued",
+		})
+		var count int
+		var failed int
+		var innerErr error
+		err = rpc.VerifyBlobs(grpcClient, names, verifyRepair, func(res *rpc.VerifyBlobResult) bool {
+			count++
+			status := verifyStatus(res)
+			if status != "ok" && status != "skipped" {
+				failed++
+			}
+			ids := make([]int, len(res.CorruptSectors))
+			idStrs := make([]string, len(res.CorruptSectors))
+			for i, id := range res.CorruptSectors {
+				ids[i] = int(id)
+				idStrs[i] = strconv.Itoa(int(id))
+			}
+			if format == "json" {
+				err := encoder.Encode(struct {
+					Name           string `json:"name"`
+					Status         string `json:"status"`
+					CorruptSectors []int  `json:"corrupt_sectors"`
+					RepairQueued   bool   `json:"repair_queued"`
+				}{
+					Name:           res.Name,
+					Status:         status,
+					CorruptSectors: ids,
+					RepairQueued:   res.RepairQueued,
+				})
+				if err != nil {
+					innerErr = err
+					return false
+				}
+				return true
+			}
+			table.Append([]string{
+				res.Name,
+				status,
+				strings.Join(idStrs, ", "),
+				strconv.FormatBool(res.RepairQueued),
+			})
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if innerErr != nil {
+			return innerErr
+		}
+		if format != "json" {
+			table.Render()
+			fmt.Println("")
+			fmt.Printf("Total: %d, Failed: %d\n", count, failed)
+		}
+		if failed > 0 {
+			return errors.Errorf("%d blobs failed verification", failed)
+		}
+		return nil
+	},
+}
+
+func verifyStatus(res *rpc.VerifyBlobResult) string {
+	if res.Skipped {
+		return "skipped"
+	}
+	var problems []string
+	if res.Banned {
+		problems = append(problems, "banned")
+	}
+	if res.Orphaned {
+		problems = append(problems, "orphaned")
+	}
+	if res.BadSignature {
+		problems = append(problems, "bad_signature")
+	}
+	if res.Corrupt() {
+		problems = append(problems, "corrupt")
+	}
+	if len(problems) == 0 {
+		return "ok"
+	}
+	return strings.Join(problems, ",")
+}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyRepair, "repair", false, "Re-sync corrupted blobs from peers.")
+	cmd.AddCommand(verifyCmd)
+}
@@ -11,16 +11,19 @@ import (
 	"github.com/spf13/cobra"
 	"io"
 	"os"
+	"time"
 )
 
 const (
 	TruncateFlag  = "truncate"
 	BroadcastFlag = "broadcast"
+	TTLFlag       = "ttl"
 )
 
 var (
 	truncate  bool
 	broadcast bool
+	ttl       time.Duration
 )
 
 var writeCmd = &cobra.Command{
@@ -40,6 +43,7 @@ var writeCmd = &cobra.Command{
 
 		name := args[0]
 		wr := rpc.NewBlobWriter(apiv1.NewFootnotev1Client(conn), signer, name)
+		wr.TTL = ttl
 
 		if err := wr.Open(); err != nil {
 			return err
@@ -89,5 +93,6 @@ func readDataTTY() []byte {
 func init() {
 	writeCmd.Flags().BoolVar(&truncate, TruncateFlag, false, "Truncate the blob before writing")
 	writeCmd.Flags().BoolVar(&broadcast, BroadcastFlag, true, "Broadcast data to the network upon completion")
+	writeCmd.Flags().DurationVar(&ttl, TTLFlag, 0, "How long the node keeps the transaction without activity")
 	cmd.AddCommand(writeCmd)
 }
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Commands related to the node's configuration.",
+}
+
+func AddCmd(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var reloadCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Reloads the node's config file.",
+	Long: `Makes the node re-read its config file and apply the settings that
+can change while it is running, such as ban lists, peer limits, rate
+limits, heartbeats, and log levels. Settings that only take effect
+after a restart are listed. Sending SIGHUP to fnd does the same.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		res, err := rpc.ReloadConfig(grpcClient)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		if format == "json" {
+			return json.NewEncoder(os.Stdout).Encode(res)
+		}
+
+		if len(res.Applied) == 0 && len(res.RestartRequired) == 0 {
+			fmt.Println("No settings changed.")
+			return nil
+		}
+		if len(res.Applied) > 0 {
+			fmt.Println("Applied:")
+			for _, key := range res.Applied {
+				fmt.Printf("  %s\n", key)
+			}
+		}
+		if len(res.RestartRequired) > 0 {
+			fmt.Println("Restart required:")
+			for _, key := range res.RestartRequired {
+				fmt.Printf("  %s\n", key)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(reloadCmd)
+}
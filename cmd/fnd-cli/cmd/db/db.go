@@ -0,0 +1,29 @@
+package db
+
+import (
+	"fnd/config"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var fndHome string
+
+var cmd = &cobra.Command{
+	Use:   "db",
+	Short: "Commands related to fnd's database. fnd must be stopped to use them.",
+}
+
+func AddCmd(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
+
+func openDB() (*leveldb.DB, error) {
+	homePath := config.ExpandHomePath(fndHome)
+	db, err := store.Open(config.ExpandDBPath(homePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening store")
+	}
+	return db, nil
+}
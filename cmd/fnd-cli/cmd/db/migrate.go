@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"fnd/store"
+	"github.com/spf13/cobra"
+)
+
+var migrateDryRun bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Applies pending database migrations. fnd also applies them on startup.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if migrateDryRun {
+			pending, err := store.DryRunMigrations(db)
+			if err != nil {
+				return err
+			}
+			if len(pending) == 0 {
+				fmt.Println("No pending migrations.")
+				return nil
+			}
+			fmt.Println("Dry run succeeded. Would apply:")
+			printMigrations(pending)
+			return nil
+		}
+
+		applied, err := store.Migrate(db)
+		if len(applied) > 0 {
+			fmt.Println("Applied:")
+			printMigrations(applied)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&fndHome, "fnd-home", "~/.fnd", "Path to FootnoteD's home directory.")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Runs pending migrations without saving their changes.")
+	cmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"fmt"
+	"fnd/store"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Shows the database's schema version and any pending migrations.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		version, err := store.GetSchemaVersion(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Schema version: %d\n", version)
+		fmt.Printf("Latest version: %d\n", store.LatestSchemaVersion())
+		pending, err := store.PendingMigrations(db)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			fmt.Println("No pending migrations.")
+			return nil
+		}
+		fmt.Println("Pending migrations:")
+		printMigrations(pending)
+		return nil
+	},
+}
+
+func printMigrations(migrations []*store.Migration) {
+	for _, m := range migrations {
+		fmt.Printf("  %d: %s\n", m.Version, m.Description)
+	}
+}
+
+func init() {
+	versionCmd.Flags().StringVar(&fndHome, "fnd-home", "~/.fnd", "Path to FootnoteD's home directory.")
+	cmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+const defaultLevel = "default"
+
+var levelCmd = &cobra.Command{
+	Use:   "level [module] [level]",
+	Short: "Shows or changes the node's log levels.",
+	Long: `Shows or changes the node's log levels without restarting it.
+
+With no arguments, lists the level of every module. With one argument,
+sets the global level. With two arguments, sets the level of a single
+module, such as tree-base-syncer. Use "default" as the level to make
+the module use the global level again.
+
+Levels can be trace, debug, info, warn, error, or fatal. Changes are
+not persisted to the config file.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		switch len(args) {
+		case 1:
+			return rpc.SetLogLevel(grpcClient, "", args[0])
+		case 2:
+			level := args[1]
+			if level == defaultLevel {
+				level = ""
+			}
+			return rpc.SetLogLevel(grpcClient, args[0], level)
+		}
+
+		levels, err := rpc.ListLogLevels(grpcClient)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		if format == "json" {
+			return json.NewEncoder(os.Stdout).Encode(levels)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Module",
+			"Level",
+		})
+		for _, module := range levels.Modules {
+			level := module.Level
+			if !module.Overridden {
+				level += " (" + defaultLevel + ")"
+			}
+			table.Append([]string{
+				module.Module,
+				level,
+			})
+		}
+		table.Render()
+		fmt.Println("")
+		fmt.Printf("Global Level: %s\n", levels.Level)
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(levelCmd)
+}
@@ -0,0 +1,14 @@
+package log
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cmd = &cobra.Command{
+	Use:   "log",
+	Short: "Commands related to the node's logging.",
+}
+
+func AddCmd(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -0,0 +1,32 @@
+package name
+
+import (
+	"fnd.localhost/handshake/primitives"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+)
+
+var banCmd = &cobra.Command{
+	Use:   "ban <name>",
+	Short: "Bans a name locally.",
+	Long: `Bans a name locally. The name's blob will be deleted, and updates to
+it will be ignored until it is unbanned.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := primitives.ValidateName(args[0]); err != nil {
+			return err
+		}
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+		return rpc.BanName(grpcClient, args[0])
+	},
+}
+
+func init() {
+	cmd.AddCommand(banCmd)
+}
@@ -0,0 +1,70 @@
+package name
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"fnd/store"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var bansCmd = &cobra.Command{
+	Use:   "bans",
+	Short: "Lists banned names and the lists that banned them.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		encoder := json.NewEncoder(os.Stdout)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Name",
+			"Sources",
+		})
+		var count int
+		var innerErr error
+		err = rpc.ListNameBans(grpcClient, func(ban *store.NameBan) bool {
+			count++
+			if format == "json" {
+				if err := encoder.Encode(ban); err != nil {
+					innerErr = err
+					return false
+				}
+				return true
+			}
+			table.Append([]string{
+				ban.Name,
+				strings.Join(ban.Sources, ", "),
+			})
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if innerErr != nil {
+			return innerErr
+		}
+		if format == "json" {
+			return nil
+		}
+
+		table.Render()
+		fmt.Println("")
+		fmt.Printf("Total: %d\n", count)
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(bansCmd)
+}
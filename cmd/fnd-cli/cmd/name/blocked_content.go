@@ -0,0 +1,84 @@
+package name
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/protocol"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var blockedContentCmd = &cobra.Command{
+	Use:   "blocked-content",
+	Short: "Lists names that carry sectors blocked by a ban list.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		encoder := json.NewEncoder(os.Stdout)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Name",
+			"Sector IDs",
+		})
+		var count int
+		var innerErr error
+		err = rpc.ListBlockedContent(grpcClient, func(content *protocol.BlockedContent) bool {
+			count++
+			ids := make([]int, len(content.SectorIDs))
+			idStrs := make([]string, len(content.SectorIDs))
+			for i, id := range content.SectorIDs {
+				ids[i] = int(id)
+				idStrs[i] = strconv.Itoa(int(id))
+			}
+			if format == "json" {
+				err := encoder.Encode(struct {
+					Name      string `json:"name"`
+					SectorIDs []int  `json:"sector_ids"`
+				}{
+					Name:      content.Name,
+					SectorIDs: ids,
+				})
+				if err != nil {
+					innerErr = err
+					return false
+				}
+				return true
+			}
+			table.Append([]string{
+				content.Name,
+				strings.Join(idStrs, ", "),
+			})
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if innerErr != nil {
+			return innerErr
+		}
+		if format == "json" {
+			return nil
+		}
+
+		table.Render()
+		fmt.Println("")
+		fmt.Printf("Total: %d\n", count)
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(blockedContentCmd)
+}
@@ -0,0 +1,76 @@
+package name
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"fnd.localhost/handshake/primitives"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info <names>",
+	Short: "Returns the owner public key and import height of Handshake names.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := strings.Split(args[0], ",")
+		for _, name := range names {
+			if err := primitives.ValidateName(name); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid name %s", name))
+			}
+		}
+
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		if format == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			for _, name := range names {
+				info, err := rpc.GetNameInfo(grpcClient, name)
+				if err != nil {
+					return err
+				}
+				if err := encoder.Encode(info); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Name",
+			"Public Key",
+			"Import Height",
+		})
+		for _, name := range names {
+			info, err := rpc.GetNameInfo(grpcClient, name)
+			if err != nil {
+				return err
+			}
+			table.Append([]string{
+				info.Name,
+				hex.EncodeToString(info.PublicKey.SerializeCompressed()),
+				strconv.Itoa(info.ImportHeight),
+			})
+		}
+		table.Render()
+		return printImportStatus(grpcClient)
+	},
+}
+
+func init() {
+	cmd.AddCommand(infoCmd)
+}
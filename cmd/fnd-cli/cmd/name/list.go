@@ -0,0 +1,90 @@
+package name
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"fnd/store"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"math"
+	"os"
+	"strconv"
+)
+
+var listPrefix string
+
+var listCmd = &cobra.Command{
+	Use:   "list <start?> <limit?>",
+	Short: "Lists Handshake names imported by Footnote.",
+	Args:  cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var start string
+		if len(args) >= 1 {
+			start = args[0]
+		}
+		lim := math.MaxInt64
+		if len(args) == 2 {
+			limit, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil {
+				return err
+			}
+			lim = int(limit)
+		}
+
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		encoder := json.NewEncoder(os.Stdout)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Name",
+			"Public Key",
+			"Import Height",
+		})
+		var count int
+		var innerErr error
+		err = rpc.ListNames(grpcClient, listPrefix, start, func(info *store.NameInfo) bool {
+			count++
+			if format == "json" {
+				if err := encoder.Encode(info); err != nil {
+					innerErr = err
+					return false
+				}
+				return count < lim
+			}
+			table.Append([]string{
+				info.Name,
+				hex.EncodeToString(info.PublicKey.SerializeCompressed()),
+				strconv.Itoa(info.ImportHeight),
+			})
+			return count < lim
+		})
+		if err != nil {
+			return err
+		}
+		if innerErr != nil {
+			return innerErr
+		}
+		if format == "json" {
+			return nil
+		}
+
+		table.Render()
+		fmt.Println("")
+		fmt.Printf("Total: %d\n", count)
+		return printImportStatus(grpcClient)
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVar(&listPrefix, "prefix", "", "Only list names beginning with this prefix.")
+	cmd.AddCommand(listCmd)
+}
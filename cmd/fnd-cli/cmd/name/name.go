@@ -0,0 +1,32 @@
+package name
+
+import (
+	"fmt"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+)
+
+var cmd = &cobra.Command{
+	Use:   "name",
+	Short: "Commands related to Handshake names known to Footnote.",
+}
+
+func AddCmd(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
+
+func printImportStatus(client apiv1.Footnotev1Client) error {
+	status, err := rpc.GetNameImportStatus(client)
+	if err != nil {
+		return err
+	}
+	fmt.Println("")
+	fmt.Printf("Last Import Height: %d\n", status.LastImportHeight)
+	if status.InitialImportComplete {
+		fmt.Println("Initial Import Complete: TRUE")
+	} else {
+		fmt.Println("Initial Import Complete: FALSE")
+	}
+	return nil
+}
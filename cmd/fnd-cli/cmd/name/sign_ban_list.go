@@ -0,0 +1,46 @@
+package name
+
+import (
+	"fnd/cli"
+	"fnd/config"
+	"fnd/protocol"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var signBanListCmd = &cobra.Command{
+	Use:   "sign-ban-list <file>",
+	Short: "Signs a ban list with the CLI's identity.",
+	Long: `Signs a ban list with the CLI's identity and prints the signed list.
+Nodes that list the CLI's public key in moderator_keys will accept the
+signed list.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		homeDir := cli.GetHomeDir(cmd)
+		return config.EnsureHomeDir(homeDir)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		homeDir := cli.GetHomeDir(cmd)
+		signer, err := cli.GetSigner(homeDir)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		list, err := protocol.ReadSignedBanList(f)
+		if err != nil {
+			return err
+		}
+		if err := list.Sign(signer); err != nil {
+			return err
+		}
+		return list.Encode(os.Stdout)
+	},
+}
+
+func init() {
+	cmd.AddCommand(signBanListCmd)
+}
@@ -0,0 +1,28 @@
+package name
+
+import (
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+)
+
+var unbanCmd = &cobra.Command{
+	Use:   "unban <name>",
+	Short: "Removes a local name ban.",
+	Long: `Removes a local name ban. Names banned by a ban list remain banned
+until they are removed from that list.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		grpcClient := apiv1.NewFootnotev1Client(conn)
+		return rpc.UnbanName(grpcClient, args[0])
+	},
+}
+
+func init() {
+	cmd.AddCommand(unbanCmd)
+}
@@ -14,10 +14,13 @@ var (
 )
 
 var addPeerCmd = &cobra.Command{
-	Use:   "add-peer <peer-id?>@<ip>",
-	Short: "Adds a peer.",
-	Long:  `Adds a peer. If the peer is banned, this command is a no-op.`,
-	Args:  cobra.ExactArgs(1),
+	Use:     "add-peer <peer-id?>@<ip>[:<port>]",
+	Aliases: []string{"add"},
+	Short:   "Adds a peer.",
+	Long: `Adds a peer. If the peer is banned, this command is a no-op.
+The port defaults to 9097. IPv6 addresses with a port must be
+bracketed, e.g. [2001:db8::1]:9097.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conn, err := cli.DialRPC(cmd)
 		if err != nil {
@@ -35,7 +38,7 @@ var addPeerCmd = &cobra.Command{
 		case 2:
 			return rpc.AddPeer(grpcClient, splits[0], splits[1], verifyPeerID)
 		default:
-			return errors.New("must specify the peer as <peer-id?>@<ip>")
+			return errors.New("must specify the peer as <peer-id?>@<ip>[:<port>]")
 		}
 	},
 }
@@ -4,20 +4,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"fnd/cli"
+	"fnd/p2p"
 	"fnd/rpc"
 	apiv1 "fnd/rpc/v1"
+	"fnd/wire"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type peerJSON struct {
-	ID          string `json:"id"`
-	IP          string `json:"ip"`
-	Banned      bool   `json:"banned"`
-	Whitelisted bool   `json:"whitelisted"`
-	Connected   bool   `json:"connected"`
-	TxBytes     int    `json:"tx_bytes"`
+	ID          string              `json:"id"`
+	IP          string              `json:"ip"`
+	Port        int                 `json:"port,omitempty"`
+	Banned      bool                `json:"banned"`
+	Whitelisted bool                `json:"whitelisted"`
+	Connected   bool                `json:"connected"`
+	TxBytes     int                 `json:"tx_bytes"`
+	RxBytes     int                 `json:"rx_bytes"`
+	Usage       []*messageUsageJSON `json:"usage,omitempty"`
+	Services    []string            `json:"services,omitempty"`
+}
+
+type messageUsageJSON struct {
+	MessageType string `json:"message_type"`
+	Received    int    `json:"received"`
+	Dropped     int    `json:"dropped"`
 	RxBytes     int    `json:"rx_bytes"`
 }
 
@@ -42,11 +56,21 @@ var peerInfoCmd = &cobra.Command{
 				jsonPeer := &peerJSON{
 					ID:          peer.ID,
 					IP:          peer.IP,
+					Port:        peer.Port,
 					Banned:      peer.Banned,
 					Whitelisted: peer.Whitelisted,
 					Connected:   peer.Connected,
 					TxBytes:     int(peer.TxBytes),
 					RxBytes:     int(peer.RxBytes),
+					Services:    wire.ServiceNames(peer.Services),
+				}
+				for _, usage := range peer.Usage {
+					jsonPeer.Usage = append(jsonPeer.Usage, &messageUsageJSON{
+						MessageType: usage.MessageType,
+						Received:    int(usage.Received),
+						Dropped:     int(usage.Dropped),
+						RxBytes:     int(usage.RxBytes),
+					})
 				}
 
 				if err := encoder.Encode(jsonPeer); err != nil {
@@ -57,22 +81,26 @@ var peerInfoCmd = &cobra.Command{
 			table := tablewriter.NewWriter(os.Stdout)
 			table.SetHeader([]string{
 				"Peer ID",
-				"IP",
+				"Address",
 				"Banned",
 				"Whitelisted",
 				"Connected",
 				"Tx Bytes",
 				"Rx Bytes",
+				"Dropped",
+				"Services",
 			})
 			for _, res := range peers {
 				table.Append([]string{
 					res.ID,
-					res.IP,
+					peerAddrToStr(res.IP, res.Port),
 					boolToStr(res.Banned),
 					boolToStr(res.Whitelisted),
 					boolToStr(res.Connected),
 					bandwidthToStr(res.TxBytes),
 					bandwidthToStr(res.RxBytes),
+					countToStr(res.DroppedMessages()),
+					servicesToStr(res.Services),
 				})
 			}
 
@@ -101,6 +129,28 @@ func bandwidthToStr(stat uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(stat)/float64(div), "kMGTPE"[exp])
 }
 
+func countToStr(count uint64) string {
+	if count == 0 {
+		return "-"
+	}
+	return strconv.FormatUint(count, 10)
+}
+
+func servicesToStr(services uint64) string {
+	names := wire.ServiceNames(services)
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ",")
+}
+
+func peerAddrToStr(ip string, port int) string {
+	if port == 0 {
+		return ip
+	}
+	return p2p.JoinAddr(ip, port)
+}
+
 func boolToStr(val bool) string {
 	if val {
 		return "TRUE"
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"fnd/backup"
+	"fnd/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+var (
+	restoreFndHome string
+	restoreDryRun  bool
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Replaces fnd's home directory with a backup archive. fnd must be stopped.",
+	Long: `Replaces fnd's home directory with a backup archive. fnd must be stopped.
+The archive is extracted and validated before anything is replaced, and the
+current home directory is kept alongside the restored one.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error opening archive")
+		}
+		defer f.Close()
+
+		if restoreDryRun {
+			dir, err := ioutil.TempDir("", "fnd-restore-")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(dir)
+			manifest, err := backup.Stage(f, path.Join(dir, "home"))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Archive from %s is valid.\n", manifest.CreatedAt)
+			fmt.Printf("Headers: %d, Schema version: %d\n", manifest.HeaderCount, manifest.SchemaVersion)
+			return nil
+		}
+
+		homePath := config.ExpandHomePath(restoreFndHome)
+		res, err := backup.Restore(f, homePath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Restored backup from %s to %s.\n", res.Manifest.CreatedAt, homePath)
+		fmt.Printf("Headers: %d, Schema version: %d\n", res.Manifest.HeaderCount, res.Manifest.SchemaVersion)
+		if res.PreviousHome != "" {
+			fmt.Printf("The previous home directory was moved to %s.\n", res.PreviousHome)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.Flags().StringVar(&restoreFndHome, "fnd-home", "~/.fnd", "Path to FootnoteD's home directory.")
+	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Validates the archive without replacing anything.")
+	rootCmd.AddCommand(restoreCmd)
+}
@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"fnd/cli"
 	"fnd/cmd/fnd-cli/cmd/blob"
+	"fnd/cmd/fnd-cli/cmd/config"
+	"fnd/cmd/fnd-cli/cmd/db"
+	"fnd/cmd/fnd-cli/cmd/log"
+	"fnd/cmd/fnd-cli/cmd/name"
 	"fnd/cmd/fnd-cli/cmd/net"
+	"fnd/cmd/fnd-cli/cmd/tx"
 	"fnd/cmd/fnd-cli/cmd/unsafe"
 	"github.com/spf13/cobra"
 	"os"
@@ -29,5 +34,10 @@ func init() {
 	rootCmd.PersistentFlags().String(cli.FlagFormat, "text", "Output format")
 	net.AddCmd(rootCmd)
 	blob.AddCmd(rootCmd)
+	name.AddCmd(rootCmd)
 	unsafe.AddCmd(rootCmd)
+	log.AddCmd(rootCmd)
+	config.AddCmd(rootCmd)
+	db.AddCmd(rootCmd)
+	tx.AddCmd(rootCmd)
 }
@@ -0,0 +1,34 @@
+package tx
+
+import (
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+)
+
+var abortCmd = &cobra.Command{
+	Use:   "abort <tx-id>",
+	Short: "Rolls back an open blob transaction.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		txID, err := parseTxID(args[0])
+		if err != nil {
+			return err
+		}
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		if err := rpc.AbortTx(apiv1.NewFootnotev1Client(conn), txID); err != nil {
+			return err
+		}
+		fmt.Printf("Aborted transaction %d.\n", txID)
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(abortCmd)
+}
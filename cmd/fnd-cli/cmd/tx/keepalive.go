@@ -0,0 +1,41 @@
+package tx
+
+import (
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var keepAliveTTL time.Duration
+
+var keepAliveCmd = &cobra.Command{
+	Use:   "keepalive <tx-id>",
+	Short: "Renews the TTL of an open blob transaction.",
+	Long: `Renews the TTL of an open blob transaction. With --ttl, the
+transaction's TTL is changed as well, up to the node's maximum.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		txID, err := parseTxID(args[0])
+		if err != nil {
+			return err
+		}
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+		expiresAt, err := rpc.KeepAliveTx(apiv1.NewFootnotev1Client(conn), txID, keepAliveTTL)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Transaction %d expires at %s.\n", txID, expiresAt.Format(time.RFC3339))
+		return nil
+	},
+}
+
+func init() {
+	keepAliveCmd.Flags().DurationVar(&keepAliveTTL, "ttl", 0, "New TTL for the transaction")
+	cmd.AddCommand(keepAliveCmd)
+}
@@ -0,0 +1,91 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"fnd/cli"
+	"fnd/rpc"
+	apiv1 "fnd/rpc/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"time"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists open blob transactions.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := cli.DialRPC(cmd)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString(cli.FlagFormat)
+		encoder := json.NewEncoder(os.Stdout)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Tx ID",
+			"Name",
+			"Dirty Sectors",
+			"Truncated",
+			"Persisted",
+			"Expires At",
+		})
+		var innerErr error
+		err = rpc.ListTxs(apiv1.NewFootnotev1Client(conn), func(info *rpc.TxInfo) bool {
+			if format == "json" {
+				err := encoder.Encode(struct {
+					TxID             uint32    `json:"tx_id"`
+					Name             string    `json:"name"`
+					CreatedAt        time.Time `json:"created_at"`
+					ExpiresAt        time.Time `json:"expires_at"`
+					TTLSeconds       int       `json:"ttl_seconds"`
+					DirtySectorCount int       `json:"dirty_sector_count"`
+					Truncated        bool      `json:"truncated"`
+					Persisted        bool      `json:"persisted"`
+				}{
+					TxID:             info.ID,
+					Name:             info.Name,
+					CreatedAt:        info.CreatedAt,
+					ExpiresAt:        info.ExpiresAt,
+					TTLSeconds:       int(info.TTL / time.Second),
+					DirtySectorCount: info.DirtySectorCount,
+					Truncated:        info.Truncated,
+					Persisted:        info.Persisted,
+				})
+				if err != nil {
+					innerErr = err
+					return false
+				}
+				return true
+			}
+			table.Append([]string{
+				strconv.FormatUint(uint64(info.ID), 10),
+				info.Name,
+				strconv.Itoa(info.DirtySectorCount),
+				strconv.FormatBool(info.Truncated),
+				strconv.FormatBool(info.Persisted),
+				info.ExpiresAt.Format(time.RFC3339),
+			})
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if innerErr != nil {
+			return innerErr
+		}
+		if format != "json" {
+			table.Render()
+			fmt.Println("")
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.AddCommand(listCmd)
+}
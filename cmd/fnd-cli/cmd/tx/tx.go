@@ -0,0 +1,23 @@
+package tx
+
+import (
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+var cmd = &cobra.Command{
+	Use:   "tx",
+	Short: "Commands related to open blob transactions.",
+}
+
+func AddCmd(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
+
+func parseTxID(in string) (uint32, error) {
+	id, err := strconv.ParseUint(in, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(id), nil
+}
@@ -0,0 +1,168 @@
+package unsafe
+
+import (
+	"fmt"
+	"fnd/blob"
+	"fnd/config"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"io"
+)
+
+var (
+	migrateFromBackend string
+	migrateToBackend   string
+	migrateRemoveOld   bool
+)
+
+var migrateBlobsCmd = &cobra.Command{
+	Use:   "migrate-blobs",
+	Short: "Copies fnd's blob data directly on disk from one storage backend to another",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrateFromBackend == migrateToBackend {
+			return errors.New("source and destination backends must differ")
+		}
+
+		homePath := config.ExpandHomePath(fndHome)
+		db, err := store.Open(config.ExpandDBPath(homePath))
+		if err != nil {
+			return errors.Wrap(err, "error opening store")
+		}
+		defer db.Close()
+
+		blobsPath := config.ExpandBlobsPath(homePath)
+		from, err := blob.OpenStore(migrateFromBackend, blobsPath)
+		if err != nil {
+			return errors.Wrap(err, "error opening source blob store")
+		}
+		defer closeBlobStore(from)
+		to, err := blob.OpenStore(migrateToBackend, blobsPath)
+		if err != nil {
+			return errors.Wrap(err, "error opening destination blob store")
+		}
+		defer closeBlobStore(to)
+
+		stream, err := store.StreamBlobInfo(db, "")
+		if err != nil {
+			return errors.Wrap(err, "error streaming blob info")
+		}
+		defer stream.Close()
+
+		var migrated int
+		for {
+			info, err := stream.Next()
+			if err != nil {
+				return errors.Wrap(err, "error streaming blob info")
+			}
+			if info == nil {
+				break
+			}
+			exists, err := from.Exists(info.Name)
+			if err != nil {
+				return errors.Wrap(err, "error checking blob existence")
+			}
+			if !exists {
+				continue
+			}
+			if err := migrateBlob(from, to, info.Name); err != nil {
+				return errors.Wrapf(err, "error migrating blob %s", info.Name)
+			}
+			if err := verifyMigratedBlob(to, info); err != nil {
+				return errors.Wrapf(err, "error verifying blob %s", info.Name)
+			}
+			if migrateRemoveOld {
+				if err := removeBlob(from, info.Name); err != nil {
+					return errors.Wrapf(err, "error removing source blob %s", info.Name)
+				}
+			}
+			migrated++
+		}
+
+		fmt.Printf("Migrated %d blobs from %s to %s.\n", migrated, migrateFromBackend, migrateToBackend)
+		fmt.Printf("Set storage.backend to \"%s\" in config.toml to use the migrated blobs.\n", migrateToBackend)
+		return nil
+	},
+}
+
+func migrateBlob(from blob.Store, to blob.Store, name string) error {
+	src, err := from.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening source blob")
+	}
+	defer src.Close()
+	dst, err := to.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening destination blob")
+	}
+	defer dst.Close()
+	tx, err := dst.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error opening transaction")
+	}
+	if err := tx.Truncate(); err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "error truncating destination blob")
+	}
+	for i := 0; i < blob.SectorCount; i++ {
+		sector, err := src.ReadSector(uint8(i))
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "error reading sector")
+		}
+		if sector == blob.ZeroSector {
+			continue
+		}
+		if err := tx.WriteSector(uint8(i), sector); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "error writing sector")
+		}
+	}
+	return tx.Commit()
+}
+
+func verifyMigratedBlob(bs blob.Store, info *store.BlobInfo) error {
+	bl, err := bs.Open(info.Name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	tree, err := blob.Merkleize(blob.NewReader(bl))
+	if err != nil {
+		return errors.Wrap(err, "error merkleizing blob")
+	}
+	if tree.Root() != info.MerkleRoot {
+		return errors.New("merkle root mismatch")
+	}
+	return nil
+}
+
+func removeBlob(bs blob.Store, name string) error {
+	bl, err := bs.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error opening transaction")
+	}
+	if err := tx.Remove(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func closeBlobStore(bs blob.Store) {
+	if closer, ok := bs.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+func init() {
+	migrateBlobsCmd.Flags().StringVar(&fndHome, "fnd-home", "~/.fnd", "Path to FootnoteD's home directory.")
+	migrateBlobsCmd.Flags().StringVar(&migrateFromBackend, "from", blob.BackendFile, "Storage backend to migrate blobs from.")
+	migrateBlobsCmd.Flags().StringVar(&migrateToBackend, "to", blob.BackendPacked, "Storage backend to migrate blobs to.")
+	migrateBlobsCmd.Flags().BoolVar(&migrateRemoveOld, "remove-old", false, "Removes blobs from the source backend once they are migrated.")
+	cmd.AddCommand(migrateBlobsCmd)
+}
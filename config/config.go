@@ -9,15 +9,34 @@ import (
 
 type Config struct {
 	LogLevel       string            `mapstructure:"log_level"`
+	Log            LogConfig         `mapstructure:"log"`
 	EnableProfiler bool              `mapstructure:"enable_profiler"`
+	Health         HealthConfig      `mapstructure:"health"`
 	Heartbeat      HeartbeatConfig   `mapstructure:"heartbeat"`
 	P2P            P2PConfig         `mapstructure:"p2p"`
 	RPC            RPCConfig         `mapstructure:"rpc"`
 	HNSResolver    HNSResolverConfig `mapstructure:"hns_resolver"`
 	BanLists       []string          `mapstructure:"ban_lists"`
+	ModeratorKeys  []string          `mapstructure:"moderator_keys"`
+	Storage        StorageConfig     `mapstructure:"storage"`
 	Tuning         TuningConfig      `mapstructure:"tuning"`
 }
 
+type LogConfig struct {
+	File          string            `mapstructure:"file"`
+	Format        string            `mapstructure:"format"`
+	MaxFileSizeMB int               `mapstructure:"max_file_size_mb"`
+	MaxFiles      int               `mapstructure:"max_files"`
+	ModuleLevels  map[string]string `mapstructure:"module_levels"`
+}
+
+type HealthConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	Host          string `mapstructure:"host"`
+	Port          int    `mapstructure:"port"`
+	MinFreeDiskMB int    `mapstructure:"min_free_disk_mb"`
+}
+
 type HeartbeatConfig struct {
 	Moniker string `mapstructure:"moniker"`
 	URL     string `mapstructure:"url"`
@@ -25,16 +44,23 @@ type HeartbeatConfig struct {
 
 type P2PConfig struct {
 	Host                string   `mapstructure:"host"`
+	Port                int      `mapstructure:"port"`
 	DNSSeeds            []string `mapstructure:"dns_seeds"`
 	FixedSeeds          []string `mapstructure:"seed_peers"`
 	MaxInboundPeers     int      `mapstructure:"max_inbound_peers"`
 	MaxOutboundPeers    int      `mapstructure:"max_outbound_peers"`
 	ConnectionTimeoutMS int      `mapstructure:"connection_timeout_ms"`
+	Proxy               string   `mapstructure:"proxy"`
+	ProxyOnly           bool     `mapstructure:"proxy_only"`
+	AdvertiseAddresses  []string `mapstructure:"advertise_addresses"`
 }
 
 type RPCConfig struct {
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Host                string `mapstructure:"host"`
+	Port                int    `mapstructure:"port"`
+	TransactionTTLMS    int    `mapstructure:"transaction_ttl_ms"`
+	MaxTransactionTTLMS int    `mapstructure:"max_transaction_ttl_ms"`
+	PersistTransactions bool   `mapstructure:"persist_transactions"`
 }
 
 type HNSResolverConfig struct {
@@ -44,16 +70,23 @@ type HNSResolverConfig struct {
 	APIKey   string `mapstructure:"api_key"`
 }
 
+type StorageConfig struct {
+	Backend string `mapstructure:"backend"`
+}
+
 type TuningConfig struct {
-	Timebank      TimebankConfig      `mapstructure:"timebank"`
-	UpdateQueue   UpdateQueueConfig   `mapstructure:"update_queue"`
-	Updater       UpdaterConfig       `mapstructure:"updater"`
-	Syncer        SyncerConfig        `mapstructure:"syncer"`
-	SectorServer  SectorServerConfig  `mapstructure:"sector_server"`
-	PeerExchanger PeerExchangerConfig `mapstructure:"peer_exchanger"`
-	NameImporter  NameImporterConfig  `mapstructure:"name_importer"`
-	Heartbeat     HeartbeaterConfig   `mapstructure:"heartbeat"`
-	NameSyncer    NameSyncerConfig    `mapstructure:"name_syncer"`
+	Timebank        TimebankConfig        `mapstructure:"timebank"`
+	UpdateAnnouncer UpdateAnnouncerConfig `mapstructure:"update_announcer"`
+	UpdateQueue     UpdateQueueConfig     `mapstructure:"update_queue"`
+	Updater         UpdaterConfig         `mapstructure:"updater"`
+	Syncer          SyncerConfig          `mapstructure:"syncer"`
+	SectorServer    SectorServerConfig    `mapstructure:"sector_server"`
+	PeerExchanger   PeerExchangerConfig   `mapstructure:"peer_exchanger"`
+	PeerMuxer       PeerMuxerConfig       `mapstructure:"peer_muxer"`
+	NameImporter    NameImporterConfig    `mapstructure:"name_importer"`
+	Heartbeat       HeartbeaterConfig     `mapstructure:"heartbeat"`
+	NameSyncer      NameSyncerConfig      `mapstructure:"name_syncer"`
+	Scrubber        ScrubberConfig        `mapstructure:"scrubber"`
 }
 
 type TimebankConfig struct {
@@ -62,6 +95,12 @@ type TimebankConfig struct {
 	FullUpdatesPerPeriod int `mapstructure:"full_updates_per_period"`
 }
 
+type UpdateAnnouncerConfig struct {
+	BatchIntervalMS  int `mapstructure:"batch_interval_ms"`
+	MaxBatchSize     int `mapstructure:"max_batch_size"`
+	RequestTimeoutMS int `mapstructure:"request_timeout_ms"`
+}
+
 type UpdateQueueConfig struct {
 	MaxLen         int `mapstructure:"max_len"`
 	ReapIntervalMS int `mapstructure:"reap_interval_ms"`
@@ -70,6 +109,7 @@ type UpdateQueueConfig struct {
 type UpdaterConfig struct {
 	PollIntervalMS int `mapstructure:"poll_interval_ms"`
 	Workers        int `mapstructure:"workers"`
+	StallTimeoutMS int `mapstructure:"stall_timeout_ms"`
 }
 
 type SyncerConfig struct {
@@ -78,7 +118,9 @@ type SyncerConfig struct {
 }
 
 type SectorServerConfig struct {
-	CacheExpiryMS int `mapstructure:"cache_expiry_ms"`
+	BatchAckTimeoutMS int `mapstructure:"batch_ack_timeout_ms"`
+	CacheExpiryMS     int `mapstructure:"cache_expiry_ms"`
+	MaxBatchStreams   int `mapstructure:"max_batch_streams"`
 }
 
 type PeerExchangerConfig struct {
@@ -90,6 +132,18 @@ type PeerExchangerConfig struct {
 	MaxConcurrentDials int `mapstructure:"max_concurrent_dials"`
 }
 
+type PeerMuxerConfig struct {
+	Compression        []string                   `mapstructure:"compression"`
+	DropWindowMS       int                        `mapstructure:"drop_window_ms"`
+	MaxDroppedMessages int                        `mapstructure:"max_dropped_messages"`
+	RateLimits         map[string]RateLimitConfig `mapstructure:"rate_limits"`
+}
+
+type RateLimitConfig struct {
+	Burst int     `mapstructure:"burst"`
+	Rate  float64 `mapstructure:"rate"`
+}
+
 type NameImporterConfig struct {
 	ConfirmationDepth     int     `mapstructure:"confirmation_depth"`
 	CheckIntervalMS       int     `mapstructure:"check_interval_ms"`
@@ -110,6 +164,11 @@ type NameSyncerConfig struct {
 	SyncResponseTimeoutMS   int `mapstructure:"sync_response_timeout_ms"`
 }
 
+type ScrubberConfig struct {
+	IntervalMS int `mapstructure:"interval_ms"`
+	PauseMS    int `mapstructure:"pause_ms"`
+}
+
 func ReadConfig(r io.Reader) (*Config, error) {
 	decoder := toml.NewDecoder(r)
 	decoder.SetTagName("mapstructure")
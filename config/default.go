@@ -12,14 +12,29 @@ import (
 
 var DefaultConfig = Config{
 	BanLists:       []string{},
+	ModeratorKeys:  []string{},
 	LogLevel:       log.LevelInfo.String(),
+	Log: LogConfig{
+		File:          "",
+		Format:        log.FormatText,
+		MaxFileSizeMB: 100,
+		MaxFiles:      5,
+		ModuleLevels:  map[string]string{},
+	},
 	EnableProfiler: false,
+	Health: HealthConfig{
+		Enabled:       true,
+		Host:          "127.0.0.1",
+		Port:          9099,
+		MinFreeDiskMB: 512,
+	},
 	Heartbeat: HeartbeatConfig{
 		Moniker: "",
 		URL:     "",
 	},
 	P2P: P2PConfig{
 		Host: "0.0.0.0",
+		Port: 9097,
 		DNSSeeds: []string{},
 		FixedSeeds:          []string{
 			"3b755ceafc5811f0a50e102c96169b062ad1295edea0adf675e8647963acf89e@64.225.89.142",
@@ -28,10 +43,13 @@ var DefaultConfig = Config{
 		MaxInboundPeers:     117,
 		MaxOutboundPeers:    8,
 		ConnectionTimeoutMS: 5000,
+		AdvertiseAddresses:  []string{},
 	},
 	RPC: RPCConfig{
-		Host: "127.0.0.1",
-		Port: 9098,
+		Host:                "127.0.0.1",
+		Port:                9098,
+		TransactionTTLMS:    60000,
+		MaxTransactionTTLMS: 86400000,
 	},
 	HNSResolver: HNSResolverConfig{
 		Host:     "http://127.0.0.1",
@@ -39,12 +57,20 @@ var DefaultConfig = Config{
 		BasePath: "",
 		APIKey:   "",
 	},
+	Storage: StorageConfig{
+		Backend: "file",
+	},
 	Tuning: TuningConfig{
 		Timebank: TimebankConfig{
 			PeriodMS:             86400 * 2,
 			MinUpdateIntervalMS:  120,
 			FullUpdatesPerPeriod: 2,
 		},
+		UpdateAnnouncer: UpdateAnnouncerConfig{
+			BatchIntervalMS:  500,
+			MaxBatchSize:     500,
+			RequestTimeoutMS: 30000,
+		},
 		UpdateQueue: UpdateQueueConfig{
 			MaxLen:         1000,
 			ReapIntervalMS: 5000,
@@ -52,13 +78,16 @@ var DefaultConfig = Config{
 		Updater: UpdaterConfig{
 			PollIntervalMS: 100,
 			Workers:        2,
+			StallTimeoutMS: 10 * 60 * 1000,
 		},
 		Syncer: SyncerConfig{
 			TreeBaseResponseTimeoutMS: 10000,
 			SectorResponseTimeoutMS:   15000,
 		},
 		SectorServer: SectorServerConfig{
-			CacheExpiryMS: 5000,
+			BatchAckTimeoutMS: 10000,
+			CacheExpiryMS:     5000,
+			MaxBatchStreams:   8,
 		},
 		PeerExchanger: PeerExchangerConfig{
 			SampleSize:         12,
@@ -67,6 +96,45 @@ var DefaultConfig = Config{
 			MaxReceivedPeers:   255,
 			MaxConcurrentDials: 2,
 		},
+		PeerMuxer: PeerMuxerConfig{
+			Compression:        []string{"flate", "zero_run"},
+			DropWindowMS:       60000,
+			MaxDroppedMessages: 100,
+			RateLimits: map[string]RateLimitConfig{
+				"PeerReq": {
+					Burst: 2,
+					Rate:  0.1,
+				},
+				"SectorBatchAck": {
+					Burst: 64,
+					Rate:  16,
+				},
+				"SectorBatchReq": {
+					Burst: 8,
+					Rate:  2,
+				},
+				"SectorReq": {
+					Burst: 512,
+					Rate:  128,
+				},
+				"TreeBaseReq": {
+					Burst: 20,
+					Rate:  5,
+				},
+				"Update": {
+					Burst: 200,
+					Rate:  20,
+				},
+				"UpdateInv": {
+					Burst: 50,
+					Rate:  10,
+				},
+				"UpdateReq": {
+					Burst: 100,
+					Rate:  20,
+				},
+			},
+		},
 		NameImporter: NameImporterConfig{
 			ConfirmationDepth:     24,
 			CheckIntervalMS:       60000,
@@ -84,12 +152,16 @@ var DefaultConfig = Config{
 			IntervalMS:              60 * 60 * 1000,
 			SyncResponseTimeoutMS:   60000,
 		},
+		Scrubber: ScrubberConfig{
+			IntervalMS: 24 * 60 * 60 * 1000,
+			PauseMS:    100,
+		},
 	},
 }
 
 const defaultConfigTemplateText = `# FootnoteD Config File
 
-# List of ban list URLs.
+# List of ban list URLs. Supports http://, https://, and file:// URLs.
 ban_lists = []
 
 # Enables pprof profiling.
@@ -103,6 +175,23 @@ enable_profiler = {{.EnableProfiler}}
 # - trace
 log_level = "{{.LogLevel}}"
 
+# List of base64-encoded moderator public keys. When set, ban lists
+# fetched over HTTP(S) must be signed by one of these keys.
+moderator_keys = []
+
+# Configures the HTTP server that exposes /healthz and /readyz
+# endpoints for process supervisors and orchestrators.
+[health]
+  # Enables the HTTP health server.
+  enabled = {{.Health.Enabled}}
+  # Sets the IP the health server listens on.
+  host = "{{.Health.Host}}"
+  # Sets the minimum free space in megabytes on the disk holding
+  # fnd's home directory before the node reports itself not ready.
+  min_free_disk_mb = {{.Health.MinFreeDiskMB}}
+  # Sets the port the health server listens on.
+  port = {{.Health.Port}}
+
 # Configures heartbeating, which announces this
 # node's moniker and peer ID to the provided URL.
 [heartbeat]
@@ -124,6 +213,23 @@ log_level = "{{.LogLevel}}"
   # Sets the HSD connection's port.
   port = {{.HNSResolver.Port}}
 
+# Configures log output.
+[log]
+  # Sets a file to write logs to instead of stderr. Relative paths
+  # are relative to fnd's home directory.
+  file = "{{.Log.File}}"
+  # Sets the log format. Can be "text" or "json".
+  format = "{{.Log.Format}}"
+  # Sets the size in megabytes at which the log file is rotated.
+  max_file_size_mb = {{.Log.MaxFileSizeMB}}
+  # Sets how many rotated log files are kept.
+  max_files = {{.Log.MaxFiles}}
+
+  # Overrides log_level for individual modules, such as
+  # tree-base-syncer = "trace". Levels can also be changed at
+  # runtime with fnd-cli log level.
+  [log.module_levels]
+
 # Configures the behavior of this node's peer-to-peer
 # connections.
 [p2p]
@@ -137,6 +243,8 @@ log_level = "{{.LogLevel}}"
   # Sets the IP this node should listen on. Should be set to 0.0.0.0
   # for all Internet-accessible nodes.
   host = "{{.P2P.Host}}"
+  # Sets the port this node should listen on.
+  port = {{.P2P.Port}}
   # Sets the maximum number of inbound peers this node will handle. All
   # additional inbound peers will be rejected once this number is reached.
   # The default of 117 was chosen to match Bitcoin.
@@ -145,7 +253,17 @@ log_level = "{{.LogLevel}}"
   # will not connect to any additional peers once this number is reached. The
   # default of 8 was chosen to match Bitcoin.
   max_outbound_peers = {{.P2P.MaxOutboundPeers}}
-  # Sets a list of fixed seed peers. Items should be formatted as <peer-id>@<ip>.
+  # Sets the address of a SOCKS5 proxy, such as Tor, formatted as
+  # <ip>:<port>. Onion addresses are always dialed through the proxy.
+  proxy = "{{.P2P.Proxy}}"
+  # Routes all outbound connections through the proxy, and skips
+  # resolving DNS seeds locally.
+  proxy_only = {{.P2P.ProxyOnly}}
+  # Sets addresses other nodes can reach this node on, such as an onion
+  # address. These are shared with peers during peer exchange.
+  advertise_addresses = []
+  # Sets a list of fixed seed peers. Items should be formatted as
+  # <peer-id>@<ip>, <peer-id>@<ip>:<port>, or <peer-id>@[<ipv6>]:<port>.
   seed_peers = ["{{index .P2P.FixedSeeds 0}}", "{{index .P2P.FixedSeeds 1}}"]
 
 # Configures the behavior of this node's RPC server.
@@ -156,6 +274,24 @@ log_level = "{{.LogLevel}}"
   host = "{{.RPC.Host}}"
   # Sets the port this node should listen for RPC requests on.
   port = {{.RPC.Port}}
+  # Sets how long blob transactions are kept without activity, unless
+  # the client asks for another TTL when checking out the blob.
+  transaction_ttl_ms = {{.RPC.TransactionTTLMS}}
+  # Sets the longest TTL clients can ask for.
+  max_transaction_ttl_ms = {{.RPC.MaxTransactionTTLMS}}
+  # Stores the writes of open blob transactions in the database so
+  # that clients can resume them after fnd restarts.
+  persist_transactions = {{.RPC.PersistTransactions}}
+
+# Configures how fnd stores blob data on disk.
+[storage]
+  # Sets the blob storage backend. Can be one of the following values:
+  # - file: stores each blob as a 1MB file.
+  # - packed: stores deduplicated sectors in a content-addressed
+  #   store. Zero sectors take up no space.
+  # Use fnd-cli unsafe migrate-blobs to move existing blobs between
+  # backends.
+  backend = "{{.Storage.Backend}}"
 
 # Configures various internal tuning parameters. Unless directed otherwise
 # or you know what you are doing, these values should be left as their
@@ -216,10 +352,47 @@ log_level = "{{.LogLevel}}"
     # peer exchange operation.
     sample_size = {{.Tuning.PeerExchanger.SampleSize}}
 
+  # Configures how fnd limits the messages each peer can send.
+  [tuning.peer_muxer]
+    # Sets the codecs fnd can use to compress sector and tree base
+    # responses, in order of preference. A codec is only used if the
+    # peer supports it too. Valid codecs are "flate" and "zero_run".
+    # Set to [] to disable compression.
+    compression = [{{range $i, $codec := .Tuning.PeerMuxer.Compression}}{{if $i}}, {{end}}"{{$codec}}"{{end}}]
+    # Sets the window over which dropped messages are counted.
+    drop_window_ms = {{.Tuning.PeerMuxer.DropWindowMS}}
+    # Sets how many of a peer's messages can be dropped for exceeding
+    # its rate limits within drop_window_ms before fnd disconnects it.
+    max_dropped_messages = {{.Tuning.PeerMuxer.MaxDroppedMessages}}
+
+    # Sets rate limits for each message type. rate is the number of
+    # messages per second a peer can send on average, and burst is the
+    # number it can send at once. Message types without a limit
+    # are not limited.
+    [tuning.peer_muxer.rate_limits]
+{{- range $msgType, $limit := .Tuning.PeerMuxer.RateLimits}}
+      [tuning.peer_muxer.rate_limits.{{$msgType}}]
+        burst = {{$limit.Burst}}
+        rate = {{$limit.Rate}}
+{{- end}}
+
+  # Configures how fnd checks stored blobs for corruption.
+  [tuning.scrubber]
+    # Sets how often every stored blob is verified. Set to 0 to
+    # disable background verification.
+    interval_ms = {{.Tuning.Scrubber.IntervalMS}}
+    # Sets how long fnd will wait between verifying two blobs.
+    pause_ms = {{.Tuning.Scrubber.PauseMS}}
+
   # Configures how fnd serves sector data to peers that request it.
   [tuning.sector_server]
+    # Sets how long fnd will wait for a peer to acknowledge streamed
+    # sectors before it stops streaming to them.
+    batch_ack_timeout_ms = {{.Tuning.SectorServer.BatchAckTimeoutMS}}
     # Sets how often fnd will reap in-memory cached sectors.
     cache_expiry_ms = {{.Tuning.SectorServer.CacheExpiryMS}}
+    # Sets how many batched sector requests fnd will stream at once.
+    max_batch_streams = {{.Tuning.SectorServer.MaxBatchStreams}}
 
   # Configures how fnd synchronizes sectors with remote peers.
   [tuning.syncer]
@@ -242,6 +415,18 @@ log_level = "{{.LogLevel}}"
     # Sets the time period over which the timebank will be calculated.
     period_ms = {{.Tuning.Timebank.PeriodMS}}
 
+  # Configures how fnd announces blob updates to peers.
+  [tuning.update_announcer]
+    # Sets how long fnd will collect updates before announcing them
+    # to peers in a single inventory message.
+    batch_interval_ms = {{.Tuning.UpdateAnnouncer.BatchIntervalMS}}
+    # Sets how many updates will be collected before fnd announces
+    # them without waiting for the batch interval.
+    max_batch_size = {{.Tuning.UpdateAnnouncer.MaxBatchSize}}
+    # Sets how long fnd will wait for a peer to return an announced
+    # update before requesting it from another peer.
+    request_timeout_ms = {{.Tuning.UpdateAnnouncer.RequestTimeoutMS}}
+
   # Configures how fnd enqueues blob updates.
   [tuning.update_queue]
     # Sets the maximum length of the update queue.
@@ -253,6 +438,9 @@ log_level = "{{.LogLevel}}"
   [tuning.updater]
     # Sets how often fnd will check the update queue for new updates.
     poll_interval_ms = {{.Tuning.Updater.PollIntervalMS}}
+    # Sets how long a single update can take before fnd reports
+    # the updater as unhealthy.
+    stall_timeout_ms = {{.Tuning.Updater.StallTimeoutMS}}
     # Sets how many updates fnd will process concurrently.
     workers = {{.Tuning.Updater.Workers}}
 `
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"reflect"
+)
+
+// Diff returns the keys of the settings that differ between a and b,
+// such as "p2p.max_inbound_peers". Lists and tables of arbitrary keys
+// are compared as a whole.
+func Diff(a *Config, b *Config) []string {
+	var out []string
+	diffStruct("", reflect.ValueOf(*a), reflect.ValueOf(*b), &out)
+	return out
+}
+
+func diffStruct(prefix string, a reflect.Value, b reflect.Value, out *[]string) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+		aVal := a.Field(i)
+		bVal := b.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			diffStruct(key+".", aVal, bVal, out)
+			continue
+		}
+		if !reflect.DeepEqual(aVal.Interface(), bVal.Interface()) {
+			*out = append(*out, key)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := DefaultConfig
+	b := DefaultConfig
+	require.Empty(t, Diff(&a, &b))
+
+	b.LogLevel = "debug"
+	b.P2P.MaxInboundPeers = 10
+	b.BanLists = []string{"https://example.com/bans.txt"}
+	b.Tuning.PeerMuxer.RateLimits = map[string]RateLimitConfig{
+		"Update": {
+			Burst: 1,
+			Rate:  1,
+		},
+	}
+	require.Equal(t, []string{
+		"log_level",
+		"p2p.max_inbound_peers",
+		"ban_lists",
+		"tuning.peer_muxer.rate_limits",
+	}, Diff(&a, &b))
+}
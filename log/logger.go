@@ -3,8 +3,11 @@ package log
 import (
 	"errors"
 	"github.com/sirupsen/logrus"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 )
 
 type Level int
@@ -56,10 +59,22 @@ func (l Level) String() string {
 	}
 }
 
-var currLevel = LevelInfo
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	currLevel    = LevelInfo
+	moduleLevels = make(map[string]Level)
+	modules      = make(map[string]bool)
+	levelMu      sync.RWMutex
+)
+
+var backend = newBackend()
 
 var rootLogger = &logrusLogger{
-	backend: logrus.New(),
+	backend: backend,
 }
 
 type Logger interface {
@@ -72,29 +87,107 @@ type Logger interface {
 	Sub(...interface{}) Logger
 }
 
+// SetLevel sets the level of every module without its own level.
 func SetLevel(level Level) {
+	levelMu.Lock()
 	currLevel = level
+	levelMu.Unlock()
+}
 
-	var logrusLevel logrus.Level
-	switch level {
-	case LevelTrace:
-		logrusLevel = logrus.TraceLevel
-	case LevelDebug:
-		logrusLevel = logrus.DebugLevel
-	case LevelInfo:
-		logrusLevel = logrus.InfoLevel
-	case LevelWarn:
-		logrusLevel = logrus.WarnLevel
-	case LevelError:
-		logrusLevel = logrus.ErrorLevel
-	case LevelFatal:
-		logrusLevel = logrus.PanicLevel
+func GetLevel() Level {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	return currLevel
+}
+
+// SetModuleLevel overrides the level of a single module.
+func SetModuleLevel(module string, level Level) {
+	levelMu.Lock()
+	moduleLevels[module] = level
+	levelMu.Unlock()
+}
+
+// ClearModuleLevel makes a module use the global level again.
+func ClearModuleLevel(module string) {
+	levelMu.Lock()
+	delete(moduleLevels, module)
+	levelMu.Unlock()
+}
+
+// ClearModuleLevels removes every module's level override.
+func ClearModuleLevels() {
+	levelMu.Lock()
+	moduleLevels = make(map[string]Level)
+	levelMu.Unlock()
+}
+
+// ModuleLevels returns the modules whose levels are overridden.
+func ModuleLevels() map[string]Level {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	out := make(map[string]Level)
+	for module, level := range moduleLevels {
+		out[module] = level
+	}
+	return out
+}
+
+// Modules returns the names of every module that has created a
+// logger, in alphabetical order.
+func Modules() []string {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	var out []string
+	for module := range modules {
+		out = append(out, module)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func levelFor(module string) Level {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	if level, ok := moduleLevels[module]; ok {
+		return level
 	}
-	rootLogger.backend.(*logrus.Logger).SetLevel(logrusLevel)
+	return currLevel
+}
+
+// SetFormat sets the format of log output. Can be FormatText or
+// FormatJSON.
+func SetFormat(format string) error {
+	switch format {
+	case FormatText:
+		backend.SetFormatter(new(logrus.TextFormatter))
+	case FormatJSON:
+		backend.SetFormatter(new(logrus.JSONFormatter))
+	default:
+		return errors.New("invalid log format")
+	}
+	return nil
+}
+
+// SetOutput sets where log output is written. Defaults to stderr.
+func SetOutput(w io.Writer) {
+	backend.SetOutput(w)
 }
 
 func WithModule(name string) Logger {
-	return rootLogger.Sub("module", name)
+	levelMu.Lock()
+	modules[name] = true
+	levelMu.Unlock()
+	lgr := rootLogger.Sub("module", name).(*logrusLogger)
+	lgr.module = name
+	return lgr
+}
+
+func newBackend() *logrus.Logger {
+	lgr := logrus.New()
+	// levels are checked before messages reach logrus so that
+	// modules can log below the global level
+	lgr.SetLevel(logrus.TraceLevel)
+	return lgr
 }
 
 func init() {
@@ -0,0 +1,45 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestModuleLevels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	SetOutput(buf)
+	require.NoError(t, SetFormat(FormatJSON))
+	prevLevel := GetLevel()
+	defer func() {
+		SetOutput(os.Stderr)
+		require.NoError(t, SetFormat(FormatText))
+		SetLevel(prevLevel)
+		ClearModuleLevels()
+	}()
+
+	SetLevel(LevelWarn)
+	quiet := WithModule("quiet-module")
+	loud := WithModule("loud-module").Sub("name", "foo")
+	SetModuleLevel("loud-module", LevelDebug)
+
+	quiet.Info("hidden")
+	loud.Debug("shown")
+	require.Equal(t, map[string]Level{"loud-module": LevelDebug}, ModuleLevels())
+	require.Contains(t, Modules(), "quiet-module")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.NewDecoder(buf).Decode(&entry))
+	require.Equal(t, "shown", entry["msg"])
+	require.Equal(t, "loud-module", entry["module"])
+	require.Equal(t, "foo", entry["name"])
+	require.Equal(t, 0, buf.Len())
+
+	ClearModuleLevel("loud-module")
+	loud.Debug("hidden")
+	require.Equal(t, 0, buf.Len())
+
+	require.Error(t, SetFormat("xml"))
+}
@@ -4,6 +4,7 @@ import "github.com/sirupsen/logrus"
 
 type logrusLogger struct {
 	backend logrus.FieldLogger
+	module  string
 }
 
 var _ Logger = (*logrusLogger)(nil)
@@ -47,11 +48,12 @@ func (l *logrusLogger) Fatal(msg string, fields ...interface{}) {
 func (l *logrusLogger) Sub(fields ...interface{}) Logger {
 	return &logrusLogger{
 		backend: l.parseFields(fields),
+		module:  l.module,
 	}
 }
 
 func (l *logrusLogger) isEnabled(level Level) bool {
-	return level >= currLevel
+	return level >= levelFor(l.module)
 }
 
 func (l *logrusLogger) parseFields(fields []interface{}) logrus.FieldLogger {
@@ -0,0 +1,43 @@
+package log
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fndtest_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fnd.log")
+
+	f, err := NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, line := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	requireContents := func(path string, expected string) {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	requireContents(path, "dddddddd\n")
+	requireContents(path+".1", "cccccccc\n")
+	requireContents(path+".2", "bbbbbbbb\n")
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+
+	// reopening appends to the existing file
+	f, err = NewRotatingFile(path, 20, 2)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("eeeeeeee\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	requireContents(path, "dddddddd\neeeeeeee\n")
+}
@@ -0,0 +1,22 @@
+package node
+
+import (
+	"fnd/backup"
+	"io"
+)
+
+// Backup writes a consistent archive of the node's database, blobs,
+// and config file to w. It can run while the node is serving. The
+// node's identity is not included; callers that want it must add it
+// themselves with backup.AddIdentity, as fnd-cli backup does.
+func (n *Node) Backup(w io.Writer) (*backup.Manifest, error) {
+	if n.isStopping() {
+		return nil, ErrNodeStopped
+	}
+	return backup.Write(w, &backup.Opts{
+		DB:         n.db,
+		BlobStore:  n.bs,
+		NameLocker: n.nameLocker,
+		HomeDir:    n.homeDir,
+	})
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	"fnd/config"
+	"fnd/log"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type logSettings struct {
+	level        log.Level
+	moduleLevels map[string]log.Level
+	format       string
+}
+
+// ConfigureLogging applies the log settings in cfg to the
+// process-wide logger. Nothing is changed if any setting is invalid.
+// If logs are written to a file, the returned closer closes it;
+// otherwise it is nil.
+func ConfigureLogging(cfg *config.Config, homeDir string) (io.Closer, error) {
+	settings, err := parseLogSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *log.RotatingFile
+	if cfg.Log.File != "" {
+		path := cfg.Log.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(homeDir, path)
+		}
+		file, err = log.NewRotatingFile(path, int64(cfg.Log.MaxFileSizeMB)*1024*1024, cfg.Log.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	settings.apply()
+	if file == nil {
+		log.SetOutput(os.Stderr)
+		return nil, nil
+	}
+	log.SetOutput(file)
+	return file, nil
+}
+
+func parseLogSettings(cfg *config.Config) (*logSettings, error) {
+	level, err := log.NewLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing log level")
+	}
+	moduleLevels := make(map[string]log.Level)
+	for module, levelStr := range cfg.Log.ModuleLevels {
+		moduleLevel, err := log.NewLevel(levelStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing log level for module %s", module)
+		}
+		moduleLevels[module] = moduleLevel
+	}
+	if cfg.Log.Format != log.FormatText && cfg.Log.Format != log.FormatJSON {
+		return nil, errors.Errorf("invalid log format %s", cfg.Log.Format)
+	}
+	return &logSettings{
+		level:        level,
+		moduleLevels: moduleLevels,
+		format:       cfg.Log.Format,
+	}, nil
+}
+
+func (s *logSettings) apply() {
+	// the format was validated by parseLogSettings
+	_ = log.SetFormat(s.format)
+	log.SetLevel(s.level)
+	log.ClearModuleLevels()
+	for module, level := range s.moduleLevels {
+		log.SetModuleLevel(module, level)
+	}
+}
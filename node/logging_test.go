@@ -0,0 +1,55 @@
+package node
+
+import (
+	"fnd/config"
+	"fnd/log"
+	"fnd/testutil/testfs"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	homeDir, done := testfs.NewTempDir(t)
+	defer done()
+	prevLevel := log.GetLevel()
+	defer func() {
+		log.SetLevel(prevLevel)
+		log.ClearModuleLevels()
+		log.SetOutput(os.Stderr)
+		require.NoError(t, log.SetFormat(log.FormatText))
+	}()
+
+	cfg := config.DefaultConfig
+	cfg.LogLevel = "warn"
+	cfg.Log.File = "fnd.log"
+	cfg.Log.Format = log.FormatJSON
+	cfg.Log.ModuleLevels = map[string]string{
+		"tree-base-syncer": "trace",
+	}
+	closer, err := ConfigureLogging(&cfg, homeDir)
+	require.NoError(t, err)
+	require.Equal(t, log.LevelWarn, log.GetLevel())
+	require.Equal(t, map[string]log.Level{"tree-base-syncer": log.LevelTrace}, log.ModuleLevels())
+
+	log.WithModule("tree-base-syncer").Trace("syncing tree base")
+	log.WithModule("updater").Info("hidden")
+	require.NoError(t, closer.Close())
+	data, err := ioutil.ReadFile(filepath.Join(homeDir, "fnd.log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], `"msg":"syncing tree base"`)
+
+	invalid := cfg
+	invalid.LogLevel = "info"
+	invalid.Log.ModuleLevels = map[string]string{
+		"updater": "loud",
+	}
+	_, err = ConfigureLogging(&invalid, homeDir)
+	require.Error(t, err)
+	require.Equal(t, log.LevelWarn, log.GetLevel())
+}
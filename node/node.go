@@ -0,0 +1,726 @@
+package node
+
+import (
+	"fnd.localhost/handshake/client"
+	"fnd/blob"
+	"fnd/cli"
+	"fnd/config"
+	"fnd/crypto"
+	"fnd/log"
+	"fnd/p2p"
+	"fnd/protocol"
+	"fnd/rpc"
+	"fnd/service"
+	"fnd/store"
+	"fnd/util"
+	"fnd/wire"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultShutdownTimeout = 30 * time.Second
+	DefaultStartTimeout    = 30 * time.Second
+	DefaultHSDRetries      = 10
+	DefaultHSDRetryDelay   = 10 * time.Second
+)
+
+var (
+	ErrNodeStopped = errors.New("node stopped")
+)
+
+type component struct {
+	name string
+	svc  service.Service
+}
+
+type Options struct {
+	// Config configures the node. If nil, config.DefaultConfig is used.
+	Config *config.Config
+	// HomeDir contains the node's database and blob store.
+	HomeDir string
+	// Signer is the node's identity. If nil, the identity stored in
+	// HomeDir is used.
+	Signer crypto.Signer
+}
+
+// Node wires together and runs every service that makes up a Footnote
+// node. Services are started in dependency order, each once the one
+// before it is ready, and stopped in reverse order. Start fails if a
+// service isn't ready within StartTimeout.
+type Node struct {
+	ShutdownTimeout time.Duration
+	StartTimeout    time.Duration
+	HSDRetries      int
+	HSDRetryDelay   time.Duration
+
+	cfg        *config.Config
+	signer     crypto.Signer
+	db         *leveldb.DB
+	bs         blob.Store
+	nameLocker util.MultiLocker
+	mux        *p2p.PeerMuxer
+	pm         p2p.PeerManager
+	addrs      *p2p.AddrManager
+	hsd        *client.Client
+	moderators []*btcec.PublicKey
+	seeds      []p2p.SeedPeer
+	homeDir    string
+	components []component
+	health     *rpc.HealthServer
+	readyCh    chan struct{}
+	errCh      chan error
+	quitCh     chan struct{}
+	doneCh     chan struct{}
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	reloadMu   sync.Mutex
+	started    bool
+	stopped    bool
+	lgr        log.Logger
+}
+
+// New opens the node's stores and configures its services. Nothing
+// touches the network until Start is called.
+func New(opts *Options) (*Node, error) {
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = &config.DefaultConfig
+	}
+	lgr := log.WithModule("node")
+
+	signer := opts.Signer
+	if signer == nil {
+		var err error
+		signer, err = cli.GetSigner(opts.HomeDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening home directory")
+		}
+	}
+
+	parsed, err := parseConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath := config.ExpandDBPath(opts.HomeDir)
+	lgr.Info("opening db", "path", dbPath)
+	db, err := store.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := store.Migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "error migrating database")
+	}
+	if len(migrated) > 0 {
+		lgr.Info("migrated database", "version", migrated[len(migrated)-1].Version)
+	}
+
+	blobsPath := config.ExpandBlobsPath(opts.HomeDir)
+	lgr.Info("opening blob store", "path", blobsPath, "backend", cfg.Storage.Backend)
+	bs, err := blob.OpenStore(cfg.Storage.Backend, blobsPath)
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "error opening blob store")
+	}
+
+	addrs, err := p2p.NewAddrManager(db)
+	if err != nil {
+		closeBlobStore(bs)
+		db.Close()
+		return nil, errors.Wrap(err, "error opening address book")
+	}
+
+	n := &Node{
+		ShutdownTimeout: DefaultShutdownTimeout,
+		StartTimeout:    DefaultStartTimeout,
+		HSDRetries:      DefaultHSDRetries,
+		HSDRetryDelay:   DefaultHSDRetryDelay,
+		cfg:             cfg,
+		signer:          signer,
+		db:              db,
+		bs:              bs,
+		addrs:           addrs,
+		moderators:      parsed.moderators,
+		seeds:           parsed.seeds,
+		homeDir:         opts.HomeDir,
+		readyCh:         make(chan struct{}),
+		quitCh:          make(chan struct{}),
+		doneCh:          make(chan struct{}),
+		lgr:             lgr,
+	}
+
+	mux := p2p.NewPeerMuxer(p2p.MainnetMagic, signer)
+	mux.RateLimits = parsed.rateLimits
+	mux.MaxDroppedMessages = cfg.Tuning.PeerMuxer.MaxDroppedMessages
+	mux.DropWindow = config.ConvertDuration(cfg.Tuning.PeerMuxer.DropWindowMS, time.Millisecond)
+	mux.Compression = parsed.compression
+	n.mux = mux
+
+	p2pHost := cfg.P2P.Host
+	// nodes reachable via an onion service listen on localhost,
+	// so only skip the listener if there is nothing to advertise
+	listening := p2pHost != "" && (p2pHost != "127.0.0.1" || len(cfg.P2P.AdvertiseAddresses) > 0)
+	localServices := p2p.LocalServices
+	if listening {
+		localServices |= wire.ServiceInbound
+	}
+	pm := p2p.NewPeerManager(&p2p.PeerManagerOpts{
+		Mux:         mux,
+		DB:          db,
+		AddrManager: addrs,
+		Dialer:      parsed.connDialer,
+		SeedPeers:   parsed.seeds,
+		Signer:      signer,
+		ListenHost:  p2pHost,
+		ListenPort:  cfg.P2P.Port,
+		MaxInbound:  cfg.P2P.MaxInboundPeers,
+		MaxOutbound: cfg.P2P.MaxOutboundPeers,
+		Services:    localServices,
+	})
+	n.pm = pm
+
+	n.hsd = client.NewClient(
+		cfg.HNSResolver.Host,
+		client.WithAPIKey(cfg.HNSResolver.APIKey),
+		client.WithPort(cfg.HNSResolver.Port),
+		client.WithBasePath(cfg.HNSResolver.BasePath),
+	)
+
+	nameLocker := util.NewMultiLocker()
+	n.nameLocker = nameLocker
+	ownPeerID := crypto.HashPub(signer.Pub())
+
+	importer := protocol.NewNameImporter(n.hsd, db)
+	importer.ConfirmationDepth = cfg.Tuning.NameImporter.ConfirmationDepth
+	importer.CheckInterval = config.ConvertDuration(cfg.Tuning.NameImporter.CheckIntervalMS, time.Millisecond)
+	importer.Workers = cfg.Tuning.NameImporter.Workers
+	importer.VerificationThreshold = cfg.Tuning.NameImporter.VerificationThreshold
+
+	updateQueue := protocol.NewUpdateQueue(mux, db)
+	updateQueue.MaxLen = int32(cfg.Tuning.UpdateQueue.MaxLen)
+	updateQueue.MinUpdateInterval = config.ConvertDuration(cfg.Tuning.Timebank.MinUpdateIntervalMS, time.Millisecond)
+
+	updateAnnouncer := protocol.NewUpdateAnnouncer(mux, db)
+	updateAnnouncer.BatchInterval = config.ConvertDuration(cfg.Tuning.UpdateAnnouncer.BatchIntervalMS, time.Millisecond)
+	updateAnnouncer.MaxBatchSize = cfg.Tuning.UpdateAnnouncer.MaxBatchSize
+	updateAnnouncer.RequestTimeout = config.ConvertDuration(cfg.Tuning.UpdateAnnouncer.RequestTimeoutMS, time.Millisecond)
+
+	updater := protocol.NewUpdater(mux, db, updateQueue, updateAnnouncer, nameLocker, bs)
+	updater.PollInterval = config.ConvertDuration(cfg.Tuning.Updater.PollIntervalMS, time.Millisecond)
+	updater.Workers = cfg.Tuning.Updater.Workers
+	updater.StallTimeout = config.ConvertDuration(cfg.Tuning.Updater.StallTimeoutMS, time.Millisecond)
+
+	pinger := protocol.NewPinger(mux)
+
+	sectorServer := protocol.NewSectorServer(mux, db, bs, nameLocker)
+	sectorServer.CacheExpiry = config.ConvertDuration(cfg.Tuning.SectorServer.CacheExpiryMS, time.Millisecond)
+	sectorServer.BatchAckTimeout = config.ConvertDuration(cfg.Tuning.SectorServer.BatchAckTimeoutMS, time.Millisecond)
+	sectorServer.MaxBatchStreams = cfg.Tuning.SectorServer.MaxBatchStreams
+
+	updateServer := protocol.NewUpdateServer(mux, db, nameLocker)
+
+	peerExchanger := protocol.NewPeerExchanger(pm, addrs, mux, db)
+	peerExchanger.SampleSize = cfg.Tuning.PeerExchanger.SampleSize
+	peerExchanger.ResponseTimeout = config.ConvertDuration(cfg.Tuning.PeerExchanger.ResponseTimeoutMS, time.Millisecond)
+	peerExchanger.RequestInterval = config.ConvertDuration(cfg.Tuning.PeerExchanger.RequestIntervalMS, time.Millisecond)
+	peerExchanger.MaxSentPeers = cfg.Tuning.PeerExchanger.MaxSentPeers
+	peerExchanger.MaxReceivedPeers = cfg.Tuning.PeerExchanger.MaxReceivedPeers
+	peerExchanger.MaxConcurrentDials = cfg.Tuning.PeerExchanger.MaxConcurrentDials
+	peerExchanger.AdvertisedAddrs = cfg.P2P.AdvertiseAddresses
+	peerExchanger.Services = localServices
+	peerExchanger.PeerID = ownPeerID
+
+	nameSyncer := protocol.NewNameSyncer(mux, db, nameLocker, updater)
+	nameSyncer.Workers = cfg.Tuning.NameSyncer.Workers
+	nameSyncer.SampleSize = cfg.Tuning.NameSyncer.SampleSize
+	nameSyncer.UpdateResponseTimeout = config.ConvertDuration(cfg.Tuning.NameSyncer.UpdateResponseTimeoutMS, time.Millisecond)
+	nameSyncer.Interval = config.ConvertDuration(cfg.Tuning.NameSyncer.IntervalMS, time.Millisecond)
+	nameSyncer.SyncResponseTimeout = config.ConvertDuration(cfg.Tuning.NameSyncer.SyncResponseTimeoutMS, time.Millisecond)
+
+	scrubber := protocol.NewScrubber(mux, db, bs, nameLocker, updateQueue)
+	scrubber.Interval = config.ConvertDuration(cfg.Tuning.Scrubber.IntervalMS, time.Millisecond)
+	scrubber.Pause = config.ConvertDuration(cfg.Tuning.Scrubber.PauseMS, time.Millisecond)
+
+	server := rpc.NewServer(&rpc.Opts{
+		PeerID:      ownPeerID,
+		Mux:         mux,
+		Announcer:   updateAnnouncer,
+		DB:          db,
+		BlobStore:   bs,
+		PeerManager: pm,
+		NameLocker:  nameLocker,
+		Scrubber:    scrubber,
+		Host:        cfg.RPC.Host,
+		Port:        cfg.RPC.Port,
+		Health:      n.Health,
+		ReloadConfig: func() ([]string, []string, error) {
+			res, err := n.ReloadConfigFile()
+			if err != nil {
+				return nil, nil, err
+			}
+			return res.Applied, res.RestartRequired, nil
+		},
+		Backup: func(w io.Writer) error {
+			_, err := n.Backup(w)
+			return err
+		},
+		TransactionTTL:      config.ConvertDuration(cfg.RPC.TransactionTTLMS, time.Millisecond),
+		MaxTransactionTTL:   config.ConvertDuration(cfg.RPC.MaxTransactionTTLMS, time.Millisecond),
+		PersistTransactions: cfg.RPC.PersistTransactions,
+	})
+
+	// services that others depend on come first, and services that
+	// accept connections from the outside world come last
+	n.components = []component{
+		{"peer_manager", pm},
+		{"name_importer", importer},
+		{"update_queue", updateQueue},
+		{"update_announcer", updateAnnouncer},
+		{"updater", updater},
+		{"pinger", pinger},
+		{"sector_server", sectorServer},
+		{"update_server", updateServer},
+		{"peer_exchanger", peerExchanger},
+		{"name_syncer", nameSyncer},
+		{"scrubber", scrubber},
+	}
+	if listening {
+		n.components = append(n.components, component{"listener", p2p.NewListener(p2pHost, cfg.P2P.Port, pm)})
+	}
+	n.components = append(n.components, component{"rpc_server", server})
+	if cfg.Heartbeat.URL != "" {
+		n.components = append(n.components, component{heartbeaterComponent, newHeartbeater(cfg, ownPeerID)})
+	}
+	// the health server runs for the node's whole lifetime so that
+	// probes can reach it while the node is still connecting to HSD
+	if cfg.Health.Enabled {
+		n.health = rpc.NewHealthServer(cfg.Health.Host, cfg.Health.Port, n.Health)
+	}
+	n.errCh = make(chan error, len(n.components)+1)
+	return n, nil
+}
+
+// Start ingests ban lists, waits for HSD to become reachable, starts
+// every service, and dials seed peers once every service is ready.
+// Ready is closed once Start returns successfully.
+func (n *Node) Start() error {
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return ErrNodeStopped
+	}
+	if n.health != nil {
+		n.runService(n.health)
+	}
+	n.mu.Unlock()
+
+	dnsSeeds := n.resolveDNSSeeds()
+
+	n.lgr.Info("ingesting ban lists")
+	n.mu.Lock()
+	banLists := n.cfg.BanLists
+	moderators := n.moderators
+	n.mu.Unlock()
+	if err := protocol.IngestBanLists(n.db, n.bs, n.nameLocker, banLists, moderators); err != nil {
+		return errors.Wrap(err, "failed to ingest ban lists")
+	}
+
+	if err := n.connectHSD(); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return ErrNodeStopped
+	}
+	// services that are stopped before they are started exit as soon
+	// as they start
+	n.started = true
+	components := n.components
+	n.mu.Unlock()
+
+	n.lgr.Info("starting services")
+	for _, c := range components {
+		if err := n.startService(c); err != nil {
+			return err
+		}
+	}
+
+	err := store.WithTx(n.db, func(tx *leveldb.Transaction) error {
+		for _, seed := range n.seeds {
+			if err := store.WhitelistPeerTx(tx, seed.IP); err != nil {
+				return err
+			}
+		}
+		for _, seed := range dnsSeeds {
+			if err := store.WhitelistPeerTx(tx, seed); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error whitelisting seed peers")
+	}
+
+	for _, seed := range n.seeds {
+		n.addrs.Add(seed.ID, seed.IP, seed.Port, 0, seed.IP, true)
+	}
+	for _, seed := range dnsSeeds {
+		n.addrs.Add(crypto.ZeroHash, seed, p2p.StandardPort, 0, seed, false)
+	}
+
+	n.lgr.Info("dialing seed peers")
+	for _, seed := range n.seeds {
+		if err := n.pm.DialPeer(seed.ID, seed.IP, seed.Port, true); err != nil {
+			n.lgr.Warn("error dialing seed peer", "err", err)
+			continue
+		}
+	}
+	for _, seed := range dnsSeeds {
+		if err := n.pm.DialPeer(crypto.ZeroHash, seed, p2p.StandardPort, false); err != nil {
+			n.lgr.Warn("error dialing DNS seed peer", "err", err)
+		}
+	}
+
+	close(n.readyCh)
+	n.lgr.Info("node ready")
+	return nil
+}
+
+// Stop stops every service in reverse start order, closes all peers,
+// and closes the blob store and database. Services that don't exit
+// within ShutdownTimeout are abandoned.
+func (n *Node) Stop() error {
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return nil
+	}
+	n.stopped = true
+	started := n.started
+	components := n.components
+	close(n.quitCh)
+	n.mu.Unlock()
+
+	n.lgr.Info("stopping node")
+	var firstErr error
+	if started {
+		for i := len(components) - 1; i >= 0; i-- {
+			c := components[i]
+			if err := c.svc.Stop(); err != nil {
+				n.lgr.Error("error stopping service", "service", c.name, "err", err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		for _, peer := range n.mux.Peers() {
+			if err := peer.Close(); err != nil {
+				n.lgr.Warn("error closing peer", "err", err)
+			}
+		}
+	} else if err := n.addrs.Flush(); err != nil {
+		n.lgr.Error("error flushing address book", "err", err)
+	}
+	if n.health != nil {
+		if err := n.health.Stop(); err != nil {
+			n.lgr.Error("error stopping health server", "err", err)
+		}
+	}
+
+	doneCh := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(doneCh)
+	}()
+	timer := time.NewTimer(n.ShutdownTimeout)
+	select {
+	case <-doneCh:
+		timer.Stop()
+	case <-timer.C:
+		n.lgr.Warn("timed out waiting for services to stop")
+	}
+
+	if err := closeBlobStore(n.bs); err != nil {
+		n.lgr.Error("error closing blob store", "err", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := n.db.Close(); err != nil {
+		n.lgr.Error("error closing db", "err", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	n.lgr.Info("node stopped")
+	close(n.doneCh)
+	return firstErr
+}
+
+// Ready is closed once the node has started all of its services.
+func (n *Node) Ready() <-chan struct{} {
+	return n.readyCh
+}
+
+// Done is closed once the node has been stopped.
+func (n *Node) Done() <-chan struct{} {
+	return n.doneCh
+}
+
+// Err receives errors from services that exit unexpectedly.
+func (n *Node) Err() <-chan error {
+	return n.errCh
+}
+
+func (n *Node) PeerID() crypto.Hash {
+	return crypto.HashPub(n.signer.Pub())
+}
+
+func (n *Node) DB() *leveldb.DB {
+	return n.db
+}
+
+func (n *Node) BlobStore() blob.Store {
+	return n.bs
+}
+
+func (n *Node) Mux() *p2p.PeerMuxer {
+	return n.mux
+}
+
+// Health checks every component of the node, along with the free
+// space on the disk holding the node's home directory.
+func (n *Node) Health() *service.Report {
+	report := &service.Report{
+		Checks: []service.Check{
+			{Component: "node", Err: n.checkRunning()},
+		},
+	}
+	n.mu.Lock()
+	components := n.components
+	n.mu.Unlock()
+	for _, c := range components {
+		report.Checks = append(report.Checks, service.Check{
+			Component: c.name,
+			Err:       c.svc.Health(),
+		})
+	}
+	report.Checks = append(report.Checks, service.Check{
+		Component: "disk",
+		Err:       n.checkDisk(),
+	})
+	return report
+}
+
+func (n *Node) checkRunning() error {
+	if n.isStopping() {
+		return ErrNodeStopped
+	}
+	select {
+	case <-n.readyCh:
+		return nil
+	default:
+		return service.NotReady(errors.New("node is starting"))
+	}
+}
+
+func (n *Node) checkDisk() error {
+	minFreeMB := n.config().Health.MinFreeDiskMB
+	if minFreeMB <= 0 {
+		return nil
+	}
+	free, err := util.FreeDiskSpace(n.homeDir)
+	if errors.Is(err, util.ErrFreeDiskSpaceUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error checking free disk space")
+	}
+	freeMB := free / (1024 * 1024)
+	if freeMB < uint64(minFreeMB) {
+		return service.NotReady(errors.Errorf("only %d MB of disk space is free", freeMB))
+	}
+	return nil
+}
+
+// startService runs c and waits until it is ready, so that the
+// services after it can depend on it.
+func (n *Node) startService(c component) error {
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return ErrNodeStopped
+	}
+	exitCh := n.runService(c.svc)
+	n.mu.Unlock()
+
+	var readyCh <-chan struct{}
+	if r, ok := c.svc.(service.Readier); ok {
+		readyCh = r.Ready()
+	}
+	timer := time.NewTimer(n.StartTimeout)
+	defer timer.Stop()
+	select {
+	case <-readyCh:
+	case err := <-exitCh:
+		if err != nil {
+			return errors.Wrapf(err, "error starting %s", c.name)
+		}
+	case <-n.quitCh:
+		return ErrNodeStopped
+	case <-timer.C:
+		return errors.Errorf("timed out waiting for %s to start", c.name)
+	}
+	n.lgr.Debug("started service", "service", c.name)
+	return nil
+}
+
+// runService runs s in the background. The returned channel receives
+// the result of Start.
+func (n *Node) runService(s service.Service) <-chan error {
+	n.wg.Add(1)
+	exitCh := make(chan error, 1)
+	// most services block in Start until they are stopped
+	go func() {
+		defer n.wg.Done()
+		err := s.Start()
+		exitCh <- err
+		if err == nil || n.isStopping() {
+			return
+		}
+		n.lgr.Error("service failed", "err", err)
+		n.errCh <- err
+	}()
+	return exitCh
+}
+
+// config returns the settings the node is currently running with.
+func (n *Node) config() *config.Config {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.cfg
+}
+
+func (n *Node) isStopping() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.stopped
+}
+
+func (n *Node) resolveDNSSeeds() []string {
+	var dnsSeeds []string
+	cfg := n.config()
+	if len(cfg.P2P.DNSSeeds) == 0 {
+		return nil
+	}
+	if cfg.P2P.ProxyOnly {
+		n.lgr.Warn("skipping DNS seeds in proxy-only mode")
+		return nil
+	}
+	seenSeeds := make(map[string]bool)
+	for _, domain := range cfg.P2P.DNSSeeds {
+		n.lgr.Info("looking up DNS seeds", "domain", domain)
+		seeds, err := p2p.ResolveDNSSeeds(domain)
+		if err != nil {
+			n.lgr.Error("error resolving DNS seeds", "domain", domain)
+			continue
+		}
+		for _, seed := range seeds {
+			if seenSeeds[seed] {
+				continue
+			}
+			seenSeeds[seed] = true
+			dnsSeeds = append(dnsSeeds, seed)
+		}
+	}
+	return dnsSeeds
+}
+
+func (n *Node) connectHSD() error {
+	n.lgr.Info("connecting to HSD", "host", n.config().HNSResolver.Host)
+	for i := 0; i < n.HSDRetries; i++ {
+		_, err := n.hsd.GetInfo()
+		if err == nil {
+			return nil
+		}
+		if i == n.HSDRetries-1 {
+			break
+		}
+		n.lgr.Warn("error connecting to HSD, retrying", "err", err, "delay", n.HSDRetryDelay)
+		select {
+		case <-time.After(n.HSDRetryDelay):
+		case <-n.quitCh:
+			return ErrNodeStopped
+		}
+	}
+	return errors.Errorf("could not connect to HSD after %d retries", n.HSDRetries)
+}
+
+type parsedConfig struct {
+	seeds       []p2p.SeedPeer
+	connDialer  *p2p.ConnDialer
+	moderators  []*btcec.PublicKey
+	rateLimits  map[wire.MessageType]p2p.RateLimit
+	compression []uint8
+}
+
+// parseConfig validates the parts of cfg that need parsing before
+// they can be used.
+func parseConfig(cfg *config.Config) (*parsedConfig, error) {
+	seeds, err := p2p.ParseSeedPeers(cfg.P2P.FixedSeeds)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing seed peers")
+	}
+	for _, addr := range cfg.P2P.AdvertiseAddresses {
+		if _, _, err := p2p.ParseAddr(addr); err != nil {
+			return nil, errors.Wrapf(err, "error parsing advertised address %s", addr)
+		}
+	}
+	connDialer, err := p2p.NewConnDialer(cfg.P2P.Proxy, cfg.P2P.ProxyOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "error configuring proxy")
+	}
+	moderators, err := protocol.ParseModeratorKeys(cfg.ModeratorKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing moderator keys")
+	}
+	rateLimits, err := p2p.ParseRateLimits(cfg.Tuning.PeerMuxer.RateLimits)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing peer rate limits")
+	}
+	compression, err := p2p.ParseCompression(cfg.Tuning.PeerMuxer.Compression)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing peer compression codecs")
+	}
+	return &parsedConfig{
+		seeds:       seeds,
+		connDialer:  connDialer,
+		moderators:  moderators,
+		rateLimits:  rateLimits,
+		compression: compression,
+	}, nil
+}
+
+func newHeartbeater(cfg *config.Config, peerID crypto.Hash) *protocol.Heartbeater {
+	hb := protocol.NewHeartbeater(cfg.Heartbeat.URL, cfg.Heartbeat.Moniker, peerID)
+	hb.Interval = config.ConvertDuration(cfg.Tuning.Heartbeat.IntervalMS, time.Millisecond)
+	hb.Timeout = config.ConvertDuration(cfg.Tuning.Heartbeat.TimeoutMS, time.Millisecond)
+	return hb
+}
+
+func closeBlobStore(bs blob.Store) error {
+	if closer, ok := bs.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package node
+
+import (
+	"fnd/config"
+	"fnd/log"
+	"fnd/service"
+	"fnd/testutil/testcrypto"
+	"fnd/testutil/testfs"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestNode(t *testing.T) (*Node, func()) {
+	hsd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	hsdHost, hsdPortStr, err := net.SplitHostPort(hsd.Listener.Addr().String())
+	require.NoError(t, err)
+	hsdPort, err := strconv.Atoi(hsdPortStr)
+	require.NoError(t, err)
+
+	homeDir, done := testfs.NewTempDir(t)
+	require.NoError(t, config.InitHomeDir(homeDir))
+
+	cfg := config.DefaultConfig
+	cfg.P2P.Host = "127.0.0.1"
+	cfg.P2P.FixedSeeds = nil
+	cfg.P2P.DNSSeeds = nil
+	cfg.RPC.Host = "127.0.0.1"
+	cfg.RPC.Port = 0
+	cfg.HNSResolver.Host = "http://" + hsdHost
+	cfg.HNSResolver.Port = hsdPort
+	cfg.Health.Port = 0
+
+	n, err := New(&Options{
+		Config:  &cfg,
+		HomeDir: homeDir,
+		Signer:  testcrypto.FixedSigner(t),
+	})
+	require.NoError(t, err)
+	n.HSDRetries = 1
+	return n, func() {
+		require.NoError(t, n.Stop())
+		hsd.Close()
+		done()
+	}
+}
+
+func TestNode_Lifecycle(t *testing.T) {
+	n, done := newTestNode(t)
+	defer done()
+	require.NoError(t, n.Start())
+
+	select {
+	case <-n.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("node did not become ready")
+	}
+	select {
+	case err := <-n.Err():
+		t.Fatalf("unexpected service failure: %v", err)
+	default:
+	}
+
+	report := n.Health()
+	require.True(t, report.Live())
+	require.NoError(t, report.Component("node").Err)
+	require.True(t, service.IsNotReady(report.Component("name_importer").Err))
+
+	n.ShutdownTimeout = 5 * time.Second
+	stopped := time.Now()
+	require.NoError(t, n.Stop())
+	require.True(t, time.Since(stopped) < n.ShutdownTimeout, "services did not stop before the shutdown timeout")
+	select {
+	case <-n.Done():
+	default:
+		t.Fatal("done channel not closed")
+	}
+	_, err := n.DB().Get([]byte("foo"), nil)
+	require.Equal(t, leveldb.ErrClosed, err)
+	require.NoError(t, n.Stop())
+	require.Equal(t, ErrNodeStopped, n.Start())
+	require.Equal(t, ErrNodeStopped, n.Health().Component("node").Err)
+}
+
+func TestNode_Reload(t *testing.T) {
+	n, done := newTestNode(t)
+	defer done()
+	prevLevel := log.GetLevel()
+	defer func() {
+		log.SetLevel(prevLevel)
+		log.ClearModuleLevels()
+	}()
+	require.NoError(t, n.Start())
+
+	heartbeats := make(chan struct{}, 1)
+	heartbeatSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case heartbeats <- struct{}{}:
+		default:
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer heartbeatSrv.Close()
+
+	cfg := *n.config()
+	cfg.P2P.MaxInboundPeers = 3
+	cfg.P2P.Port = 10000
+	cfg.Log.ModuleLevels = map[string]string{
+		"updater": "debug",
+	}
+	cfg.Heartbeat.URL = heartbeatSrv.URL
+	cfg.Tuning.Heartbeat.IntervalMS = 10
+
+	invalid := cfg
+	invalid.ModeratorKeys = []string{"not a key"}
+	_, err := n.Reload(&invalid)
+	require.Error(t, err)
+	require.Nil(t, n.Health().Component("heartbeater"))
+
+	res, err := n.Reload(&cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"log.module_levels",
+		"heartbeat.url",
+		"p2p.max_inbound_peers",
+		"tuning.heartbeat.interval_ms",
+	}, res.Applied)
+	require.Equal(t, []string{"p2p.port"}, res.RestartRequired)
+	require.Equal(t, map[string]log.Level{"updater": log.LevelDebug}, log.ModuleLevels())
+	require.NotNil(t, n.Health().Component("heartbeater"))
+	select {
+	case <-heartbeats:
+	case <-time.After(time.Second):
+		t.Fatal("reloaded heartbeater did not send a heartbeat")
+	}
+
+	// settings that need a restart are reported until the node restarts
+	res, err = n.Reload(&cfg)
+	require.NoError(t, err)
+	require.Empty(t, res.Applied)
+	require.Equal(t, []string{"p2p.port"}, res.RestartRequired)
+
+	cfg.Heartbeat.URL = ""
+	res, err = n.Reload(&cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"heartbeat.url"}, res.Applied)
+	require.Nil(t, n.Health().Component("heartbeater"))
+
+	require.NoError(t, n.Stop())
+	_, err = n.Reload(&cfg)
+	require.Equal(t, ErrNodeStopped, err)
+}
+
+type eventLog struct {
+	events []string
+	mu     sync.Mutex
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.events...)
+}
+
+type orderedService struct {
+	name    string
+	events  *eventLog
+	delay   time.Duration
+	readyCh chan struct{}
+	quitCh  chan struct{}
+}
+
+func newOrderedService(name string, events *eventLog, delay time.Duration) *orderedService {
+	return &orderedService{
+		name:    name,
+		events:  events,
+		delay:   delay,
+		readyCh: make(chan struct{}),
+		quitCh:  make(chan struct{}),
+	}
+}
+
+func (s *orderedService) Start() error {
+	s.events.add(s.name + " started")
+	select {
+	case <-time.After(s.delay):
+	case <-s.quitCh:
+		return nil
+	}
+	s.events.add(s.name + " ready")
+	close(s.readyCh)
+	<-s.quitCh
+	return nil
+}
+
+func (s *orderedService) Ready() <-chan struct{} {
+	return s.readyCh
+}
+
+func (s *orderedService) Stop() error {
+	close(s.quitCh)
+	return nil
+}
+
+func (s *orderedService) Health() error {
+	return nil
+}
+
+func TestNode_StartOrder(t *testing.T) {
+	n, done := newTestNode(t)
+	defer done()
+	events := new(eventLog)
+	n.components = []component{
+		{"first", newOrderedService("first", events, 50*time.Millisecond)},
+		{"second", newOrderedService("second", events, 0)},
+	}
+	require.NoError(t, n.Start())
+	require.Equal(t, []string{
+		"first started",
+		"first ready",
+		"second started",
+		"second ready",
+	}, events.get())
+}
+
+func TestNode_StartTimeout(t *testing.T) {
+	n, done := newTestNode(t)
+	defer done()
+	events := new(eventLog)
+	n.StartTimeout = 50 * time.Millisecond
+	n.components = []component{
+		{"stuck", newOrderedService("stuck", events, time.Hour)},
+		{"next", newOrderedService("next", events, 0)},
+	}
+	err := n.Start()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "timed out waiting for stuck to start")
+	require.Equal(t, []string{"stuck started"}, events.get())
+}
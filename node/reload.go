@@ -0,0 +1,166 @@
+package node
+
+import (
+	"fnd/config"
+	"fnd/protocol"
+	"fnd/service"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const heartbeaterComponent = "heartbeater"
+
+// liveSettings are the settings Reload can change without restarting
+// the node.
+var liveSettings = map[string]bool{
+	"log_level":                              true,
+	"log.format":                             true,
+	"log.module_levels":                      true,
+	"ban_lists":                              true,
+	"moderator_keys":                         true,
+	"health.min_free_disk_mb":                true,
+	"heartbeat.moniker":                      true,
+	"heartbeat.url":                          true,
+	"tuning.heartbeat.interval_ms":           true,
+	"tuning.heartbeat.timeout_ms":            true,
+	"p2p.max_inbound_peers":                  true,
+	"p2p.max_outbound_peers":                 true,
+	"tuning.peer_muxer.rate_limits":          true,
+	"tuning.peer_muxer.max_dropped_messages": true,
+	"tuning.peer_muxer.drop_window_ms":       true,
+}
+
+type ReloadResult struct {
+	// Applied lists the changed settings that took effect.
+	Applied []string
+	// RestartRequired lists the changed settings that only take
+	// effect once the node is restarted.
+	RestartRequired []string
+}
+
+// ReloadConfigFile re-reads the config file in the node's home
+// directory and reloads it.
+func (n *Node) ReloadConfigFile() (*ReloadResult, error) {
+	cfg, err := config.ReadConfigFile(n.homeDir)
+	if err != nil {
+		return nil, err
+	}
+	return n.Reload(cfg)
+}
+
+// Reload applies the settings in cfg that can change while the node
+// is running. Nothing is changed if cfg is invalid. Settings that
+// need a restart are reported, and keep being reported by later
+// reloads until the node is restarted.
+func (n *Node) Reload(cfg *config.Config) (*ReloadResult, error) {
+	n.reloadMu.Lock()
+	defer n.reloadMu.Unlock()
+	if n.isStopping() {
+		return nil, ErrNodeStopped
+	}
+
+	parsed, err := parseConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+	logSettings, err := parseLogSettings(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+
+	curr := n.config()
+	res := new(ReloadResult)
+	changed := make(map[string]bool)
+	for _, key := range config.Diff(curr, cfg) {
+		if liveSettings[key] {
+			changed[key] = true
+			res.Applied = append(res.Applied, key)
+		} else {
+			res.RestartRequired = append(res.RestartRequired, key)
+		}
+	}
+
+	next := *curr
+	// ingesting ban lists is the only step that can fail, so it
+	// goes first
+	if changed["ban_lists"] || changed["moderator_keys"] {
+		n.lgr.Info("ingesting ban lists")
+		if err := protocol.IngestBanLists(n.db, n.bs, n.nameLocker, cfg.BanLists, parsed.moderators); err != nil {
+			return nil, errors.Wrap(err, "failed to ingest ban lists")
+		}
+		next.BanLists = cfg.BanLists
+		next.ModeratorKeys = cfg.ModeratorKeys
+		n.mu.Lock()
+		n.moderators = parsed.moderators
+		n.mu.Unlock()
+	}
+	if changed["log_level"] || changed["log.format"] || changed["log.module_levels"] {
+		logSettings.apply()
+		next.LogLevel = cfg.LogLevel
+		next.Log.Format = cfg.Log.Format
+		next.Log.ModuleLevels = cfg.Log.ModuleLevels
+	}
+	if changed["p2p.max_inbound_peers"] || changed["p2p.max_outbound_peers"] {
+		n.pm.SetMaxPeers(cfg.P2P.MaxInboundPeers, cfg.P2P.MaxOutboundPeers)
+		next.P2P.MaxInboundPeers = cfg.P2P.MaxInboundPeers
+		next.P2P.MaxOutboundPeers = cfg.P2P.MaxOutboundPeers
+	}
+	if changed["tuning.peer_muxer.rate_limits"] || changed["tuning.peer_muxer.max_dropped_messages"] || changed["tuning.peer_muxer.drop_window_ms"] {
+		n.mux.SetRateLimits(
+			parsed.rateLimits,
+			cfg.Tuning.PeerMuxer.MaxDroppedMessages,
+			config.ConvertDuration(cfg.Tuning.PeerMuxer.DropWindowMS, time.Millisecond),
+		)
+		next.Tuning.PeerMuxer.RateLimits = cfg.Tuning.PeerMuxer.RateLimits
+		next.Tuning.PeerMuxer.MaxDroppedMessages = cfg.Tuning.PeerMuxer.MaxDroppedMessages
+		next.Tuning.PeerMuxer.DropWindowMS = cfg.Tuning.PeerMuxer.DropWindowMS
+	}
+	if changed["heartbeat.moniker"] || changed["heartbeat.url"] || changed["tuning.heartbeat.interval_ms"] || changed["tuning.heartbeat.timeout_ms"] {
+		n.reloadHeartbeater(cfg)
+		next.Heartbeat = cfg.Heartbeat
+		next.Tuning.Heartbeat = cfg.Tuning.Heartbeat
+	}
+	next.Health.MinFreeDiskMB = cfg.Health.MinFreeDiskMB
+
+	n.mu.Lock()
+	n.cfg = &next
+	n.mu.Unlock()
+	n.lgr.Info("reloaded config", "applied", res.Applied, "restart_required", res.RestartRequired)
+	return res, nil
+}
+
+// reloadHeartbeater replaces the running heartbeater with one
+// configured by cfg, or removes it if cfg disables heartbeats.
+func (n *Node) reloadHeartbeater(cfg *config.Config) {
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return
+	}
+	var old service.Service
+	var components []component
+	for _, c := range n.components {
+		if c.name == heartbeaterComponent {
+			old = c.svc
+			continue
+		}
+		components = append(components, c)
+	}
+	var hb service.Service
+	if cfg.Heartbeat.URL != "" {
+		hb = newHeartbeater(cfg, n.PeerID())
+		components = append(components, component{heartbeaterComponent, hb})
+	}
+	n.components = components
+	started := n.started
+	if started && hb != nil {
+		n.runService(hb)
+	}
+	n.mu.Unlock()
+
+	if started && old != nil {
+		if err := old.Stop(); err != nil {
+			n.lgr.Error("error stopping heartbeater", "err", err)
+		}
+	}
+}
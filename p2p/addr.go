@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"github.com/pkg/errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParseAddr parses an IP address, onion address, or hostname with
+// an optional port. IPv6 addresses with a port must be bracketed,
+// e.g. [::1]:9097. The standard port is returned if no port is
+// specified.
+func ParseAddr(addr string) (string, int, error) {
+	host := addr
+	port := StandardPort
+	if strings.HasPrefix(addr, "[") || strings.Count(addr, ":") == 1 {
+		h, p, err := net.SplitHostPort(addr)
+		if err != nil {
+			if !strings.HasSuffix(addr, "]") {
+				return "", 0, errors.Wrap(err, "mal-formed address")
+			}
+			h = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+		} else {
+			parsedPort, err := strconv.ParseUint(p, 10, 16)
+			if err != nil || parsedPort == 0 {
+				return "", 0, errors.New("mal-formed port")
+			}
+			port = int(parsedPort)
+		}
+		host = h
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String(), port, nil
+	}
+	if !isValidHostname(host) {
+		return "", 0, errors.New("mal-formed host")
+	}
+	return strings.ToLower(host), port, nil
+}
+
+func isValidHostname(host string) bool {
+	if len(host) == 0 || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			isAlnum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+			if !isAlnum && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// JoinAddr formats ip and port as a dialable address. A zero port
+// is treated as the standard port.
+func JoinAddr(ip string, port int) string {
+	if port == 0 {
+		port = StandardPort
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(port))
+}
@@ -0,0 +1,490 @@
+package p2p
+
+import (
+	"encoding/binary"
+	"fnd/crypto"
+	"fnd/log"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AddrNewBucketCount   = 256
+	AddrTriedBucketCount = 64
+	AddrBucketSize       = 64
+
+	// AddrNewBucketsPerSourceGroup limits how many new buckets
+	// addresses learned from a single source group can occupy.
+	AddrNewBucketsPerSourceGroup = 32
+	// AddrTriedBucketsPerGroup limits how many tried buckets
+	// addresses in a single group can occupy.
+	AddrTriedBucketsPerGroup = 8
+
+	AddrHorizon        = 30 * 24 * time.Hour
+	AddrMaxRetries     = 3
+	AddrMaxFailures    = 10
+	AddrMinFailure     = 7 * 24 * time.Hour
+	AddrRecentAttempt  = 10 * time.Minute
+	AddrMaxSelectTries = 1000
+	AddrFlushInterval  = 15 * time.Minute
+)
+
+type addrInfo struct {
+	*store.AddrBookEntry
+	bucket int
+	slot   int
+}
+
+func (a *addrInfo) addr() string {
+	return JoinAddr(a.IP, a.Port)
+}
+
+func (a *addrInfo) isTerrible(now time.Time) bool {
+	if now.Sub(a.LastAttempt) < time.Minute {
+		return false
+	}
+	if a.LastSuccess.IsZero() && now.Sub(a.AddedAt) > AddrHorizon {
+		return true
+	}
+	if a.LastSuccess.IsZero() && a.Attempts >= AddrMaxRetries {
+		return true
+	}
+	if now.Sub(a.LastSuccess) > AddrMinFailure && a.Attempts >= AddrMaxFailures {
+		return true
+	}
+	return false
+}
+
+func (a *addrInfo) chance(now time.Time) float64 {
+	chance := 1.0
+	if now.Sub(a.LastAttempt) < AddrRecentAttempt {
+		chance *= 0.01
+	}
+	attempts := a.Attempts
+	if attempts > 8 {
+		attempts = 8
+	}
+	return chance * math.Pow(0.66, float64(attempts))
+}
+
+// AddrManager keeps track of addresses learned from seeds and peer
+// exchange. Addresses are split into a "new" table of addresses that
+// have never been connected to and a "tried" table of addresses that
+// have. Both tables are bucketed by network group so that a single
+// source or network range cannot fill up the table, which makes it
+// difficult for an attacker to eclipse the node.
+type AddrManager struct {
+	db         *leveldb.DB
+	key        crypto.Hash
+	addrs      map[string]*addrInfo
+	newTable   [AddrNewBucketCount][AddrBucketSize]*addrInfo
+	triedTable [AddrTriedBucketCount][AddrBucketSize]*addrInfo
+	newCount   int
+	triedCount int
+	rand       *rand.Rand
+	mtx        sync.Mutex
+	lgr        log.Logger
+}
+
+func NewAddrManager(db *leveldb.DB) (*AddrManager, error) {
+	key, err := store.GetAddrBookKey(db)
+	if err != nil {
+		return nil, err
+	}
+	am := &AddrManager{
+		db:    db,
+		key:   key,
+		addrs: make(map[string]*addrInfo),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		lgr:   log.WithModule("addr-manager"),
+	}
+	if err := am.load(); err != nil {
+		return nil, err
+	}
+	return am, nil
+}
+
+func (am *AddrManager) load() error {
+	stream, err := store.StreamAddrBook(am.db)
+	if err != nil {
+		return errors.Wrap(err, "error opening address book stream")
+	}
+	defer stream.Close()
+	for {
+		entry, err := stream.Next()
+		if err != nil {
+			return errors.Wrap(err, "error streaming address book")
+		}
+		if entry == nil {
+			break
+		}
+		if entry.Port == 0 {
+			entry.Port = StandardPort
+		}
+		if _, ok := am.addrs[JoinAddr(entry.IP, entry.Port)]; ok || !isAddrValid(entry.IP) {
+			continue
+		}
+		info := &addrInfo{AddrBookEntry: entry}
+		if entry.Tried {
+			am.placeTried(info)
+		} else {
+			am.placeNew(info)
+		}
+	}
+	if len(am.addrs) > 0 {
+		return nil
+	}
+
+	// migrate peers from before the address book existed. these
+	// were all connected to successfully, so they go into tried.
+	peerStream, err := store.StreamPeers(am.db, false)
+	if err != nil {
+		return errors.Wrap(err, "error opening peer stream")
+	}
+	defer peerStream.Close()
+	for {
+		peer, err := peerStream.Next()
+		if err != nil {
+			return errors.Wrap(err, "error streaming stored peers")
+		}
+		if peer == nil {
+			break
+		}
+		port := peer.Port
+		if port == 0 {
+			port = StandardPort
+		}
+		if _, ok := am.addrs[JoinAddr(peer.IP, port)]; ok || !isAddrValid(peer.IP) {
+			continue
+		}
+		am.placeTried(&addrInfo{
+			AddrBookEntry: &store.AddrBookEntry{
+				ID:          peer.ID,
+				IP:          peer.IP,
+				Port:        port,
+				Source:      peer.IP,
+				Verify:      peer.Verify,
+				Tried:       true,
+				AddedAt:     peer.LastSeen,
+				LastSuccess: peer.LastSeen,
+			},
+		})
+	}
+	return nil
+}
+
+// Add adds an address learned from source to the new table. It
+// returns false if the address is already known or if there is no
+// room for it. A zero port is treated as the standard port, and
+// zero services means they are unknown.
+func (am *AddrManager) Add(id crypto.Hash, ip string, port int, services uint64, source string, verify bool) bool {
+	if !isAddrValid(ip) {
+		return false
+	}
+	ip, port = normalizeAddr(ip, port)
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if existing, ok := am.addrs[JoinAddr(ip, port)]; ok {
+		if existing.ID == crypto.ZeroHash {
+			existing.ID = id
+		}
+		if existing.Services == 0 {
+			existing.Services = services
+		}
+		existing.Verify = existing.Verify || verify
+		return false
+	}
+	info := &addrInfo{
+		AddrBookEntry: &store.AddrBookEntry{
+			ID:       id,
+			IP:       ip,
+			Port:     port,
+			Services: services,
+			Source:   source,
+			Verify:   verify,
+			AddedAt:  time.Now(),
+		},
+	}
+	return am.placeNew(info)
+}
+
+// Attempt records a connection attempt to ip and port.
+func (am *AddrManager) Attempt(ip string, port int) {
+	ip, port = normalizeAddr(ip, port)
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	info, ok := am.addrs[JoinAddr(ip, port)]
+	if !ok {
+		return
+	}
+	info.Attempts++
+	info.LastAttempt = time.Now()
+}
+
+// Good marks ip and port as successfully connected to, and moves
+// the address into the tried table. services are the ones the peer
+// advertised during the handshake.
+func (am *AddrManager) Good(id crypto.Hash, ip string, port int, services uint64, verify bool) {
+	if !isAddrValid(ip) {
+		return
+	}
+	ip, port = normalizeAddr(ip, port)
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	now := time.Now()
+	info, ok := am.addrs[JoinAddr(ip, port)]
+	if !ok {
+		info = &addrInfo{
+			AddrBookEntry: &store.AddrBookEntry{
+				IP:      ip,
+				Port:    port,
+				Source:  ip,
+				AddedAt: now,
+			},
+		}
+	}
+	info.ID = id
+	info.Services = services
+	info.Verify = info.Verify || verify
+	info.Attempts = 0
+	info.LastAttempt = now
+	info.LastSuccess = now
+	if info.Tried {
+		return
+	}
+	if ok {
+		am.clearNew(info)
+	}
+
+	bucket, slot := am.triedPosition(ip, port)
+	if evicted := am.triedTable[bucket][slot]; evicted != nil {
+		am.clearTried(evicted)
+		if !am.placeNew(evicted) {
+			delete(am.addrs, evicted.addr())
+		}
+	}
+	am.placeTried(info)
+}
+
+// Select picks an address to dial. Tried and new addresses are
+// chosen with equal probability, and addresses that have failed
+// recently are less likely to be picked. Addresses for which skip
+// returns true are never picked.
+func (am *AddrManager) Select(skip func(entry *store.AddrBookEntry) bool) *store.AddrBookEntry {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	now := time.Now()
+	var tried, fresh []*addrInfo
+	for _, info := range am.addrs {
+		if skip != nil && skip(info.AddrBookEntry) {
+			continue
+		}
+		if info.Tried {
+			tried = append(tried, info)
+		} else {
+			fresh = append(fresh, info)
+		}
+	}
+	if len(tried) == 0 && len(fresh) == 0 {
+		return nil
+	}
+
+	candidates := fresh
+	if len(fresh) == 0 || (len(tried) > 0 && am.rand.Intn(2) == 0) {
+		candidates = tried
+	}
+	factor := 1.0
+	for i := 0; i < AddrMaxSelectTries; i++ {
+		info := candidates[am.rand.Intn(len(candidates))]
+		if am.rand.Float64() < info.chance(now)*factor {
+			entry := *info.AddrBookEntry
+			return &entry
+		}
+		factor *= 1.2
+	}
+	return nil
+}
+
+// Sample returns up to n random addresses that are not terrible,
+// for sending to peers.
+func (am *AddrManager) Sample(n int) []*store.AddrBookEntry {
+	if n <= 0 {
+		return nil
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	now := time.Now()
+	var out []*store.AddrBookEntry
+	for _, info := range am.addrs {
+		if info.isTerrible(now) {
+			continue
+		}
+		entry := *info.AddrBookEntry
+		out = append(out, &entry)
+	}
+	am.rand.Shuffle(len(out), func(i, j int) {
+		out[i], out[j] = out[j], out[i]
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Counts returns the number of addresses in the new and tried
+// tables.
+func (am *AddrManager) Counts() (int, int) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	return am.newCount, am.triedCount
+}
+
+// Flush writes the address book to the database.
+func (am *AddrManager) Flush() error {
+	am.mtx.Lock()
+	entries := make([]*store.AddrBookEntry, 0, len(am.addrs))
+	for _, info := range am.addrs {
+		entry := *info.AddrBookEntry
+		entries = append(entries, &entry)
+	}
+	am.mtx.Unlock()
+	if err := store.SetAddrBook(am.db, entries); err != nil {
+		return errors.Wrap(err, "error flushing address book")
+	}
+	am.lgr.Debug("flushed address book", "count", len(entries))
+	return nil
+}
+
+func (am *AddrManager) placeNew(info *addrInfo) bool {
+	bucket, slot := am.newPosition(info.IP, info.Port, info.Source)
+	if existing := am.newTable[bucket][slot]; existing != nil {
+		if !existing.isTerrible(time.Now()) {
+			return false
+		}
+		am.clearNew(existing)
+		delete(am.addrs, existing.addr())
+	}
+	info.Tried = false
+	info.bucket = bucket
+	info.slot = slot
+	am.newTable[bucket][slot] = info
+	am.addrs[info.addr()] = info
+	am.newCount++
+	return true
+}
+
+func (am *AddrManager) placeTried(info *addrInfo) {
+	bucket, slot := am.triedPosition(info.IP, info.Port)
+	if existing := am.triedTable[bucket][slot]; existing != nil {
+		am.clearTried(existing)
+		delete(am.addrs, existing.addr())
+	}
+	info.Tried = true
+	info.bucket = bucket
+	info.slot = slot
+	am.triedTable[bucket][slot] = info
+	am.addrs[info.addr()] = info
+	am.triedCount++
+}
+
+func (am *AddrManager) clearNew(info *addrInfo) {
+	am.newTable[info.bucket][info.slot] = nil
+	am.newCount--
+}
+
+func (am *AddrManager) clearTried(info *addrInfo) {
+	am.triedTable[info.bucket][info.slot] = nil
+	am.triedCount--
+}
+
+func (am *AddrManager) newPosition(ip string, port int, source string) (int, int) {
+	addr := JoinAddr(ip, port)
+	group := AddrGroup(ip)
+	sourceGroup := AddrGroup(source)
+	h := am.hash([]byte(group), []byte(sourceGroup)) % AddrNewBucketsPerSourceGroup
+	bucket := int(am.hash([]byte(sourceGroup), uint64Bytes(h)) % AddrNewBucketCount)
+	slot := int(am.hash([]byte("new"), uint64Bytes(uint64(bucket)), []byte(addr)) % AddrBucketSize)
+	return bucket, slot
+}
+
+func (am *AddrManager) triedPosition(ip string, port int) (int, int) {
+	addr := JoinAddr(ip, port)
+	group := AddrGroup(ip)
+	h := am.hash([]byte(addr)) % AddrTriedBucketsPerGroup
+	bucket := int(am.hash([]byte(group), uint64Bytes(h)) % AddrTriedBucketCount)
+	slot := int(am.hash([]byte("tried"), uint64Bytes(uint64(bucket)), []byte(addr)) % AddrBucketSize)
+	return bucket, slot
+}
+
+func (am *AddrManager) hash(data ...[]byte) uint64 {
+	h := crypto.Blake2B256(append([][]byte{am.key[:]}, data...)...)
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// AddrGroup returns the network group of ip: its /16 for IPv4
+// addresses and its /32 for IPv6 addresses. Onion addresses are
+// grouped by their first character, and unroutable addresses each
+// get their own group.
+func AddrGroup(ip string) string {
+	if store.IsOnion(ip) {
+		return "onion/" + strings.ToLower(ip[:1])
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if !isAddrRoutable(ip) {
+		return "unroutable:" + parsed.String()
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return net.IP(v4).Mask(net.CIDRMask(16, 32)).String() + "/16"
+	}
+	return parsed.Mask(net.CIDRMask(32, 128)).String() + "/32"
+}
+
+// isAddrValid returns true for IPs and onion addresses. Other
+// hostnames are not accepted so that peers can't make us perform
+// arbitrary DNS lookups.
+func isAddrValid(ip string) bool {
+	if store.IsOnion(ip) {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	return parsed != nil && !parsed.IsUnspecified() && !parsed.IsMulticast()
+}
+
+func isAddrRoutable(ip string) bool {
+	if store.IsOnion(ip) {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	return !parsed.IsUnspecified() && !parsed.IsLoopback() && !parsed.IsLinkLocalUnicast() && !parsed.IsMulticast()
+}
+
+func normalizeAddr(ip string, port int) (string, int) {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		ip = parsed.String()
+	} else {
+		ip = strings.ToLower(ip)
+	}
+	if port == 0 {
+		port = StandardPort
+	}
+	return ip, port
+}
+
+func uint64Bytes(n uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, n)
+	return buf
+}
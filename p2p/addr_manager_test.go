@@ -0,0 +1,109 @@
+package p2p
+
+import (
+	"fmt"
+	"fnd/crypto"
+	"fnd/store"
+	"fnd/testutil/testfs"
+	"fnd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"testing"
+)
+
+func setupAddrManager(t *testing.T) (*AddrManager, *leveldb.DB, func()) {
+	dir, done := testfs.NewTempDir(t)
+	db, err := store.Open(dir)
+	require.NoError(t, err)
+	am, err := NewAddrManager(db)
+	require.NoError(t, err)
+	return am, db, func() {
+		require.NoError(t, db.Close())
+		done()
+	}
+}
+
+func TestAddrGroup(t *testing.T) {
+	require.Equal(t, "1.2.0.0/16", AddrGroup("1.2.3.4"))
+	require.Equal(t, AddrGroup("1.2.3.4"), AddrGroup("1.2.200.100"))
+	require.NotEqual(t, AddrGroup("1.2.3.4"), AddrGroup("1.3.3.4"))
+	require.Equal(t, "2001:db8::/32", AddrGroup("2001:db8:1:2::1"))
+	require.NotEqual(t, AddrGroup("127.0.0.1"), AddrGroup("127.0.0.2"))
+	require.Equal(t, "onion/e", AddrGroup(testOnion))
+}
+
+func TestAddrManager_SourceLimit(t *testing.T) {
+	am, _, done := setupAddrManager(t)
+	defer done()
+
+	// a single source should only be able to fill a fraction of
+	// the new table, no matter how many groups it advertises.
+	var added int
+	for i := 0; i < 256; i++ {
+		for j := 0; j < 64; j++ {
+			if am.Add(crypto.ZeroHash, fmt.Sprintf("%d.%d.1.1", i+1, j), 0, 0, "8.8.8.8", false) {
+				added++
+			}
+		}
+	}
+	newCount, _ := am.Counts()
+	require.Equal(t, added, newCount)
+	require.True(t, added <= AddrNewBucketsPerSourceGroup*AddrBucketSize)
+}
+
+func TestAddrManager_GoodAndSelect(t *testing.T) {
+	am, db, done := setupAddrManager(t)
+	defer done()
+
+	id := crypto.Rand32()
+	require.True(t, am.Add(id, "10.1.1.1", 0, 0, "8.8.8.8", false))
+	require.True(t, am.Add(crypto.ZeroHash, "10.2.1.1", 0, 0, "9.9.9.9", false))
+	require.False(t, am.Add(id, "10.1.1.1", 0, 0, "9.9.9.9", false))
+	require.False(t, am.Add(id, "10.1.1.1", StandardPort, wire.ServiceBlobs, "9.9.9.9", false))
+	am.Attempt("10.1.1.1", 0)
+	am.Good(id, "10.1.1.1", 0, wire.LegacyServices, true)
+	newCount, triedCount := am.Counts()
+	require.Equal(t, 1, newCount)
+	require.Equal(t, 1, triedCount)
+
+	selected := am.Select(func(entry *store.AddrBookEntry) bool {
+		return !entry.Tried
+	})
+	require.NotNil(t, selected)
+	require.Equal(t, "10.1.1.1", selected.IP)
+	require.EqualValues(t, id, selected.ID)
+	require.True(t, selected.Verify)
+	require.Equal(t, StandardPort, selected.Port)
+	require.Equal(t, wire.LegacyServices, selected.Services)
+	require.Nil(t, am.Select(func(entry *store.AddrBookEntry) bool {
+		return true
+	}))
+	require.Len(t, am.Sample(10), 2)
+	require.Len(t, am.Sample(1), 1)
+
+	require.NoError(t, am.Flush())
+	reloaded, err := NewAddrManager(db)
+	require.NoError(t, err)
+	require.Equal(t, am.key, reloaded.key)
+	newCount, triedCount = reloaded.Counts()
+	require.Equal(t, 1, newCount)
+	require.Equal(t, 1, triedCount)
+}
+
+func TestAddrManager_MigratesPeers(t *testing.T) {
+	dir, done := testfs.NewTempDir(t)
+	defer done()
+	db, err := store.Open(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, store.SetPeer(db, crypto.Rand32(), "10.1.1.1", 9098, true))
+	am, err := NewAddrManager(db)
+	require.NoError(t, err)
+	newCount, triedCount := am.Counts()
+	require.Equal(t, 0, newCount)
+	require.Equal(t, 1, triedCount)
+	selected := am.Select(nil)
+	require.NotNil(t, selected)
+	require.Equal(t, 9098, selected.Port)
+}
@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		ip   string
+		port int
+	}{
+		{"1.2.3.4", "1.2.3.4", StandardPort},
+		{"1.2.3.4:9098", "1.2.3.4", 9098},
+		{"2001:db8::1", "2001:db8::1", StandardPort},
+		{"[2001:db8::1]", "2001:db8::1", StandardPort},
+		{"[2001:db8::1]:9098", "2001:db8::1", 9098},
+		{"2001:0db8:0000::0001", "2001:db8::1", StandardPort},
+		{"Seed.Example.com:9098", "seed.example.com", 9098},
+		{"expyuzz4wqqyqhjn.onion", "expyuzz4wqqyqhjn.onion", StandardPort},
+	}
+	for _, tt := range tests {
+		ip, port, err := ParseAddr(tt.addr)
+		require.NoError(t, err, tt.addr)
+		require.Equal(t, tt.ip, ip, tt.addr)
+		require.Equal(t, tt.port, port, tt.addr)
+	}
+
+	for _, addr := range []string{"", "foo bar", "-foo.com", "foo..com", "1.2.3.4:", "1.2.3.4:0", "1.2.3.4:70000", "[2001:db8::1]:foo", "2001:db8::1]"} {
+		_, _, err := ParseAddr(addr)
+		require.Error(t, err, addr)
+	}
+
+	require.Equal(t, "1.2.3.4:9097", JoinAddr("1.2.3.4", 0))
+	require.Equal(t, "[2001:db8::1]:9098", JoinAddr("2001:db8::1", 9098))
+}
@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"bytes"
+	"fnd/config"
+	"fnd/wire"
+	"github.com/pkg/errors"
+)
+
+var defaultCompression []uint8
+
+func ParseCompression(names []string) ([]uint8, error) {
+	var codecs []uint8
+	for _, name := range names {
+		codec, err := wire.ParseCompression(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid compression config")
+		}
+		codecs = append(codecs, codec)
+	}
+	return codecs, nil
+}
+
+// compressFor wraps message in a wire.Compressed if the peer supports
+// one of our codecs and compression makes the message smaller.
+func (p *PeerMuxer) compressFor(peer Peer, message wire.Message) (wire.Message, error) {
+	if !wire.IsCompressible(message.MsgType()) {
+		return message, nil
+	}
+	codec := wire.NegotiateCompression(p.Compression, peer.Extensions().Compression())
+	if codec == wire.CompressionNone {
+		return message, nil
+	}
+	compressed, err := wire.NewCompressed(codec, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "error compressing message")
+	}
+	var raw bytes.Buffer
+	if err := message.Encode(&raw); err != nil {
+		return nil, errors.Wrap(err, "error encoding message")
+	}
+	if len(compressed.Data) >= raw.Len() {
+		return message, nil
+	}
+	return compressed, nil
+}
+
+// decompress replaces a compressed envelope's message with the one
+// it wraps. The returned envelope keeps the original's signature,
+// which was already validated over the compressed message.
+func (p *PeerMuxer) decompress(envelope *wire.Envelope) (*wire.Envelope, error) {
+	compressed := envelope.Message.(*wire.Compressed)
+	if wire.NegotiateCompression(p.Compression, []uint8{compressed.Codec}) == wire.CompressionNone {
+		return nil, errors.Errorf("unadvertised compression codec %d", compressed.Codec)
+	}
+	msg, err := compressed.Unwrap()
+	if err != nil {
+		return nil, errors.Wrap(err, "error decompressing message")
+	}
+	return &wire.Envelope{
+		Magic:       envelope.Magic,
+		MessageType: msg.MsgType(),
+		Timestamp:   envelope.Timestamp,
+		Message:     msg,
+		Signature:   envelope.Signature,
+	}, nil
+}
+
+func init() {
+	codecs, err := ParseCompression(config.DefaultConfig.Tuning.PeerMuxer.Compression)
+	if err != nil {
+		panic(err)
+	}
+	defaultCompression = codecs
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"fnd/crypto"
+	"fnd/testutil"
+	"fnd/testutil/testcrypto"
+	"fnd/wire"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPeerMuxer_Compression(t *testing.T) {
+	localPriv, localPub := testcrypto.RandKey()
+	remotePriv, remotePub := testcrypto.RandKey()
+	localPeerID := crypto.HashPub(localPub)
+	remotePeerID := crypto.HashPub(remotePub)
+	clientConn, serverConn := testutil.NewTCPConn(t)
+
+	localMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(localPriv))
+	localPeer := NewPeer(Outbound, clientConn)
+	require.NoError(t, localMux.AddPeer(remotePeerID, localPeer))
+	defer localPeer.Close()
+
+	remoteMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(remotePriv))
+	received := make(chan *wire.Envelope, 10)
+	remoteMux.AddMessageHandler(func(peerID crypto.Hash, envelope *wire.Envelope) {
+		received <- envelope
+	})
+	remotePeer := NewPeer(Inbound, serverConn)
+	require.NoError(t, remoteMux.AddPeer(localPeerID, remotePeer))
+	defer remotePeer.Close()
+
+	sectorRes := &wire.SectorRes{
+		Name:     "foobar",
+		SectorID: 1,
+	}
+	copy(sectorRes.Sector[:], "hello")
+
+	// peers that don't advertise compression get the full sector
+	require.NoError(t, localMux.Send(remotePeerID, sectorRes))
+	envelope := requireReceived(t, received)
+	require.Equal(t, wire.MessageTypeSectorRes, envelope.MessageType)
+	require.True(t, sectorRes.Equals(envelope.Message))
+	uncompressedBytes := remoteMux.PeerUsage(localPeerID)[wire.MessageTypeSectorRes].RxBytes
+	require.True(t, uncompressedBytes > 4096)
+
+	localPeer.SetCapabilities(wire.LegacyServices, wire.Extensions{
+		wire.NewCompressionExtension([]uint8{wire.CompressionZeroRun}),
+	})
+	require.NoError(t, localMux.Send(remotePeerID, sectorRes))
+	envelope = requireReceived(t, received)
+	require.Equal(t, wire.MessageTypeSectorRes, envelope.MessageType)
+	require.True(t, sectorRes.Equals(envelope.Message))
+	compressedBytes := remoteMux.PeerUsage(localPeerID)[wire.MessageTypeSectorRes].RxBytes - uncompressedBytes
+	require.True(t, compressedBytes < 200)
+
+	// other messages are never compressed
+	require.NoError(t, localMux.Send(remotePeerID, &wire.Ping{}))
+	envelope = requireReceived(t, received)
+	require.Equal(t, wire.MessageTypePing, envelope.MessageType)
+}
+
+func TestPeerMuxer_RejectsUnadvertisedCompression(t *testing.T) {
+	localPriv, localPub := testcrypto.RandKey()
+	remotePriv, remotePub := testcrypto.RandKey()
+	localPeerID := crypto.HashPub(localPub)
+	clientConn, serverConn := testutil.NewTCPConn(t)
+
+	localMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(localPriv))
+	localPeer := NewPeer(Outbound, clientConn)
+	require.NoError(t, localMux.AddPeer(crypto.HashPub(remotePub), localPeer))
+	defer localPeer.Close()
+
+	remoteMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(remotePriv))
+	remoteMux.Compression = []uint8{wire.CompressionFlate}
+	closed := make(chan struct{})
+	remoteMux.obs.On("close", func(peerID crypto.Hash) {
+		close(closed)
+	})
+	require.NoError(t, remoteMux.AddPeer(localPeerID, NewPeer(Inbound, serverConn)))
+
+	compressed, err := wire.NewCompressed(wire.CompressionZeroRun, &wire.SectorRes{
+		Name: "foobar",
+	})
+	require.NoError(t, err)
+	require.NoError(t, localMux.Send(crypto.HashPub(remotePub), compressed))
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("peer was not disconnected")
+	}
+}
+
+func requireReceived(t *testing.T, ch chan *wire.Envelope) *wire.Envelope {
+	select {
+	case envelope := <-ch:
+		return envelope
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
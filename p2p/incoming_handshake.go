@@ -30,6 +30,8 @@ func HandleIncomingHandshake(ctx context.Context, cfg *HandshakeConfig) (crypto.
 		LocalNonce:      localNonce,
 		RemoteNonce:     theirHelloMsg.LocalNonce,
 		PublicKey:       cfg.Signer.Pub(),
+		Services:        cfg.Services,
+		Extensions:      cfg.Extensions,
 	}
 	if err := WriteEnvelope(ctx, cfg.Peer, cfg.Signer, cfg.Magic, ourHelloMsg); err != nil {
 		return crypto.ZeroHash, errors.Wrap(err, "failed to respond with hello message")
@@ -49,6 +51,10 @@ func HandleIncomingHandshake(ctx context.Context, cfg *HandshakeConfig) (crypto.
 	if theirHelloAck.Nonce != localNonce {
 		return crypto.ZeroHash, ErrInvalidNonce
 	}
+	// extensions in the ack take precedence over those in the hello
+	exts := append(wire.Extensions{}, theirHelloMsg.Extensions...)
+	exts = append(exts, theirHelloAck.Extensions...)
+	cfg.Peer.SetCapabilities(remoteServices(theirHelloMsg), exts)
 
 	return theirPeerID, nil
 }
@@ -31,12 +31,23 @@ func TestHandleIncomingHandshake(t *testing.T) {
 			ProtocolVersion: 1,
 			Peer:            setup.outPeer,
 			Signer:          setup.outSigner,
+			Services:        wire.ServiceUpdateInv,
+			Extensions: wire.Extensions{
+				wire.NewListenPortExtension(9097),
+			},
 		})
 		require.NoError(t, err)
 		doneCh <- struct{}{}
 	}()
 	<-doneCh
 	<-doneCh
+	require.Equal(t, wire.ServiceUpdateInv, setup.inPeer.Services())
+	port, ok := setup.inPeer.Extensions().ListenPort()
+	require.True(t, ok)
+	require.Equal(t, 9097, port)
+	// peers that don't advertise services are assumed to be legacy nodes
+	require.Equal(t, wire.LegacyServices, setup.outPeer.Services())
+	require.Empty(t, setup.outPeer.Extensions())
 	setup.Close(t)
 }
 
@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"fmt"
 	"fnd/log"
 	"fnd/service"
 	"github.com/pkg/errors"
@@ -15,22 +14,25 @@ type Listener struct {
 	manager PeerManager
 	lgr     log.Logger
 	quitCh  chan struct{}
+	readyCh chan struct{}
 	once    sync.Once
 }
 
 var _ service.Service = (*Listener)(nil)
 
-func NewListener(host string, manager PeerManager) *Listener {
+func NewListener(host string, port int, manager PeerManager) *Listener {
 	return &Listener{
 		host:    host,
+		port:    port,
 		manager: manager,
 		lgr:     log.WithModule("listener"),
 		quitCh:  make(chan struct{}),
+		readyCh: make(chan struct{}),
 	}
 }
 
 func (l *Listener) Start() error {
-	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", l.host, StandardPort))
+	listener, err := net.Listen("tcp", JoinAddr(l.host, l.port))
 	if err != nil {
 		return err
 	}
@@ -47,6 +49,7 @@ func (l *Listener) Start() error {
 	}()
 
 	l.lgr.Info("listening for connections", "host", l.host, "port", l.port)
+	close(l.readyCh)
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
@@ -68,7 +71,16 @@ func (l *Listener) Start() error {
 	}
 }
 
+// Ready is closed once the listener accepts connections.
+func (l *Listener) Ready() <-chan struct{} {
+	return l.readyCh
+}
+
 func (l *Listener) Stop() error {
 	close(l.quitCh)
 	return nil
 }
+
+func (l *Listener) Health() error {
+	return nil
+}
@@ -20,6 +20,18 @@ type HandshakeConfig struct {
 	ProtocolVersion uint32
 	Peer            Peer
 	Signer          crypto.Signer
+	Services        uint64
+	Extensions      wire.Extensions
+}
+
+// remoteServices returns the services advertised in hello. Nodes
+// that predate the services bitfield don't send one, and are
+// assumed to have the legacy services.
+func remoteServices(hello *wire.Hello) uint64 {
+	if hello.Services == 0 {
+		return wire.LegacyServices
+	}
+	return hello.Services
 }
 
 func HandleOutgoingHandshake(ctx context.Context, cfg *HandshakeConfig) (crypto.Hash, error) {
@@ -29,6 +41,8 @@ func HandleOutgoingHandshake(ctx context.Context, cfg *HandshakeConfig) (crypto.
 		LocalNonce:      localNonce,
 		PublicKey:       cfg.Signer.Pub(),
 		UserAgent:       version.UserAgent,
+		Services:        cfg.Services,
+		Extensions:      cfg.Extensions,
 	}
 
 	err := WriteEnvelope(ctx, cfg.Peer, cfg.Signer, cfg.Magic, ourHelloMsg)
@@ -56,6 +70,7 @@ func HandleOutgoingHandshake(ctx context.Context, cfg *HandshakeConfig) (crypto.
 	if theirHelloMsg.RemoteNonce != localNonce {
 		return crypto.ZeroHash, ErrInvalidNonce
 	}
+	cfg.Peer.SetCapabilities(remoteServices(theirHelloMsg), theirHelloMsg.Extensions)
 
 	remoteNonce := theirHelloMsg.LocalNonce
 	ourHelloAckMsg := &wire.HelloAck{
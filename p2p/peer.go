@@ -11,6 +11,7 @@ import (
 	"math"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -62,6 +63,9 @@ type Peer interface {
 	Close() error
 	BandwidthUsage() (uint64, uint64)
 	CloseReason() error
+	Services() uint64
+	Extensions() wire.Extensions
+	SetCapabilities(services uint64, exts wire.Extensions)
 }
 
 type PeerImpl struct {
@@ -80,6 +84,9 @@ type PeerImpl struct {
 	closeMu       sync.Mutex
 	closeReason   error
 	closeReasonMu sync.Mutex
+	services      uint64
+	exts          wire.Extensions
+	extsMu        sync.Mutex
 }
 
 type sendReq struct {
@@ -211,6 +218,9 @@ func (p *PeerImpl) LocalAddr() string {
 }
 
 func (p *PeerImpl) RemoteIP() string {
+	if proxied, ok := p.conn.RemoteAddr().(*ProxiedAddr); ok {
+		return proxied.Host
+	}
 	return p.conn.RemoteAddr().(*net.TCPAddr).IP.String()
 }
 
@@ -219,6 +229,9 @@ func (p *PeerImpl) RemoteAddr() string {
 }
 
 func (p *PeerImpl) RemotePort() int {
+	if proxied, ok := p.conn.RemoteAddr().(*ProxiedAddr); ok {
+		return proxied.Port
+	}
 	return p.conn.RemoteAddr().(*net.TCPAddr).Port
 }
 
@@ -226,6 +239,23 @@ func (p *PeerImpl) BandwidthUsage() (uint64, uint64) {
 	return p.connW.Count(), p.connR.Count()
 }
 
+func (p *PeerImpl) Services() uint64 {
+	return atomic.LoadUint64(&p.services)
+}
+
+func (p *PeerImpl) Extensions() wire.Extensions {
+	p.extsMu.Lock()
+	defer p.extsMu.Unlock()
+	return p.exts
+}
+
+func (p *PeerImpl) SetCapabilities(services uint64, exts wire.Extensions) {
+	atomic.StoreUint64(&p.services, services)
+	p.extsMu.Lock()
+	p.exts = exts
+	p.extsMu.Unlock()
+}
+
 func (p *PeerImpl) CloseReason() error {
 	p.closeReasonMu.Lock()
 	defer p.closeReasonMu.Unlock()
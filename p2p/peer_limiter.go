@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"fnd/config"
+	"fnd/wire"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket limit on one type of message. Rate is
+// in messages per second.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// MessageUsage counts the messages of one type received from a peer.
+type MessageUsage struct {
+	Received uint64
+	Dropped  uint64
+	RxBytes  uint64
+}
+
+func ParseRateLimits(cfg map[string]config.RateLimitConfig) (map[wire.MessageType]RateLimit, error) {
+	limits := make(map[wire.MessageType]RateLimit)
+	for name, limit := range cfg {
+		msgType, err := wire.ParseMessageType(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid rate limit")
+		}
+		if limit.Rate <= 0 || limit.Burst <= 0 {
+			return nil, errors.Errorf("rate limit for %s must have a positive rate and burst", name)
+		}
+		limits[msgType] = RateLimit{
+			Rate:  limit.Rate,
+			Burst: limit.Burst,
+		}
+	}
+	return limits, nil
+}
+
+type peerLimiter struct {
+	limiters map[wire.MessageType]*rate.Limiter
+	drops    *rate.Limiter
+	usage    map[wire.MessageType]*MessageUsage
+	mu       sync.Mutex
+}
+
+func newPeerLimiter(limits map[wire.MessageType]RateLimit, maxDropped int, dropWindow time.Duration) *peerLimiter {
+	l := &peerLimiter{
+		limiters: make(map[wire.MessageType]*rate.Limiter),
+		usage:    make(map[wire.MessageType]*MessageUsage),
+	}
+	for msgType, limit := range limits {
+		l.limiters[msgType] = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+	}
+	if maxDropped > 0 && dropWindow > 0 {
+		l.drops = rate.NewLimiter(rate.Limit(float64(maxDropped)/dropWindow.Seconds()), maxDropped)
+	}
+	return l
+}
+
+// Allow records a received message and returns whether it should be
+// handled. exceeded is true once the peer has had too many messages
+// dropped and should be disconnected.
+func (l *peerLimiter) Allow(msgType wire.MessageType, size uint64) (allowed bool, exceeded bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	usage := l.usage[msgType]
+	if usage == nil {
+		usage = new(MessageUsage)
+		l.usage[msgType] = usage
+	}
+	usage.Received++
+	usage.RxBytes += size
+
+	limiter := l.limiters[msgType]
+	if limiter == nil || limiter.Allow() {
+		return true, false
+	}
+	usage.Dropped++
+	return false, l.drops != nil && !l.drops.Allow()
+}
+
+func (l *peerLimiter) Usage() map[wire.MessageType]MessageUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[wire.MessageType]MessageUsage)
+	for msgType, usage := range l.usage {
+		out[msgType] = *usage
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package p2p
+
+import (
+	"fnd/config"
+	"fnd/crypto"
+	"fnd/testutil"
+	"fnd/testutil/testcrypto"
+	"fnd/wire"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits(map[string]config.RateLimitConfig{
+		"SectorReq": {
+			Burst: 10,
+			Rate:  2.5,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RateLimit{Rate: 2.5, Burst: 10}, limits[wire.MessageTypeSectorReq])
+
+	_, err = ParseRateLimits(map[string]config.RateLimitConfig{
+		"NotAMessage": {
+			Burst: 1,
+			Rate:  1,
+		},
+	})
+	require.Error(t, err)
+	_, err = ParseRateLimits(map[string]config.RateLimitConfig{
+		"SectorReq": {},
+	})
+	require.Error(t, err)
+}
+
+func TestPeerMuxer_RateLimits(t *testing.T) {
+	localPriv, localPub := testcrypto.RandKey()
+	remotePriv, remotePub := testcrypto.RandKey()
+	localPeerID := crypto.HashPub(localPub)
+	clientConn, serverConn := testutil.NewTCPConn(t)
+
+	localMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(localPriv))
+	localPeer := NewPeer(Outbound, clientConn)
+	require.NoError(t, localMux.AddPeer(crypto.HashPub(remotePub), localPeer))
+	defer localPeer.Close()
+
+	remoteMux := NewPeerMuxer(testutil.TestMagic, crypto.NewSECP256k1Signer(remotePriv))
+	remoteMux.RateLimits = map[wire.MessageType]RateLimit{
+		wire.MessageTypeSectorReq: {
+			Rate:  0.001,
+			Burst: 2,
+		},
+	}
+	remoteMux.MaxDroppedMessages = 2
+	remoteMux.DropWindow = time.Hour
+	received := make(chan wire.MessageType, 10)
+	remoteMux.AddMessageHandler(func(peerID crypto.Hash, envelope *wire.Envelope) {
+		received <- envelope.MessageType
+	})
+	closed := make(chan struct{})
+	remoteMux.obs.On("close", func(peerID crypto.Hash) {
+		close(closed)
+	})
+	remotePeer := NewPeer(Inbound, serverConn)
+	require.NoError(t, remoteMux.AddPeer(localPeerID, remotePeer))
+
+	remotePeerID := crypto.HashPub(remotePub)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, localMux.Send(remotePeerID, &wire.SectorReq{}))
+	}
+	// unlimited message types are still handled
+	require.NoError(t, localMux.Send(remotePeerID, &wire.Ping{}))
+
+	require.Equal(t, wire.MessageTypeSectorReq, <-received)
+	require.Equal(t, wire.MessageTypeSectorReq, <-received)
+	require.Equal(t, wire.MessageTypePing, <-received)
+	usage := remoteMux.PeerUsage(localPeerID)
+	require.EqualValues(t, 4, usage[wire.MessageTypeSectorReq].Received)
+	require.EqualValues(t, 2, usage[wire.MessageTypeSectorReq].Dropped)
+	require.NotZero(t, usage[wire.MessageTypeSectorReq].RxBytes)
+
+	// the next dropped message exceeds the peer's allowance
+	require.NoError(t, localMux.Send(remotePeerID, &wire.SectorReq{}))
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("peer was not disconnected")
+	}
+	require.False(t, remoteMux.HasPeerID(localPeerID))
+	require.Nil(t, remoteMux.PeerUsage(localPeerID))
+}
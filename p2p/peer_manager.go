@@ -2,17 +2,18 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 	"fnd/crypto"
 	"fnd/log"
 	"fnd/service"
 	"fnd/store"
 	"fnd/util"
+	"fnd/wire"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"golang.org/x/sync/semaphore"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,16 +22,21 @@ const (
 
 	MainnetMagic    = 0xcafecafe
 	ProtocolVersion = 1
+	// LocalServices are the features advertised in this node's
+	// Hello when PeerManagerOpts.Services is unset.
+	LocalServices = wire.ServiceUpdateInv | wire.ServiceBlobs | wire.ServiceSectorBatch
 
 	MaxPendingInbound  = 12
 	MaxPendingOutbound = 5
 
 	DayBan  = 24 * time.Hour
 	YearBan = 365 * DayBan
+
+	MaxRefillDials = 256
 )
 
 type PeerDialer interface {
-	DialPeer(id crypto.Hash, ip string, verify bool) error
+	DialPeer(id crypto.Hash, ip string, port int, verify bool) error
 }
 
 type PeerMeta struct {
@@ -57,16 +63,23 @@ type PeerManager interface {
 	service.Service
 	PeerDialer
 	AcceptPeer(conn *net.TCPConn) error
+	// SetMaxPeers changes the peer limits. Existing peers are not
+	// disconnected if they are over the new limits.
+	SetMaxPeers(maxInbound int, maxOutbound int)
 }
 
 type peerManager struct {
 	mux             *PeerMuxer
 	db              *leveldb.DB
-	maxInbound      int
-	maxOutbound     int
+	addrs           *AddrManager
+	dialer          *ConnDialer
+	maxInbound      int32
+	maxOutbound     int32
 	obs             *util.Observable
 	signer          crypto.Signer
 	listenHost      string
+	listenPort      int
+	services        uint64
 	magic           uint32
 	protocolVersion uint32
 	peerID          crypto.Hash
@@ -84,22 +97,34 @@ type peerManager struct {
 type PeerManagerOpts struct {
 	Mux         *PeerMuxer
 	DB          *leveldb.DB
+	AddrManager *AddrManager
+	Dialer      *ConnDialer
 	SeedPeers   []SeedPeer
 	Signer      crypto.Signer
 	ListenHost  string
+	ListenPort  int
 	MaxInbound  int
 	MaxOutbound int
+	Services    uint64
 }
 
 func NewPeerManager(opts *PeerManagerOpts) PeerManager {
+	services := opts.Services
+	if services == 0 {
+		services = LocalServices
+	}
 	return &peerManager{
-		maxInbound:      opts.MaxInbound,
-		maxOutbound:     opts.MaxOutbound,
+		maxInbound:      int32(opts.MaxInbound),
+		maxOutbound:     int32(opts.MaxOutbound),
 		obs:             util.NewObservable(),
 		mux:             opts.Mux,
 		db:              opts.DB,
+		addrs:           opts.AddrManager,
+		dialer:          opts.Dialer,
 		signer:          opts.Signer,
 		listenHost:      opts.ListenHost,
+		listenPort:      opts.ListenPort,
+		services:        services,
 		magic:           MainnetMagic,
 		protocolVersion: ProtocolVersion,
 		peerID:          crypto.HashPub(opts.Signer.Pub()),
@@ -117,10 +142,15 @@ func (p *peerManager) Start() error {
 
 	go func() {
 		refillTick := time.NewTicker(30 * time.Second)
+		flushTick := time.NewTicker(AddrFlushInterval)
 		for {
 			select {
 			case <-refillTick.C:
 				p.refillPeers()
+			case <-flushTick.C:
+				if err := p.addrs.Flush(); err != nil {
+					p.lgr.Error("error flushing address book", "err", err)
+				}
 			case <-p.doneCh:
 				return
 			}
@@ -132,9 +162,18 @@ func (p *peerManager) Start() error {
 
 func (p *peerManager) Stop() error {
 	close(p.doneCh)
+	return p.addrs.Flush()
+}
+
+func (p *peerManager) Health() error {
 	return nil
 }
 
+func (p *peerManager) SetMaxPeers(maxInbound int, maxOutbound int) {
+	atomic.StoreInt32(&p.maxInbound, int32(maxInbound))
+	atomic.StoreInt32(&p.maxOutbound, int32(maxOutbound))
+}
+
 func (p *peerManager) AcceptPeer(conn *net.TCPConn) error {
 	if !p.inSem.TryAcquire(1) {
 		if err := conn.Close(); err != nil {
@@ -160,6 +199,8 @@ func (p *peerManager) AcceptPeer(conn *net.TCPConn) error {
 		ProtocolVersion: p.protocolVersion,
 		Peer:            peer,
 		Signer:          p.signer,
+		Services:        p.services,
+		Extensions:      p.extensions(),
 	})
 	if err != nil {
 		if err := peer.Close(); err != nil {
@@ -168,7 +209,24 @@ func (p *peerManager) AcceptPeer(conn *net.TCPConn) error {
 		p.cleanupInboundPeer(tcpAddr.String())
 		return err
 	}
-	return p.completeConnection(theirPeerID, peer, false)
+	// inbound peers connect from an ephemeral port, so only learn
+	// their address if they tell us where they listen
+	var port int
+	if peer.Services()&wire.ServiceInbound != 0 {
+		port, _ = peer.Extensions().ListenPort()
+	}
+	return p.completeConnection(theirPeerID, peer, port, false)
+}
+
+func (p *peerManager) extensions() wire.Extensions {
+	var exts wire.Extensions
+	if p.services&wire.ServiceInbound != 0 && p.listenPort != 0 {
+		exts = append(exts, wire.NewListenPortExtension(p.listenPort))
+	}
+	if len(p.mux.Compression) > 0 {
+		exts = append(exts, wire.NewCompressionExtension(p.mux.Compression))
+	}
+	return exts
 }
 
 func (p *peerManager) gateInboundPeer(addr *net.TCPAddr) error {
@@ -181,7 +239,7 @@ func (p *peerManager) gateInboundPeer(addr *net.TCPAddr) error {
 		return ErrAlreadyConnecting
 	}
 	in, _ := p.mux.PeerCount()
-	if in >= p.maxInbound {
+	if in >= int(atomic.LoadInt32(&p.maxInbound)) {
 		return ErrMaxInbound
 	}
 	isBanned, _, err := store.IsBanned(p.db, addr.IP.String())
@@ -200,19 +258,25 @@ func (p *peerManager) cleanupInboundPeer(addr string) {
 	delete(p.pendingInbound, addr)
 }
 
-func (p *peerManager) DialPeer(peerID crypto.Hash, ip string, verifyPeerID bool) error {
+func (p *peerManager) DialPeer(peerID crypto.Hash, ip string, port int, verifyPeerID bool) error {
 	if !p.outSem.TryAcquire(1) {
 		return ErrOutboundBusy
 	}
 	defer p.outSem.Release(1)
-	if err := p.gateOutboundPeer(peerID, ip); err != nil {
+	ip, port = normalizeAddr(ip, port)
+	addr := JoinAddr(ip, port)
+	if !p.dialer.CanDial(ip) {
+		return ErrProxyRequired
+	}
+	if err := p.gateOutboundPeer(peerID, ip, port); err != nil {
 		return err
 	}
+	p.addrs.Attempt(ip, port)
 
-	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", ip, StandardPort), 2*time.Second)
+	conn, err := p.dialer.Dial(ip, port)
 	if err != nil {
 		p.banOutboundPeer(ip)
-		p.cleanupOutboundPeer(ip)
+		p.cleanupOutboundPeer(addr)
 		return errors.Wrap(err, "dial failed")
 	}
 
@@ -224,39 +288,44 @@ func (p *peerManager) DialPeer(peerID crypto.Hash, ip string, verifyPeerID bool)
 		ProtocolVersion: p.protocolVersion,
 		Peer:            peer,
 		Signer:          p.signer,
+		Services:        p.services,
+		Extensions:      p.extensions(),
 	})
 	if err != nil {
 		_ = peer.Close()
 		p.banOutboundPeer(ip)
-		p.cleanupOutboundPeer(ip)
+		p.cleanupOutboundPeer(addr)
 		return err
 	}
 	if verifyPeerID && peerID != theirPeerID {
 		_ = peer.Close()
 		p.banOutboundPeer(ip)
-		p.cleanupOutboundPeer(ip)
+		p.cleanupOutboundPeer(addr)
 		return ErrPeerIDMismatch
 	}
-	return p.completeConnection(theirPeerID, peer, verifyPeerID)
+	// this needs to be deleted before the connection completes
+	p.cleanupOutboundPeer(addr)
+	return p.completeConnection(theirPeerID, peer, port, verifyPeerID)
 }
 
-func (p *peerManager) gateOutboundPeer(peerID crypto.Hash, ip string) error {
+func (p *peerManager) gateOutboundPeer(peerID crypto.Hash, ip string, port int) error {
 	p.outMu.Lock()
 	defer p.outMu.Unlock()
-	if ip == p.listenHost {
+	addr := JoinAddr(ip, port)
+	if ip == p.listenHost && port == p.listenPort {
 		return ErrSelfDial
 	}
-	if p.pendingOutbound[ip] {
+	if p.pendingOutbound[addr] {
 		return ErrAlreadyConnecting
 	}
 	_, out := p.mux.PeerCount()
-	if out >= p.maxOutbound {
+	if out >= int(atomic.LoadInt32(&p.maxOutbound)) {
 		return ErrMaxOutbound
 	}
 	if p.mux.HasPeerID(peerID) {
 		return ErrAlreadyConnected
 	}
-	if p.mux.HasOutboundPeerIP(ip) {
+	if p.mux.HasOutboundPeerAddr(addr) {
 		return ErrAlreadyConnected
 	}
 	_, isBanned, err := store.IsBanned(p.db, ip)
@@ -265,23 +334,23 @@ func (p *peerManager) gateOutboundPeer(peerID crypto.Hash, ip string) error {
 	} else if isBanned {
 		return ErrPeerBanned
 	}
-	p.pendingOutbound[ip] = true
+	p.pendingOutbound[addr] = true
 	return nil
 }
 
-func (p *peerManager) cleanupOutboundPeer(ip string) {
+func (p *peerManager) cleanupOutboundPeer(addr string) {
 	p.outMu.Lock()
 	defer p.outMu.Unlock()
-	delete(p.pendingOutbound, ip)
+	delete(p.pendingOutbound, addr)
 }
 
-func (p *peerManager) completeConnection(peerID crypto.Hash, peer Peer, verify bool) error {
+// completeConnection adds the peer to the muxer. port is the
+// peer's listening port, or zero if it is unknown.
+func (p *peerManager) completeConnection(peerID crypto.Hash, peer Peer, port int, verify bool) error {
 	rIP := peer.RemoteIP()
 	// these need to be deleted first
 	if peer.Direction() == Inbound {
 		p.cleanupInboundPeer(peer.RemoteAddr())
-	} else {
-		p.cleanupOutboundPeer(rIP)
 	}
 
 	if p.peerID == peerID {
@@ -307,53 +376,54 @@ func (p *peerManager) completeConnection(peerID crypto.Hash, peer Peer, verify b
 		}
 		return errors.Wrap(err, "error completing peer connection")
 	}
-	if err := store.SetPeer(p.db, peerID, rIP, verify); err != nil {
+	if err := store.SetPeer(p.db, peerID, rIP, port, verify); err != nil {
 		p.lgr.Error("error saving peer", "err", err)
 	}
+	if peer.Direction() == Outbound {
+		p.addrs.Good(peerID, rIP, port, peer.Services(), verify)
+	} else if port != 0 && isAddrRoutable(rIP) {
+		p.addrs.Add(peerID, rIP, port, peer.Services(), rIP, false)
+	}
 	p.lgr.Info("peer added", "peer_id", peerID, "direction", peer.Direction())
 	return nil
 }
 
 func (p *peerManager) refillPeers() {
 	_, outCount := p.mux.PeerCount()
-	if outCount >= p.maxOutbound {
+	maxOutbound := int(atomic.LoadInt32(&p.maxOutbound))
+	if outCount >= maxOutbound {
 		return
 	}
 
-	p.lgr.Info("refilling peers", "have", outCount, "want", p.maxOutbound)
-	peerStream, err := store.StreamPeers(p.db, false)
-	if err != nil {
-		p.lgr.Error("error opening peer stream", "err", err)
-		return
-	}
-	// can't stream and modify the DB at the same time
-	var candidatePeers []*store.Peer
-	for len(candidatePeers) < 256 {
-		peer, err := peerStream.Next()
-		if err != nil {
-			p.lgr.Error("error streaming stored peer", "err", err)
-			break
-		}
-		if peer == nil {
-			break
-		}
-		if p.mux.HasPeerID(peer.ID) {
-			continue
+	p.lgr.Info("refilling peers", "have", outCount, "want", maxOutbound)
+	// only connect to one peer per network group to make it harder
+	// for a single operator to control all of our outbound peers
+	groups := make(map[string]bool)
+	for _, peer := range p.mux.Peers() {
+		if peer.Direction() == Outbound {
+			groups[AddrGroup(peer.RemoteIP())] = true
 		}
-		candidatePeers = append(candidatePeers, peer)
 	}
-	peerStream.Close()
-
-	for _, peer := range candidatePeers {
-		_, outCount := p.mux.PeerCount()
-		if outCount >= p.maxOutbound {
+	attempted := make(map[string]bool)
+	for i := 0; i < MaxRefillDials && outCount < maxOutbound; i++ {
+		addr := p.addrs.Select(func(entry *store.AddrBookEntry) bool {
+			return attempted[JoinAddr(entry.IP, entry.Port)] ||
+				!p.dialer.CanDial(entry.IP) ||
+				groups[AddrGroup(entry.IP)] ||
+				!AcceptsInbound(entry.Services) ||
+				p.mux.HasPeerID(entry.ID) ||
+				p.mux.HasOutboundPeerAddr(JoinAddr(entry.IP, entry.Port))
+		})
+		if addr == nil {
 			break
 		}
-
-		if err := p.DialPeer(peer.ID, peer.IP, peer.Verify); err != nil {
+		attempted[JoinAddr(addr.IP, addr.Port)] = true
+		if err := p.DialPeer(addr.ID, addr.IP, addr.Port, addr.Verify); err != nil {
 			p.lgr.Error("failed to dial peer during refill", "err", err)
 			continue
 		}
+		groups[AddrGroup(addr.IP)] = true
+		_, outCount = p.mux.PeerCount()
 	}
 }
 
@@ -363,3 +433,9 @@ func (p *peerManager) banOutboundPeer(ip string) {
 		p.lgr.Error("error storing outbound peer ban state", "err", err)
 	}
 }
+
+// AcceptsInbound returns false if services are known and show that
+// the node doesn't accept connections.
+func AcceptsInbound(services uint64) bool {
+	return services == 0 || services&wire.ServiceInbound != 0
+}
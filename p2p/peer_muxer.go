@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"fmt"
+	"fnd/config"
 	"fnd/crypto"
 	"fnd/log"
 	"fnd/util"
@@ -9,43 +10,62 @@ import (
 	"github.com/pkg/errors"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
 	DefaultPeerMuxerGossipTimeoutMS = 5 * 60 * 1000
 )
 
+var defaultRateLimits map[wire.MessageType]RateLimit
+
 type PeerMessageHandler func(peerID crypto.Hash, envelope *wire.Envelope)
 type PeerStateHandler func(peerID crypto.Hash)
 
 type PeerMuxer struct {
-	GossipTimeoutMS   int
-	outboundPeersByIP map[string]Peer
-	inboundPeersByIP  map[string][]Peer
-	peers             map[crypto.Hash]Peer
-	obs               *util.Observable
-	mu                sync.RWMutex
-	gossipFilter      *util.Cache
-	inboundCount      int
-	outboundCount     int
-	magic             uint32
-	signer            crypto.Signer
-	bytesTx           uint64
-	bytesRx           uint64
-	lgr               log.Logger
+	GossipTimeoutMS int
+	// Compression lists the codecs used to compress responses and
+	// accepted from peers, in order of preference.
+	Compression []uint8
+	// RateLimits limit how many messages of each type a peer can
+	// send. Messages over the limit are dropped, and peers with more
+	// than MaxDroppedMessages dropped within DropWindow are
+	// disconnected.
+	RateLimits          map[wire.MessageType]RateLimit
+	MaxDroppedMessages  int
+	DropWindow          time.Duration
+	limiters            map[crypto.Hash]*peerLimiter
+	outboundPeersByAddr map[string]Peer
+	inboundPeersByIP    map[string][]Peer
+	peers               map[crypto.Hash]Peer
+	obs                 *util.Observable
+	mu                  sync.RWMutex
+	gossipFilter        *util.Cache
+	inboundCount        int
+	outboundCount       int
+	magic               uint32
+	signer              crypto.Signer
+	bytesTx             uint64
+	bytesRx             uint64
+	lgr                 log.Logger
 }
 
 func NewPeerMuxer(magic uint32, signer crypto.Signer) *PeerMuxer {
 	return &PeerMuxer{
-		GossipTimeoutMS:   DefaultPeerMuxerGossipTimeoutMS,
-		outboundPeersByIP: make(map[string]Peer),
-		inboundPeersByIP:  make(map[string][]Peer),
-		peers:             make(map[crypto.Hash]Peer),
-		obs:               util.NewObservable(),
-		gossipFilter:      util.NewCache(),
-		magic:             magic,
-		signer:            signer,
-		lgr:               log.WithModule("peer-muxer"),
+		GossipTimeoutMS:     DefaultPeerMuxerGossipTimeoutMS,
+		RateLimits:          defaultRateLimits,
+		MaxDroppedMessages:  config.DefaultConfig.Tuning.PeerMuxer.MaxDroppedMessages,
+		DropWindow:          config.ConvertDuration(config.DefaultConfig.Tuning.PeerMuxer.DropWindowMS, time.Millisecond),
+		Compression:         defaultCompression,
+		limiters:            make(map[crypto.Hash]*peerLimiter),
+		outboundPeersByAddr: make(map[string]Peer),
+		inboundPeersByIP:    make(map[string][]Peer),
+		peers:               make(map[crypto.Hash]Peer),
+		obs:                 util.NewObservable(),
+		gossipFilter:        util.NewCache(),
+		magic:               magic,
+		signer:              signer,
+		lgr:                 log.WithModule("peer-muxer"),
 	}
 }
 
@@ -69,7 +89,18 @@ func (p *PeerMuxer) HasPeerID(id crypto.Hash) bool {
 func (p *PeerMuxer) HasOutboundPeerIP(ip string) bool {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	_, ok := p.outboundPeersByIP[ip]
+	for _, peer := range p.outboundPeersByAddr {
+		if peer.RemoteIP() == ip {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PeerMuxer) HasOutboundPeerAddr(addr string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.outboundPeersByAddr[addr]
 	return ok
 }
 
@@ -90,9 +121,10 @@ func (p *PeerMuxer) PeersByIP(ip string) []Peer {
 	for _, peer := range p.inboundPeersByIP[ip] {
 		peers = append(peers, peer)
 	}
-	outbound := p.outboundPeersByIP[ip]
-	if outbound != nil {
-		peers = append(peers, outbound)
+	for _, peer := range p.outboundPeersByAddr {
+		if peer.RemoteIP() == ip {
+			peers = append(peers, peer)
+		}
 	}
 	return peers
 }
@@ -118,19 +150,20 @@ func (p *PeerMuxer) PeerByID(id crypto.Hash) (Peer, error) {
 func (p *PeerMuxer) PeerByIP(ip string) (Peer, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	peer := p.outboundPeersByIP[ip]
-	if peer == nil {
-		return nil, errors.New("peer not found")
+	for _, peer := range p.outboundPeersByAddr {
+		if peer.RemoteIP() == ip {
+			return peer, nil
+		}
 	}
-	return peer, nil
+	return nil, errors.New("peer not found")
 }
 
 func (p *PeerMuxer) GossipPeerIDs(message wire.Message) []crypto.Hash {
+	hash, _ := message.Hash()
 	peers := p.PeerIDs()
 	var out []crypto.Hash
 	for _, peerID := range peers {
-		key := p.gossipKey(peerID, message)
-		if p.gossipFilter.Has(key) {
+		if p.HasGossiped(peerID, hash) {
 			continue
 		}
 
@@ -140,8 +173,43 @@ func (p *PeerMuxer) GossipPeerIDs(message wire.Message) []crypto.Hash {
 	return out
 }
 
+// HasGossiped returns true if the message with the given hash was
+// recently sent to or received from the peer.
+func (p *PeerMuxer) HasGossiped(peerID crypto.Hash, hash crypto.Hash) bool {
+	return p.gossipFilter.Has(gossipKey(peerID, hash))
+}
+
+// MarkGossiped records that the peer knows about the message with
+// the given hash, usually because it was announced in an inventory.
+func (p *PeerMuxer) MarkGossiped(peerID crypto.Hash, hash crypto.Hash) {
+	p.gossipFilter.Set(gossipKey(peerID, hash), true, int64(p.GossipTimeoutMS))
+}
+
+func (p *PeerMuxer) HasService(peerID crypto.Hash, service uint64) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	peer, ok := p.peers[peerID]
+	if !ok {
+		return false
+	}
+	return peer.Services()&service != 0
+}
+
+// SetRateLimits changes the rate limits of peers that connect from
+// now on.
+func (p *PeerMuxer) SetRateLimits(limits map[wire.MessageType]RateLimit, maxDroppedMessages int, dropWindow time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.RateLimits = limits
+	p.MaxDroppedMessages = maxDroppedMessages
+	p.DropWindow = dropWindow
+}
+
 func (p *PeerMuxer) AddPeer(id crypto.Hash, peer Peer) error {
-	if err := p.handlePeerOpen(id, peer); err != nil {
+	p.mu.RLock()
+	limiter := newPeerLimiter(p.RateLimits, p.MaxDroppedMessages, p.DropWindow)
+	p.mu.RUnlock()
+	if err := p.handlePeerOpen(id, peer, limiter); err != nil {
 		return errors.Wrap(err, "error adding peer")
 	}
 	go func() {
@@ -152,19 +220,49 @@ func (p *PeerMuxer) AddPeer(id crypto.Hash, peer Peer) error {
 				p.handlePeerClose(id)
 				return
 			}
+			_, endRx := peer.BandwidthUsage()
+			atomic.AddUint64(&p.bytesRx, endRx-startRx)
 			if err := ValidateEnvelope(p.magic, id, envelope); err != nil {
 				p.lgr.Error("envelope failed validation, closing peer", "err", err)
 				p.handlePeerClose(id)
 				return
 			}
+			if envelope.MessageType == wire.MessageTypeCompressed {
+				envelope, err = p.decompress(envelope)
+				if err != nil {
+					p.lgr.Warn("invalid compressed message, closing peer", "peer_id", id, "err", err)
+					p.handlePeerClose(id)
+					return
+				}
+			}
+			allowed, exceeded := limiter.Allow(envelope.MessageType, endRx-startRx)
+			if exceeded {
+				p.lgr.Warn("peer exceeded rate limits, closing peer", "peer_id", id, "message_type", envelope.MessageType)
+				p.handlePeerClose(id)
+				return
+			}
+			if !allowed {
+				p.lgr.Debug("dropped rate limited message", "peer_id", id, "message_type", envelope.MessageType)
+				continue
+			}
 			p.handlePeerMessage(id, envelope)
-			_, endRx := peer.BandwidthUsage()
-			atomic.AddUint64(&p.bytesRx, endRx-startRx)
 		}
 	}()
 	return nil
 }
 
+// PeerUsage returns the number of messages of each type received
+// from the peer, including dropped messages.
+func (p *PeerMuxer) PeerUsage(id crypto.Hash) map[wire.MessageType]MessageUsage {
+	p.mu.RLock()
+	limiter := p.limiters[id]
+	p.mu.RUnlock()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Usage()
+}
+
 func (p *PeerMuxer) ClosePeer(id crypto.Hash) error {
 	p.handlePeerClose(id)
 	return nil
@@ -187,6 +285,10 @@ func (p *PeerMuxer) Send(id crypto.Hash, message wire.Message) error {
 	}
 	p.mu.RUnlock()
 
+	message, err := p.compressFor(peer, message)
+	if err != nil {
+		return err
+	}
 	envelope, err := wire.NewEnvelope(p.magic, message, p.signer)
 	if err != nil {
 		return errors.Wrap(err, "error creating envelope")
@@ -201,7 +303,8 @@ func (p *PeerMuxer) Send(id crypto.Hash, message wire.Message) error {
 }
 
 func (p *PeerMuxer) handlePeerMessage(id crypto.Hash, envelope *wire.Envelope) {
-	p.gossipFilter.Set(p.gossipKey(id, envelope.Message), true, int64(p.GossipTimeoutMS))
+	hash, _ := envelope.Message.Hash()
+	p.MarkGossiped(id, hash)
 	p.obs.Emit("message", id, envelope)
 }
 
@@ -218,9 +321,10 @@ func (p *PeerMuxer) handlePeerClose(id crypto.Hash) {
 		p.removeInboundPeerByIP(peer)
 	} else {
 		p.outboundCount--
-		delete(p.outboundPeersByIP, peer.RemoteIP())
+		delete(p.outboundPeersByAddr, peer.RemoteAddr())
 	}
 	delete(p.peers, id)
+	delete(p.limiters, id)
 	p.mu.Unlock()
 
 	peer.Close()
@@ -228,7 +332,7 @@ func (p *PeerMuxer) handlePeerClose(id crypto.Hash) {
 	p.lgr.Info("peer closed", "peer_id", id, "reason", peer.CloseReason())
 }
 
-func (p *PeerMuxer) handlePeerOpen(id crypto.Hash, peer Peer) error {
+func (p *PeerMuxer) handlePeerOpen(id crypto.Hash, peer Peer, limiter *peerLimiter) error {
 	p.mu.Lock()
 	_, ok := p.peers[id]
 	if ok {
@@ -240,20 +344,16 @@ func (p *PeerMuxer) handlePeerOpen(id crypto.Hash, peer Peer) error {
 		p.inboundPeersByIP[peer.RemoteIP()] = append(p.inboundPeersByIP[peer.RemoteIP()], peer)
 	} else {
 		p.outboundCount++
-		p.outboundPeersByIP[peer.RemoteIP()] = peer
+		p.outboundPeersByAddr[peer.RemoteAddr()] = peer
 	}
 	p.peers[id] = peer
+	p.limiters[id] = limiter
 	p.mu.Unlock()
 
 	p.obs.Emit("open", id)
 	return nil
 }
 
-func (p *PeerMuxer) gossipKey(peerID crypto.Hash, message wire.Message) string {
-	hash, _ := message.Hash()
-	return fmt.Sprintf("%s:%s", peerID, hash)
-}
-
 func (p *PeerMuxer) removeInboundPeerByIP(peer Peer) {
 	for i, storedPeer := range p.inboundPeersByIP[peer.RemoteIP()] {
 		if storedPeer == peer {
@@ -301,3 +401,15 @@ func GossipAll(mux *PeerMuxer, message wire.Message) ([]crypto.Hash, []error) {
 	}
 	return recips, errs
 }
+
+func gossipKey(peerID crypto.Hash, hash crypto.Hash) string {
+	return fmt.Sprintf("%s:%s", peerID, hash)
+}
+
+func init() {
+	limits, err := ParseRateLimits(config.DefaultConfig.Tuning.PeerMuxer.RateLimits)
+	if err != nil {
+		panic(err)
+	}
+	defaultRateLimits = limits
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"golang.org/x/net/proxy"
+	"net"
+	"time"
+)
+
+var (
+	ErrProxyRequired = errors.New("a proxy is required to reach this address")
+)
+
+// ConnDialer opens outbound peer connections, optionally through a
+// SOCKS5 proxy such as Tor.
+type ConnDialer struct {
+	// Proxy is the address of a SOCKS5 proxy. When set, onion
+	// addresses are dialed through it.
+	Proxy string
+	// ProxyOnly routes every connection through Proxy, including
+	// connections to IPs and hostnames.
+	ProxyOnly bool
+	Timeout   time.Duration
+}
+
+func NewConnDialer(proxy string, proxyOnly bool) (*ConnDialer, error) {
+	if proxyOnly && proxy == "" {
+		return nil, errors.New("proxy-only mode requires a proxy")
+	}
+	if proxy != "" {
+		if _, _, err := net.SplitHostPort(proxy); err != nil {
+			return nil, errors.Wrap(err, "mal-formed proxy address")
+		}
+	}
+	return &ConnDialer{
+		Proxy:     proxy,
+		ProxyOnly: proxyOnly,
+		Timeout:   2 * time.Second,
+	}, nil
+}
+
+// CanDial returns true if host is reachable with this dialer's
+// configuration.
+func (d *ConnDialer) CanDial(host string) bool {
+	return d.Proxy != "" || store.AddrTypeOf(host) != store.AddrTypeOnion
+}
+
+func (d *ConnDialer) Dial(host string, port int) (net.Conn, error) {
+	addr := JoinAddr(host, port)
+	useProxy := d.ProxyOnly || store.AddrTypeOf(host) == store.AddrTypeOnion
+	if !useProxy {
+		return net.DialTimeout("tcp", addr, d.Timeout)
+	}
+	if d.Proxy == "" {
+		return nil, ErrProxyRequired
+	}
+
+	forward := &net.Dialer{
+		Timeout: d.Timeout,
+	}
+	dialer, err := proxy.SOCKS5("tcp", d.Proxy, nil, forward)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating proxy dialer")
+	}
+	// Tor circuits take a while to build, so give proxied
+	// connections more time to complete.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*d.Timeout)
+	defer cancel()
+	conn, err := dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "proxy dial failed")
+	}
+	return &proxiedConn{
+		Conn: conn,
+		remote: &ProxiedAddr{
+			Host: host,
+			Port: port,
+		},
+	}, nil
+}
+
+// ProxiedAddr is the remote address of a connection made through a
+// proxy. It reports the peer's address rather than the proxy's.
+type ProxiedAddr struct {
+	Host string
+	Port int
+}
+
+func (p *ProxiedAddr) Network() string {
+	return "tcp"
+}
+
+func (p *ProxiedAddr) String() string {
+	return JoinAddr(p.Host, p.Port)
+}
+
+type proxiedConn struct {
+	net.Conn
+	remote *ProxiedAddr
+}
+
+func (p *proxiedConn) RemoteAddr() net.Addr {
+	return p.remote
+}
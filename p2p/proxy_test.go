@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"testing"
+)
+
+const testOnion = "expyuzz4wqqyqhjn.onion"
+
+// serveSOCKS5 accepts a single unauthenticated CONNECT request,
+// sends the requested address to reqCh, and echoes data back.
+func serveSOCKS5(t *testing.T, reqCh chan string) (string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 262)
+		// version and auth methods
+		if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(conn, buf[:buf[1]]); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, 0x00})
+		// version, command, reserved, and address type
+		if _, err := io.ReadFull(conn, buf[:4]); err != nil || buf[3] != 0x03 {
+			return
+		}
+		if _, err := io.ReadFull(conn, buf[:1]); err != nil {
+			return
+		}
+		host := make([]byte, buf[0])
+		if _, err := io.ReadFull(conn, host); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+			return
+		}
+		reqCh <- JoinAddr(string(host), int(binary.BigEndian.Uint16(buf[:2])))
+		conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		io.Copy(conn, conn)
+	}()
+	return lis.Addr().String(), func() {
+		lis.Close()
+	}
+}
+
+func TestConnDialer_Proxy(t *testing.T) {
+	reqCh := make(chan string, 1)
+	proxyAddr, done := serveSOCKS5(t, reqCh)
+	defer done()
+
+	dialer, err := NewConnDialer(proxyAddr, false)
+	require.NoError(t, err)
+	require.True(t, dialer.CanDial(testOnion))
+	conn, err := dialer.Dial(testOnion, 9098)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Equal(t, JoinAddr(testOnion, 9098), <-reqCh)
+	require.Equal(t, JoinAddr(testOnion, 9098), conn.RemoteAddr().String())
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf))
+
+	peer := NewPeer(Outbound, conn)
+	require.Equal(t, testOnion, peer.RemoteIP())
+	require.Equal(t, 9098, peer.RemotePort())
+	require.NoError(t, peer.Close())
+}
+
+func TestConnDialer_NoProxy(t *testing.T) {
+	dialer, err := NewConnDialer("", false)
+	require.NoError(t, err)
+	require.False(t, dialer.CanDial(testOnion))
+	require.True(t, dialer.CanDial("1.2.3.4"))
+	_, err = dialer.Dial(testOnion, StandardPort)
+	require.Equal(t, ErrProxyRequired, err)
+
+	_, err = NewConnDialer("", true)
+	require.Error(t, err)
+}
@@ -13,3 +13,18 @@ func TestLookupDNSSeeds(t *testing.T) {
 	require.Contains(t, seeds, "10.1.0.1")
 	require.Contains(t, seeds, "10.1.0.2")
 }
+
+func TestParseSeedPeers(t *testing.T) {
+	peers, err := ParseSeedPeers([]string{
+		"3b755ceafc5811f0a50e102c96169b062ad1295edea0adf675e8647963acf89e@64.225.89.142",
+		"3b755ceafc5811f0a50e102c96169b062ad1295edea0adf675e8647963acf89e@[2001:db8::1]:9098",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "64.225.89.142", peers[0].IP)
+	require.Equal(t, StandardPort, peers[0].Port)
+	require.Equal(t, "2001:db8::1", peers[1].IP)
+	require.Equal(t, 9098, peers[1].Port)
+
+	_, err = ParseSeedPeers([]string{"3b755ceafc5811f0a50e102c96169b062ad1295edea0adf675e8647963acf89e@1.2.3.4:foo"})
+	require.Error(t, err)
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type SeedPeer struct {
-	ID crypto.Hash
-	IP string
+	ID   crypto.Hash
+	IP   string
+	Port int
 }
 
 func ResolveDNSSeeds(domain string) ([]string, error) {
@@ -48,9 +49,14 @@ func ParseSeedPeers(seedPeers []string) ([]SeedPeer, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "mal-formed peer ID")
 		}
+		ip, port, err := ParseAddr(pieces[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "mal-formed seed peer address")
+		}
 		peers = append(peers, SeedPeer{
-			ID: peerID,
-			IP: pieces[1],
+			ID:   peerID,
+			IP:   ip,
+			Port: port,
 		})
 	}
 
@@ -2,10 +2,17 @@ package protocol
 
 import (
 	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"fnd.localhost/handshake/primitives"
+	"fnd/crypto"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/pkg/errors"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,6 +20,9 @@ import (
 
 const (
 	CurrentBanListVersion = 1
+
+	banListSigPrefix    = "FNSIG:"
+	banListSectorPrefix = "sector:"
 )
 
 var verRegex = regexp.MustCompile("^v([\\d]+)$")
@@ -38,7 +48,88 @@ func ParseBanListVersion(line string) (int, error) {
 	return verInt, nil
 }
 
+// BanList is a list of banned names and blocked sector hashes. Sector
+// hashes are listed on lines of the form sector:<hex hash>.
+type BanList struct {
+	Version   int
+	Names     []string
+	Sectors   []crypto.Hash
+	Signature *crypto.Signature
+}
+
+// Hash returns the hash moderators sign. It covers the version line, the
+// trimmed names, and then the sector hashes, so whitespace changes do not
+// invalidate signatures.
+func (b *BanList) Hash() (crypto.Hash, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("FNBAN:v%d", b.Version))
+	for _, name := range b.Names {
+		sb.WriteString("\n")
+		sb.WriteString(name)
+	}
+	for _, hash := range b.Sectors {
+		sb.WriteString("\n")
+		sb.WriteString(banListSectorPrefix)
+		sb.WriteString(hash.String())
+	}
+	return crypto.Blake2B256([]byte(sb.String())), nil
+}
+
+// Verify returns true if the list is signed by any of the given keys.
+func (b *BanList) Verify(keys []*btcec.PublicKey) bool {
+	if b.Signature == nil {
+		return false
+	}
+	for _, key := range keys {
+		if crypto.VerifySigPub(key, *b.Signature, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BanList) Sign(signer crypto.Signer) error {
+	sig, err := signer.Sign(b)
+	if err != nil {
+		return errors.Wrap(err, "error signing ban list")
+	}
+	b.Signature = &sig
+	return nil
+}
+
+func (b *BanList) Encode(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "FNBAN:v%d\n", b.Version); err != nil {
+		return err
+	}
+	if b.Signature != nil {
+		if _, err := fmt.Fprintf(w, "%s%s\n", banListSigPrefix, b.Signature.String()); err != nil {
+			return err
+		}
+	}
+	for _, name := range b.Names {
+		if _, err := fmt.Fprintf(w, "%s\n", name); err != nil {
+			return err
+		}
+	}
+	for _, hash := range b.Sectors {
+		if _, err := fmt.Fprintf(w, "%s%s\n", banListSectorPrefix, hash.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ReadBanList(r io.Reader) ([]string, error) {
+	list, err := ReadSignedBanList(r)
+	if err != nil {
+		return nil, err
+	}
+	return list.Names, nil
+}
+
+// ReadSignedBanList reads a ban list along with its optional signature,
+// which must appear on the line directly following the version line.
+func ReadSignedBanList(r io.Reader) (*BanList, error) {
 	scanner := bufio.NewScanner(r)
 	if !scanner.Scan() {
 		return nil, errors.New("ban list must start with version line")
@@ -52,24 +143,119 @@ func ReadBanList(r io.Reader) ([]string, error) {
 		return nil, errors.New("unsupported ban list version")
 	}
 
-	var names []string
+	list := &BanList{
+		Version: version,
+		Names:   make([]string, 0),
+	}
 	i := 1
 	for scanner.Scan() {
-		name := strings.Trim(scanner.Text(), " \t")
-		if err := primitives.ValidateName(name); err != nil {
-			return nil, errors.Wrapf(err, "invalid name on line %d: %s", i, name)
+		line := strings.Trim(scanner.Text(), " \t")
+		if i == 1 && strings.HasPrefix(line, banListSigPrefix) {
+			sig, err := parseBanListSig(strings.TrimPrefix(line, banListSigPrefix))
+			if err != nil {
+				return nil, err
+			}
+			list.Signature = &sig
+			i++
+			continue
+		}
+		if strings.HasPrefix(line, banListSectorPrefix) {
+			hash, err := parseBanListSector(strings.TrimPrefix(line, banListSectorPrefix))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid sector hash on line %d: %s", i, line)
+			}
+			list.Sectors = append(list.Sectors, hash)
+			i++
+			continue
 		}
-		names = append(names, name)
+		if err := primitives.ValidateName(line); err != nil {
+			return nil, errors.Wrapf(err, "invalid name on line %d: %s", i, line)
+		}
+		list.Names = append(list.Names, line)
 		i++
 	}
-	return names, nil
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading ban list")
+	}
+	return list, nil
 }
 
 func FetchListFile(url string) ([]string, error) {
-	res, err := http.Get(url)
+	list, err := FetchBanList(url)
+	if err != nil {
+		return nil, err
+	}
+	return list.Names, nil
+}
+
+// FetchBanList reads a ban list from an HTTP(S) URL or a local file://
+// URL. Signatures are parsed but not verified.
+func FetchBanList(url string) (*BanList, error) {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ban list URL")
+	}
+	switch u.Scheme {
+	case "file":
+		f, err := os.Open(u.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open list")
+		}
+		defer f.Close()
+		return ReadSignedBanList(f)
+	case "http", "https":
+		res, err := http.Get(url)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch list")
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("failed to fetch list: unexpected status %d", res.StatusCode)
+		}
+		return ReadSignedBanList(res.Body)
+	default:
+		return nil, errors.Errorf("unsupported ban list scheme %s", u.Scheme)
+	}
+}
+
+func IsLocalBanList(url string) bool {
+	return strings.HasPrefix(url, "file://")
+}
+
+// ParseModeratorKeys parses base64-encoded compressed public keys, as
+// printed by fnd-cli identity.
+func ParseModeratorKeys(keys []string) ([]*btcec.PublicKey, error) {
+	var out []*btcec.PublicKey
+	for _, key := range keys {
+		keyB, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid moderator key %s", key)
+		}
+		pub, err := btcec.ParsePubKey(keyB, btcec.S256())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid moderator key %s", key)
+		}
+		out = append(out, pub)
+	}
+	return out, nil
+}
+
+func parseBanListSig(in string) (crypto.Signature, error) {
+	sigB, err := hex.DecodeString(in)
+	if err != nil {
+		return crypto.Signature{}, errors.Wrap(err, "invalid ban list signature")
+	}
+	sig, err := crypto.NewSignatureFromBytes(sigB)
+	if err != nil {
+		return crypto.Signature{}, errors.Wrap(err, "invalid ban list signature")
+	}
+	return sig, nil
+}
+
+func parseBanListSector(in string) (crypto.Hash, error) {
+	hashB, err := hex.DecodeString(in)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch list")
+		return crypto.ZeroHash, err
 	}
-	defer res.Body.Close()
-	return ReadBanList(res.Body)
+	return crypto.NewHashFromBytes(hashB)
 }
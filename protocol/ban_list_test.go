@@ -2,7 +2,15 @@ package protocol
 
 import (
 	"bytes"
+	"fnd/crypto"
+	"fnd/testutil/testcrypto"
+	"fnd/testutil/testfs"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -124,11 +132,60 @@ func TestReadBanList(t *testing.T) {
 	}
 }
 
-func TestFetchListFile(t *testing.T) {
-	names, err := FetchListFile("<path-to-your-banlist>")
+func TestReadSignedBanList(t *testing.T) {
+	list, err := ReadSignedBanList(bytes.NewReader([]byte("FNBAN:v1\nFNSIG:beep\nfoo")))
+	require.Nil(t, list)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid ban list signature")
+
+	list, err = ReadSignedBanList(bytes.NewReader([]byte("FNBAN:v1\nsector:beep")))
+	require.Nil(t, list)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid sector hash on line 1")
+
+	priv, pub := testcrypto.RandKey()
+	_, otherPub := testcrypto.RandKey()
+	signed := &BanList{
+		Version: CurrentBanListVersion,
+		Names:   []string{"foo", "bar"},
+		Sectors: []crypto.Hash{crypto.Blake2B256([]byte("sector"))},
+	}
+	require.NoError(t, signed.Sign(crypto.NewSECP256k1Signer(priv)))
+	buf := new(bytes.Buffer)
+	require.NoError(t, signed.Encode(buf))
+
+	list, err = ReadSignedBanList(buf)
 	require.NoError(t, err)
-	require.Equal(t, 3, len(names))
-	require.Equal(t, names[0], "testname")
-	require.Equal(t, names[1], "another-test-name")
-	require.Equal(t, names[2], "hellothere")
+	require.Equal(t, signed.Names, list.Names)
+	require.Equal(t, signed.Sectors, list.Sectors)
+	require.Equal(t, signed.Signature, list.Signature)
+	require.True(t, list.Verify([]*btcec.PublicKey{otherPub, pub}))
+	require.False(t, list.Verify([]*btcec.PublicKey{otherPub}))
+	list.Sectors = nil
+	require.False(t, list.Verify([]*btcec.PublicKey{pub}))
+}
+
+func TestFetchListFile(t *testing.T) {
+	body := "FNBAN:v1\ntestname\nanother-test-name\nhellothere"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	tmpDir, done := testfs.NewTempDir(t)
+	defer done()
+	localPath := filepath.Join(tmpDir, "list.txt")
+	require.NoError(t, ioutil.WriteFile(localPath, []byte(body), 0644))
+
+	for _, url := range []string{srv.URL, "file://" + localPath} {
+		names, err := FetchListFile(url)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(names))
+		require.Equal(t, names[0], "testname")
+		require.Equal(t, names[1], "another-test-name")
+		require.Equal(t, names[2], "hellothere")
+	}
+
+	_, err := FetchListFile("ftp://example.com/list.txt")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported ban list scheme")
 }
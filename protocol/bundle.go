@@ -0,0 +1,342 @@
+package protocol
+
+import (
+	"fnd.localhost/dwire"
+	"fnd/blob"
+	"fnd/crypto"
+	"fnd/log"
+	"fnd/p2p"
+	"fnd/store"
+	"fnd/util"
+	"fnd/wire"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io"
+	"time"
+)
+
+const (
+	// BundleVersion is the version of the bundle format written by
+	// Bundle.Encode.
+	BundleVersion uint8 = 1
+
+	bundleMagic = "fndbundle"
+)
+
+var (
+	ErrBundleInvalid = errors.New("bundle is invalid")
+
+	bundleLogger = log.WithModule("bundle")
+)
+
+type BundleSector struct {
+	ID     uint8
+	Sector blob.Sector
+}
+
+func (b *BundleSector) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		b.ID,
+		b.Sector,
+	)
+}
+
+func (b *BundleSector) Decode(r io.Reader) error {
+	return dwire.DecodeFields(
+		r,
+		&b.ID,
+		&b.Sector,
+	)
+}
+
+// Bundle carries a signed blob between nodes that cannot reach each
+// other over the network. Only sectors that are not zero are
+// included. Blocked sectors are stored as zeros, so they are left out
+// too, but the merkle base keeps their real hashes.
+type Bundle struct {
+	Name         string
+	Timestamp    time.Time
+	MerkleRoot   crypto.Hash
+	ReservedRoot crypto.Hash
+	Signature    crypto.Signature
+	MerkleBase   blob.MerkleBase
+	Sectors      []*BundleSector
+}
+
+func (b *Bundle) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		bundleMagic,
+		BundleVersion,
+		b.Name,
+		b.Timestamp,
+		b.MerkleRoot,
+		b.ReservedRoot,
+		b.Signature,
+		b.MerkleBase,
+		b.Sectors,
+	)
+}
+
+func (b *Bundle) Decode(r io.Reader) error {
+	var magic string
+	var version uint8
+	if err := dwire.DecodeFields(r, &magic, &version); err != nil {
+		return errors.Wrap(err, "error decoding bundle")
+	}
+	if magic != bundleMagic {
+		return errors.New("not a bundle")
+	}
+	if version != BundleVersion {
+		return errors.Errorf("unsupported bundle version %d", version)
+	}
+	err := dwire.DecodeFields(
+		r,
+		&b.Name,
+		&b.Timestamp,
+		&b.MerkleRoot,
+		&b.ReservedRoot,
+		&b.Signature,
+		&b.MerkleBase,
+		&b.Sectors,
+	)
+	if err != nil {
+		return errors.Wrap(err, "error decoding bundle")
+	}
+	return nil
+}
+
+// Verify checks that the bundle's merkle base hashes to its merkle
+// root, and that every included sector matches the merkle base. It
+// does not check the signature, which requires the name's public key.
+func (b *Bundle) Verify() error {
+	if blob.MakeTreeFromBase(b.MerkleBase).Root() != b.MerkleRoot {
+		return errors.Wrap(ErrBundleInvalid, "merkle base does not match merkle root")
+	}
+	seen := make(map[uint8]bool)
+	for _, sector := range b.Sectors {
+		if seen[sector.ID] {
+			return errors.Wrapf(ErrBundleInvalid, "duplicate sector %d", sector.ID)
+		}
+		seen[sector.ID] = true
+		if blob.HashSector(sector.Sector) != b.MerkleBase[sector.ID] {
+			return errors.Wrapf(ErrBundleInvalid, "sector %d does not match merkle base", sector.ID)
+		}
+	}
+	return nil
+}
+
+// ExportBundle bundles the stored blob for name along with its header.
+// Blobs that do not match their headers cannot be exported.
+func ExportBundle(db *leveldb.DB, bs blob.Store, nameLocker util.MultiLocker, name string) (*Bundle, error) {
+	if !nameLocker.TryRLock(name) {
+		return nil, ErrNameLocked
+	}
+	defer nameLocker.RUnlock(name)
+
+	res, err := VerifyBlob(db, bs, name)
+	if err != nil {
+		return nil, err
+	}
+	if res.Corrupt() {
+		return nil, errors.New("blob does not match its header, repair it before exporting")
+	}
+	header, err := store.GetHeader(db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting header")
+	}
+	base, err := store.GetMerkleBase(db, name)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{
+		Name:         name,
+		Timestamp:    header.Timestamp,
+		MerkleRoot:   header.MerkleRoot,
+		ReservedRoot: header.ReservedRoot,
+		Signature:    header.Signature,
+		MerkleBase:   base,
+	}
+
+	bl, err := bs.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	for i := 0; i < blob.SectorCount; i++ {
+		id := uint8(i)
+		if base[id] == blob.EmptyBlobBaseHash {
+			continue
+		}
+		sector, err := bl.ReadSector(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading sector")
+		}
+		if sector == blob.ZeroSector {
+			continue
+		}
+		bundle.Sectors = append(bundle.Sectors, &BundleSector{
+			ID:     id,
+			Sector: sector,
+		})
+	}
+	return bundle, nil
+}
+
+type ImportBundleConfig struct {
+	Mux *p2p.PeerMuxer
+	// Announcer batches the gossip of imported bundles. If it is
+	// nil, they are gossiped to peers immediately.
+	Announcer  *UpdateAnnouncer
+	DB         *leveldb.DB
+	NameLocker util.MultiLocker
+	BlobStore  blob.Store
+	Bundle     *Bundle
+	// Broadcast gossips the imported update to peers.
+	Broadcast bool
+}
+
+// ImportBundle stores a bundled blob as if it were an update received
+// from a peer. The bundle must be signed by the name's current public
+// key, be newer than the stored header, and fit in the name's
+// timebank.
+func ImportBundle(cfg *ImportBundleConfig) error {
+	bundle := cfg.Bundle
+	l := bundleLogger.Sub("name", bundle.Name)
+	if _, err := validateUpdate(cfg.DB, bundle.Name, bundle.Timestamp, bundle.MerkleRoot, bundle.ReservedRoot, bundle.Signature); err != nil {
+		return errors.Wrap(err, "bundle failed validation")
+	}
+	if err := bundle.Verify(); err != nil {
+		return err
+	}
+
+	blockedIDs, err := BlockedSectorIDs(cfg.DB, bundle.MerkleBase)
+	if err != nil {
+		return errors.Wrap(err, "error checking sector blocklist")
+	}
+	blocked := make(map[uint8]bool)
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+	sectors := make(map[uint8]blob.Sector)
+	for _, sector := range bundle.Sectors {
+		if blocked[sector.ID] {
+			continue
+		}
+		sectors[sector.ID] = sector.Sector
+	}
+	for i, hash := range bundle.MerkleBase {
+		id := uint8(i)
+		if hash == blob.EmptyBlobBaseHash || blocked[id] {
+			continue
+		}
+		if _, ok := sectors[id]; !ok {
+			return errors.Wrapf(ErrBundleInvalid, "missing sector %d", id)
+		}
+	}
+
+	if !cfg.NameLocker.TryLock(bundle.Name) {
+		return ErrNameLocked
+	}
+	defer cfg.NameLocker.Unlock(bundle.Name)
+
+	header, err := store.GetHeader(cfg.DB, bundle.Name)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return errors.Wrap(err, "error getting header")
+	}
+	prevBase := blob.ZeroMerkleBase
+	var prevUpdateTime time.Time
+	var prevTimebank int
+	if header != nil {
+		if header.Timestamp.After(bundle.Timestamp) {
+			return ErrUpdateQueueStaleTimestamp
+		}
+		if header.Timestamp.Equal(bundle.Timestamp) {
+			return ErrUpdateQueueIdenticalTimestamp
+		}
+		prevBase, err = store.GetMerkleBase(cfg.DB, bundle.Name)
+		if err != nil {
+			return err
+		}
+		prevUpdateTime = header.ReceivedAt
+		prevTimebank = header.Timebank
+	}
+
+	sectorsNeeded := prevBase.DiffWith(bundle.MerkleBase)
+	var payableSectorCount int
+	for _, id := range sectorsNeeded {
+		if bundle.MerkleBase[id] != blob.EmptyBlobBaseHash {
+			payableSectorCount++
+		}
+	}
+	newTimebank := prevTimebank
+	if payableSectorCount > 0 {
+		newTimebank = CheckTimebank(updateTimebankParams, prevUpdateTime, prevTimebank, payableSectorCount)
+		if newTimebank == -1 {
+			return ErrInsufficientTimebank
+		}
+	}
+
+	bl, err := cfg.BlobStore.Open(bundle.Name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	defer func() {
+		if err := bl.Close(); err != nil {
+			l.Error("error closing blob", "err", err)
+		}
+	}()
+	tx, err := bl.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error starting transaction")
+	}
+	for _, id := range sectorsNeeded {
+		// sectors that are missing from the map are either
+		// empty or blocked, and are stored as zeros
+		if err := tx.WriteSector(id, sectors[id]); err != nil {
+			if err := tx.Rollback(); err != nil {
+				l.Error("error rolling back blob transaction", "err", err)
+			}
+			return errors.Wrap(err, "error writing sector")
+		}
+	}
+	err = store.WithTx(cfg.DB, func(tx *leveldb.Transaction) error {
+		return store.SetHeaderTx(tx, &store.Header{
+			Name:         bundle.Name,
+			Timestamp:    bundle.Timestamp,
+			MerkleRoot:   bundle.MerkleRoot,
+			Signature:    bundle.Signature,
+			ReservedRoot: bundle.ReservedRoot,
+			ReceivedAt:   time.Now(),
+			Timebank:     newTimebank,
+		}, blob.MakeTreeFromBase(bundle.MerkleBase))
+	})
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			l.Error("error rolling back blob transaction", "err", err)
+		}
+		return errors.Wrap(err, "error storing header")
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "error committing blob")
+	}
+	l.Info("imported bundle", "sector_count", len(sectorsNeeded))
+
+	if !cfg.Broadcast {
+		return nil
+	}
+	update := &wire.Update{
+		Name:         bundle.Name,
+		Timestamp:    bundle.Timestamp,
+		MerkleRoot:   bundle.MerkleRoot,
+		ReservedRoot: bundle.ReservedRoot,
+		Signature:    bundle.Signature,
+	}
+	if cfg.Announcer == nil {
+		p2p.GossipAll(cfg.Mux, update)
+		return nil
+	}
+	cfg.Announcer.Announce(update)
+	return nil
+}
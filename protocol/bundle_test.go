@@ -0,0 +1,137 @@
+package protocol
+
+import (
+	"bytes"
+	"fnd/blob"
+	"fnd/crypto"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/testutil/testcrypto"
+	"fnd/util"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"testing"
+	"time"
+)
+
+func TestBundle(t *testing.T) {
+	signer := testcrypto.FixedSigner(t)
+	setNameInfo := func(t *testing.T, db *leveldb.DB, signer crypto.Signer) {
+		require.NoError(t, store.WithTx(db, func(tx *leveldb.Transaction) error {
+			return store.SetNameInfoTx(tx, "foo", signer.Pub(), 10)
+		}))
+	}
+	src, srcDone := mockapp.CreateStorage(t)
+	defer srcDone()
+	setNameInfo(t, src.DB, signer)
+	// bundles encode timestamps with second precision
+	ts := time.Unix(time.Now().Unix(), 0)
+	mockapp.FillBlobRandom(t, src.DB, src.BlobStore, signer, "foo", ts, time.Now())
+
+	bundle, err := ExportBundle(src.DB, src.BlobStore, util.NewMultiLocker(), "foo")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, bundle.Encode(&buf))
+	encoded := buf.Bytes()
+	decode := func(t *testing.T) *Bundle {
+		decoded := new(Bundle)
+		require.NoError(t, decoded.Decode(bytes.NewReader(encoded)))
+		return decoded
+	}
+	decoded := decode(t)
+	require.True(t, bundle.Timestamp.Equal(decoded.Timestamp))
+	require.Equal(t, bundle.MerkleBase, decoded.MerkleBase)
+	require.Equal(t, bundle.Sectors, decoded.Sectors)
+
+	t.Run("imports valid bundles", func(t *testing.T) {
+		dest, done := mockapp.CreateStorage(t)
+		defer done()
+		setNameInfo(t, dest.DB, signer)
+		cfg := &ImportBundleConfig{
+			DB:         dest.DB,
+			NameLocker: util.NewMultiLocker(),
+			BlobStore:  dest.BlobStore,
+			Bundle:     decode(t),
+		}
+		require.NoError(t, ImportBundle(cfg))
+		mockapp.RequireBlobsEqual(t, src.BlobStore, dest.BlobStore, "foo")
+		header, err := store.GetHeader(dest.DB, "foo")
+		require.NoError(t, err)
+		require.Equal(t, bundle.MerkleRoot, header.MerkleRoot)
+		require.Equal(t, bundle.Signature, header.Signature)
+		res, err := VerifyBlob(dest.DB, dest.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.OK())
+
+		require.Equal(t, ErrUpdateQueueIdenticalTimestamp, ImportBundle(cfg))
+	})
+
+	t.Run("rejects bundles signed by another key", func(t *testing.T) {
+		dest, done := mockapp.CreateStorage(t)
+		defer done()
+		setNameInfo(t, dest.DB, testcrypto.NewRandomSigner())
+		err := ImportBundle(&ImportBundleConfig{
+			DB:         dest.DB,
+			NameLocker: util.NewMultiLocker(),
+			BlobStore:  dest.BlobStore,
+			Bundle:     decode(t),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature is invalid")
+	})
+
+	t.Run("rejects tampered sectors", func(t *testing.T) {
+		dest, done := mockapp.CreateStorage(t)
+		defer done()
+		setNameInfo(t, dest.DB, signer)
+		tampered := decode(t)
+		tampered.Sectors[0].Sector[0]++
+		err := ImportBundle(&ImportBundleConfig{
+			DB:         dest.DB,
+			NameLocker: util.NewMultiLocker(),
+			BlobStore:  dest.BlobStore,
+			Bundle:     tampered,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrBundleInvalid.Error())
+
+		missing := decode(t)
+		missing.Sectors = missing.Sectors[1:]
+		err = ImportBundle(&ImportBundleConfig{
+			DB:         dest.DB,
+			NameLocker: util.NewMultiLocker(),
+			BlobStore:  dest.BlobStore,
+			Bundle:     missing,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing sector")
+	})
+
+	t.Run("rejects updates that exceed the timebank", func(t *testing.T) {
+		dest, done := mockapp.CreateStorage(t)
+		defer done()
+		setNameInfo(t, dest.DB, signer)
+		mockapp.FillBlobRandom(t, dest.DB, dest.BlobStore, signer, "foo", time.Now().Add(-time.Hour), time.Now())
+		err := ImportBundle(&ImportBundleConfig{
+			DB:         dest.DB,
+			NameLocker: util.NewMultiLocker(),
+			BlobStore:  dest.BlobStore,
+			Bundle:     decode(t),
+		})
+		require.Equal(t, ErrInsufficientTimebank, err)
+	})
+
+	t.Run("refuses to export corrupted blobs", func(t *testing.T) {
+		storage, done := mockapp.CreateStorage(t)
+		defer done()
+		setNameInfo(t, storage.DB, signer)
+		mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, "foo", time.Now(), time.Now())
+		corruptSector(t, storage.BlobStore, "foo", 0)
+		_, err := ExportBundle(storage.DB, storage.BlobStore, util.NewMultiLocker(), "foo")
+		require.Error(t, err)
+	})
+
+	t.Run("rejects data that is not a bundle", func(t *testing.T) {
+		require.Error(t, new(Bundle).Decode(bytes.NewReader(make([]byte, blob.SectorLen))))
+	})
+}
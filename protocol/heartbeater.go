@@ -32,6 +32,7 @@ type Heartbeater struct {
 	peerID   crypto.Hash
 	lgr      log.Logger
 	quitCh   chan struct{}
+	wg       sync.WaitGroup
 	once     sync.Once
 }
 
@@ -51,6 +52,8 @@ func NewHeartbeater(url string, moniker string, peerID crypto.Hash) *Heartbeater
 	return srv
 }
 
+// Start sends heartbeats in the background until the heartbeater is
+// stopped.
 func (s *Heartbeater) Start() error {
 	client := &http.Client{
 		Timeout: s.Timeout,
@@ -65,8 +68,15 @@ func (s *Heartbeater) Start() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal heartbeat")
 	}
+	s.wg.Add(1)
+	go s.run(client, beatJSON)
+	return nil
+}
 
+func (s *Heartbeater) run(client *http.Client, beatJSON []byte) {
+	defer s.wg.Done()
 	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -82,12 +92,17 @@ func (s *Heartbeater) Start() error {
 				s.lgr.Warn("heartbeat server sent non-204 status code", "code", res.StatusCode)
 			}
 		case <-s.quitCh:
-			return nil
+			return
 		}
 	}
 }
 
 func (s *Heartbeater) Stop() error {
 	close(s.quitCh)
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Heartbeater) Health() error {
 	return nil
 }
@@ -2,8 +2,11 @@ package protocol
 
 import (
 	"fnd/blob"
+	"fnd/crypto"
 	"fnd/log"
 	"fnd/store"
+	"fnd/util"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"time"
@@ -11,9 +14,18 @@ import (
 
 const (
 	BanListUpdateInterval = 7 * 24 * time.Hour
+	// BanNameLockTimeout is how long ingestion waits for a busy name
+	// before giving up on deleting or zeroing its blob.
+	BanNameLockTimeout = 10 * time.Second
 )
 
-func IngestBanLists(db *leveldb.DB, bs blob.Store, lists []string) error {
+// IngestBanLists refreshes the names and sectors banned by the given lists.
+// Only entries that were added to or removed from a list since the last
+// refresh are touched, and bans from lists that are no longer configured
+// are dropped. Local bans are left alone. When moderator keys are
+// configured, lists fetched over HTTP(S) must be signed by one of them.
+// Names are write-locked while their blobs are deleted or zeroed.
+func IngestBanLists(db *leveldb.DB, bs blob.Store, nameLocker util.MultiLocker, lists []string, moderators []*btcec.PublicKey) error {
 	lgr := log.WithModule("moderation")
 	currRev, err := store.GetLastBanListImportAt(db)
 	if err != nil {
@@ -25,53 +37,293 @@ func IngestBanLists(db *leveldb.DB, bs blob.Store, lists []string) error {
 	}
 
 	lgr.Info("refreshing ban lists")
+	listed := make(map[string][]string)
+	listedSectors := make(map[crypto.Hash][]string)
+	for _, url := range lists {
+		lgr.Debug("fetching ban list", "url", url)
+		list, err := FetchBanList(url)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch ban list")
+		}
+		if err := verifyBanList(url, list, moderators); err != nil {
+			return err
+		}
+		for _, name := range list.Names {
+			listed[name] = append(listed[name], url)
+		}
+		for _, hash := range list.Sectors {
+			listedSectors[hash] = append(listedSectors[hash], url)
+		}
+	}
+
+	existing, err := store.ListNameBans(db)
+	if err != nil {
+		return errors.Wrap(err, "error listing banned names")
+	}
+	existingSectors, err := store.ListSectorBlocks(db)
+	if err != nil {
+		return errors.Wrap(err, "error listing blocked sectors")
+	}
+
+	var added []string
+	var removed int
+	var addedSectors int
+	var removedSectors int
 	err = store.WithTx(db, func(tx *leveldb.Transaction) error {
-		if err := store.TruncateBannedNames(tx); err != nil {
-			return errors.Wrap(err, "error truncating banned names")
+		for _, ban := range existing {
+			sources := listed[ban.Name]
+			if ban.HasSource(store.LocalBanSource) {
+				sources = append([]string{store.LocalBanSource}, sources...)
+			}
+			delete(listed, ban.Name)
+			// bans written before sources were recorded have none, and
+			// were imported from lists, so they are rewritten or lifted
+			// like any other list ban
+			if len(ban.Sources) > 0 && sameSources(ban.Sources, sources) {
+				continue
+			}
+			if len(sources) == 0 {
+				removed++
+			}
+			ban.Sources = sources
+			if err := store.SetNameBan(tx, ban); err != nil {
+				return errors.Wrap(err, "error updating banned name")
+			}
 		}
 
-		for _, url := range lists {
-			lgr.Debug("fetching ban list", "url", url)
-			listNames, err := FetchListFile(url)
-			if err != nil {
-				return errors.Wrap(err, "failed to fetch ban list")
+		for name, sources := range listed {
+			if err := store.SetNameBan(tx, &store.NameBan{
+				Name:    name,
+				Sources: sources,
+			}); err != nil {
+				return errors.Wrap(err, "error banning name")
+			}
+			added = append(added, name)
+		}
+
+		for _, block := range existingSectors {
+			sources := listedSectors[block.Hash]
+			delete(listedSectors, block.Hash)
+			if sameSources(block.Sources, sources) {
+				continue
+			}
+			if len(sources) == 0 {
+				removedSectors++
+			}
+			block.Sources = sources
+			if err := store.SetSectorBlock(tx, block); err != nil {
+				return errors.Wrap(err, "error updating blocked sector")
 			}
+		}
 
-			for _, name := range listNames {
-				if err := store.BanName(tx, name); err != nil {
-					return errors.Wrap(err, "error banning name")
-				}
-				exists, err := bs.Exists(name)
-				if err != nil {
-					return errors.Wrap(err, "error checking blob existence")
-				}
-				if !exists {
-					continue
-				}
-				lgr.Info("deleting banned name", "name", name)
-				bl, err := bs.Open(name)
-				if err != nil {
-					return errors.Wrap(err, "error opening blob")
-				}
-				tx, err := bl.Transaction()
-				if err != nil {
-					return errors.Wrap(err, "error opening transaction")
-				}
-				if err := tx.Remove(); err != nil {
-					return errors.Wrap(err, "error removing banned name")
-				}
-				if err := tx.Commit(); err != nil {
-					return errors.Wrap(err, "error committing transaction")
-				}
-				if err := bl.Close(); err != nil {
-					return errors.Wrap(err, "error closing blob")
-				}
+		for hash, sources := range listedSectors {
+			if err := store.SetSectorBlock(tx, &store.SectorBlock{
+				Hash:    hash,
+				Sources: sources,
+			}); err != nil {
+				return errors.Wrap(err, "error blocking sector")
 			}
+			addedSectors++
 		}
 		return nil
 	})
 	if err != nil {
 		return errors.Wrap(err, "error ingesting ban lists")
 	}
+
+	for _, name := range added {
+		err := withNameLock(nameLocker, name, func() error {
+			return DeleteBannedBlob(bs, name)
+		})
+		if err != nil {
+			return errors.Wrap(err, "error ingesting ban lists")
+		}
+	}
+	if addedSectors > 0 {
+		content, err := FindBlockedContent(db)
+		if err != nil {
+			return errors.Wrap(err, "error finding blocked content")
+		}
+		for _, c := range content {
+			lgr.Info("name carries blocked content", "name", c.Name, "sector_count", len(c.SectorIDs))
+			err := withNameLock(nameLocker, c.Name, func() error {
+				return ZeroBlockedSectors(bs, c.Name, c.SectorIDs)
+			})
+			if err != nil {
+				return errors.Wrap(err, "error ingesting ban lists")
+			}
+		}
+	}
+	lgr.Info(
+		"refreshed ban lists",
+		"added", len(added),
+		"removed", removed,
+		"added_sectors", addedSectors,
+		"removed_sectors", removedSectors,
+	)
 	return nil
 }
+
+type BlockedContent struct {
+	Name      string
+	SectorIDs []uint8
+}
+
+// FindBlockedContent returns the stored names whose merkle bases
+// contain blocked sector hashes.
+func FindBlockedContent(db *leveldb.DB) ([]*BlockedContent, error) {
+	blocks, err := store.ListSectorBlocks(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing blocked sectors")
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	blocked := make(map[crypto.Hash]bool)
+	for _, block := range blocks {
+		blocked[block.Hash] = true
+	}
+
+	stream, err := store.StreamHeaders(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening header stream")
+	}
+	defer stream.Close()
+	var out []*BlockedContent
+	for {
+		header, err := stream.Next()
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading header")
+		}
+		if header == nil {
+			return out, nil
+		}
+		base, err := store.GetMerkleBase(db, header.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting merkle base")
+		}
+		var ids []uint8
+		for i, hash := range base {
+			if blocked[hash] {
+				ids = append(ids, uint8(i))
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		out = append(out, &BlockedContent{
+			Name:      header.Name,
+			SectorIDs: ids,
+		})
+	}
+}
+
+// BlockedSectorIDs returns the IDs of the sectors in base whose hashes
+// are blocked.
+func BlockedSectorIDs(db *leveldb.DB, base blob.MerkleBase) ([]uint8, error) {
+	var ids []uint8
+	for i, hash := range base {
+		if hash == blob.EmptyBlobBaseHash {
+			continue
+		}
+		blocked, err := store.SectorIsBlocked(db, hash)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			ids = append(ids, uint8(i))
+		}
+	}
+	return ids, nil
+}
+
+// ZeroBlockedSectors overwrites blocked sectors of a stored blob with
+// ZeroSector. The name's stored merkle tree keeps the original hashes,
+// so the header remains valid.
+func ZeroBlockedSectors(bs blob.Store, name string, ids []uint8) error {
+	bl, err := bs.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error opening transaction")
+	}
+	for _, id := range ids {
+		if err := tx.WriteSector(id, blob.ZeroSector); err != nil {
+			if err := tx.Rollback(); err != nil {
+				log.WithModule("moderation").Error("error rolling back blob transaction", "err", err)
+			}
+			return errors.Wrap(err, "error zeroing blocked sector")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "error committing transaction")
+	}
+	return nil
+}
+
+// DeleteBannedBlob removes the blob for a newly banned name, if the node
+// stores one.
+func DeleteBannedBlob(bs blob.Store, name string) error {
+	exists, err := bs.Exists(name)
+	if err != nil {
+		return errors.Wrap(err, "error checking blob existence")
+	}
+	if !exists {
+		return nil
+	}
+	log.WithModule("moderation").Info("deleting banned name", "name", name)
+	bl, err := bs.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "error opening blob")
+	}
+	tx, err := bl.Transaction()
+	if err != nil {
+		return errors.Wrap(err, "error opening transaction")
+	}
+	if err := tx.Remove(); err != nil {
+		return errors.Wrap(err, "error removing banned name")
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "error committing transaction")
+	}
+	if err := bl.Close(); err != nil {
+		return errors.Wrap(err, "error closing blob")
+	}
+	return nil
+}
+
+func withNameLock(nameLocker util.MultiLocker, name string, fn func() error) error {
+	if !util.LockTimeout(nameLocker, name, BanNameLockTimeout) {
+		return errors.Errorf("timed out waiting for name %s", name)
+	}
+	defer nameLocker.Unlock(name)
+	return fn()
+}
+
+func verifyBanList(url string, list *BanList, moderators []*btcec.PublicKey) error {
+	if len(moderators) == 0 {
+		return nil
+	}
+	if list.Signature == nil && IsLocalBanList(url) {
+		return nil
+	}
+	if !list.Verify(moderators) {
+		return errors.Errorf("ban list %s is not signed by a moderator", url)
+	}
+	return nil
+}
+
+func sameSources(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,15 +1,26 @@
 package protocol
 
 import (
+	"fmt"
 	"fnd/blob"
+	"fnd/crypto"
 	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/testutil/testcrypto"
 	"fnd/testutil/testfs"
+	"fnd/util"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
-// TODO: refactor to use the httptest server
-
 func TestIngestBanLists(t *testing.T) {
 	db, doneDB := setupDB(t)
 	defer doneDB()
@@ -21,23 +32,171 @@ func TestIngestBanLists(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, bl.Close())
 
-	err = IngestBanLists(db, bs, []string{
-		"<path-to-your-banlist1>",
-		"<path-to-your-banlist2>",
-	})
+	remoteList := "FNBAN:v1\nfoo\nbar"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(remoteList))
+	}))
+	defer srv.Close()
+	localPath := filepath.Join(tmpDir, "local.txt")
+	require.NoError(t, ioutil.WriteFile(localPath, []byte("FNBAN:v1\nbar\nqux"), 0644))
+	localURL := "file://" + localPath
+	lists := []string{srv.URL, localURL}
+
+	require.NoError(t, store.WithTx(db, func(tx *leveldb.Transaction) error {
+		if err := store.BanName(tx, "quux", store.LocalBanSource); err != nil {
+			return err
+		}
+		if err := store.BanName(tx, "stale", "https://example.com/removed"); err != nil {
+			return err
+		}
+		return store.BanName(tx, "migrated", store.LegacyBanSource)
+	}))
+	// bans imported before sources were recorded
+	require.NoError(t, db.Put([]byte("bans/ban/legacy"), []byte{0x01}, nil))
+	require.NoError(t, db.Put([]byte("bans/ban/qux"), []byte{0x01}, nil))
+
+	// blobs are only deleted once their names are free
+	locker := util.NewMultiLocker()
+	require.True(t, locker.TryRLock("foo"))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		locker.RUnlock("foo")
+	}()
+	require.NoError(t, IngestBanLists(db, bs, locker, lists, nil))
+	requireBanSources(t, db, "foo", srv.URL)
+	requireBanSources(t, db, "bar", srv.URL, localURL)
+	requireBanSources(t, db, "qux", localURL)
+	requireBanSources(t, db, "quux", store.LocalBanSource)
+	requireBanSources(t, db, "stale")
+	requireBanSources(t, db, "legacy")
+	requireBanSources(t, db, "migrated")
+	requireBanSources(t, db, "baz")
+
+	exists, err := bs.Exists("foo")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	remoteList = "FNBAN:v1\nbar\nbaz\nquux"
+	require.NoError(t, IngestBanLists(db, bs, util.NewMultiLocker(), lists, nil))
+	requireBanSources(t, db, "foo")
+	requireBanSources(t, db, "bar", srv.URL, localURL)
+	requireBanSources(t, db, "baz", srv.URL)
+	requireBanSources(t, db, "quux", store.LocalBanSource, srv.URL)
+}
+
+func TestIngestBanLists_Signed(t *testing.T) {
+	db, doneDB := setupDB(t)
+	defer doneDB()
+	tmpDir, doneDir := testfs.NewTempDir(t)
+	defer doneDir()
+	bs := blob.NewStore(tmpDir)
+
+	modPriv, modPub := testcrypto.RandKey()
+	otherPriv, _ := testcrypto.RandKey()
+	signed := signedBanList(t, modPriv, "foo")
+	forged := signedBanList(t, otherPriv, "foo")
+	tampered := strings.Replace(signed, "foo", "bar", 1)
+
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	moderators := []*btcec.PublicKey{modPub}
+
+	invalid := []string{
+		"FNBAN:v1\nfoo",
+		forged,
+		tampered,
+	}
+	for _, in := range invalid {
+		body = in
+		err := IngestBanLists(db, bs, util.NewMultiLocker(), []string{srv.URL}, moderators)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not signed by a moderator")
+	}
+	requireBanSources(t, db, "foo")
+
+	body = signed
+	require.NoError(t, IngestBanLists(db, bs, util.NewMultiLocker(), []string{srv.URL}, moderators))
+	requireBanSources(t, db, "foo", srv.URL)
+
+	localPath := filepath.Join(tmpDir, "local.txt")
+	require.NoError(t, ioutil.WriteFile(localPath, []byte("FNBAN:v1\nbar"), 0644))
+	require.NoError(t, IngestBanLists(db, bs, util.NewMultiLocker(), []string{"file://" + localPath}, moderators))
+	requireBanSources(t, db, "bar", "file://"+localPath)
+}
+
+func TestIngestBanLists_Sectors(t *testing.T) {
+	storage, done := mockapp.CreateStorage(t)
+	defer done()
+	signer := testcrypto.FixedSigner(t)
+	ts := time.Now()
+	mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, "foo", ts, ts)
+	mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, "bar", ts, ts)
+	merkleBase, err := store.GetMerkleBase(storage.DB, "foo")
 	require.NoError(t, err)
 
-	fooBanned, err := store.NameIsBanned(db, "foo")
+	list := fmt.Sprintf("FNBAN:v1\nsector:%s", merkleBase[2])
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(list))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, IngestBanLists(storage.DB, storage.BlobStore, util.NewMultiLocker(), []string{srv.URL}, nil))
+	blocked, err := store.SectorIsBlocked(storage.DB, merkleBase[2])
+	require.NoError(t, err)
+	require.True(t, blocked)
+	content, err := FindBlockedContent(storage.DB)
+	require.NoError(t, err)
+	require.Equal(t, []*BlockedContent{
+		{
+			Name:      "foo",
+			SectorIDs: []uint8{2},
+		},
+	}, content)
+
+	bl, err := storage.BlobStore.Open("foo")
+	require.NoError(t, err)
+	sector, err := bl.ReadSector(2)
 	require.NoError(t, err)
-	require.True(t, fooBanned)
-	barBanned, err := store.NameIsBanned(db, "bar")
+	require.Equal(t, blob.ZeroSector, sector)
+	sector, err = bl.ReadSector(3)
 	require.NoError(t, err)
-	require.True(t, barBanned)
-	bazBanned, err := store.NameIsBanned(db, "baz")
+	require.Equal(t, merkleBase[3], blob.HashSector(sector))
+	require.NoError(t, bl.Close())
+	unchangedBase, err := store.GetMerkleBase(storage.DB, "foo")
 	require.NoError(t, err)
-	require.False(t, bazBanned)
+	require.Equal(t, merkleBase, unchangedBase)
 
-	exists, err := bs.Exists("foo")
+	list = "FNBAN:v1"
+	require.NoError(t, IngestBanLists(storage.DB, storage.BlobStore, util.NewMultiLocker(), []string{srv.URL}, nil))
+	blocked, err = store.SectorIsBlocked(storage.DB, merkleBase[2])
 	require.NoError(t, err)
-	require.False(t, exists)
+	require.False(t, blocked)
+	content, err = FindBlockedContent(storage.DB)
+	require.NoError(t, err)
+	require.Empty(t, content)
+}
+
+func signedBanList(t *testing.T, pk *btcec.PrivateKey, names ...string) string {
+	list := &BanList{
+		Version: CurrentBanListVersion,
+		Names:   names,
+	}
+	require.NoError(t, list.Sign(crypto.NewSECP256k1Signer(pk)))
+	var sb strings.Builder
+	require.NoError(t, list.Encode(&sb))
+	return sb.String()
+}
+
+func requireBanSources(t *testing.T, db *leveldb.DB, name string, sources ...string) {
+	ban, err := store.GetNameBan(db, name)
+	require.NoError(t, err)
+	if len(sources) == 0 {
+		require.Nil(t, ban, fmt.Sprintf("expected %s to be unbanned", name))
+		return
+	}
+	require.NotNil(t, ban, fmt.Sprintf("expected %s to be banned", name))
+	require.Equal(t, sources, ban.Sources)
 }
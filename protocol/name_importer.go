@@ -8,6 +8,7 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"fnd/config"
 	"fnd/log"
+	"fnd/service"
 	"fnd/store"
 	"fnd.localhost/handshake/client"
 	"fnd.localhost/handshake/dns"
@@ -29,6 +30,9 @@ type NameImporter struct {
 	db     *leveldb.DB
 	lgr    log.Logger
 	quitCh chan struct{}
+	wg     sync.WaitGroup
+	hsdErr error
+	mu     sync.Mutex
 }
 
 type HNSName struct {
@@ -49,17 +53,43 @@ func NewNameImporter(client *client.Client, db *leveldb.DB) *NameImporter {
 	}
 }
 
+// Start imports names from HSD in the background until the importer
+// is stopped. Health reports whether the initial import is complete.
 func (n *NameImporter) Start() error {
-	return n.sync()
+	n.wg.Add(1)
+	go n.sync()
+	return nil
 }
 
 func (n *NameImporter) Stop() error {
 	close(n.quitCh)
+	n.wg.Wait()
 	return nil
 }
 
-func (n *NameImporter) sync() error {
+// Health reports the importer as not ready until the initial name
+// import completes, or while HSD is unreachable.
+func (n *NameImporter) Health() error {
+	n.mu.Lock()
+	hsdErr := n.hsdErr
+	n.mu.Unlock()
+	if hsdErr != nil {
+		return service.NotReady(errors.Wrap(hsdErr, "HSD is unreachable"))
+	}
+	complete, err := store.GetInitialImportComplete(n.db)
+	if err != nil {
+		return errors.Wrap(err, "error getting initial import status")
+	}
+	if !complete {
+		return service.NotReady(errors.New("initial name import is not complete"))
+	}
+	return nil
+}
+
+func (n *NameImporter) sync() {
+	defer n.wg.Done()
 	ticker := time.NewTicker(n.CheckInterval)
+	defer ticker.Stop()
 
 	for {
 		n.doSync()
@@ -67,13 +97,16 @@ func (n *NameImporter) sync() error {
 		select {
 		case <-ticker.C:
 		case <-n.quitCh:
-			return nil
+			return
 		}
 	}
 }
 
 func (n *NameImporter) doSync() {
 	info, err := n.client.RPCGetBlockchainInfo()
+	n.mu.Lock()
+	n.hsdErr = err
+	n.mu.Unlock()
 	if err != nil {
 		n.lgr.Error("failed to get chain info", "err", err)
 		return
@@ -32,6 +32,7 @@ type NameSyncer struct {
 	obs                   *util.Observable
 	lgr                   log.Logger
 	doneCh                chan struct{}
+	readyCh               chan struct{}
 	once                  sync.Once
 }
 
@@ -49,12 +50,14 @@ func NewNameSyncer(mux *p2p.PeerMuxer, db *leveldb.DB, nameLocker util.MultiLock
 		obs:                   util.NewObservable(),
 		lgr:                   log.WithModule("name-syncer"),
 		doneCh:                make(chan struct{}),
+		readyCh:               make(chan struct{}),
 	}
 }
 
 func (ns *NameSyncer) Start() error {
 	ns.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeUpdate, ns.handleUpdate))
 	ns.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeNilUpdate, ns.handleNilUpdate))
+	close(ns.readyCh)
 
 	resyncTick := time.NewTicker(ns.Interval)
 	for {
@@ -62,18 +65,24 @@ func (ns *NameSyncer) Start() error {
 		total := in + out
 		if total == 0 {
 			ns.lgr.Info("no connected peers, skipping name sync")
-			time.Sleep(5 * time.Second)
+			if !ns.sleep(5 * time.Second) {
+				return nil
+			}
 			continue
 		}
 		initialImportComplete, err := store.GetInitialImportComplete(ns.db)
 		if err != nil {
 			ns.lgr.Error("error getting initial import complete", "err", err)
-			time.Sleep(5 * time.Second)
+			if !ns.sleep(5 * time.Second) {
+				return nil
+			}
 			continue
 		}
 		if !initialImportComplete {
 			ns.lgr.Info("initial import incomplete, skipping name sync")
-			time.Sleep(time.Minute)
+			if !ns.sleep(time.Minute) {
+				return nil
+			}
 			continue
 		}
 		ns.doSync()
@@ -90,11 +99,33 @@ func (ns *NameSyncer) Start() error {
 	}
 }
 
+// Ready is closed once the syncer's message handlers are registered.
+func (ns *NameSyncer) Ready() <-chan struct{} {
+	return ns.readyCh
+}
+
 func (ns *NameSyncer) Stop() error {
 	close(ns.doneCh)
 	return nil
 }
 
+func (ns *NameSyncer) Health() error {
+	return nil
+}
+
+// sleep waits for the given duration. It returns false if the syncer
+// was stopped in the meantime.
+func (ns *NameSyncer) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ns.doneCh:
+		return false
+	}
+}
+
 func (ns *NameSyncer) handleUpdate(peerID crypto.Hash, envelope *wire.Envelope) {
 	ns.obs.Emit("message:update", envelope.Message.(*wire.Update))
 }
@@ -10,7 +10,6 @@ import (
 	"fnd/wire"
 	"github.com/syndtr/goleveldb/leveldb"
 	"math"
-	"math/rand"
 	"net"
 	"sync"
 	"time"
@@ -21,10 +20,20 @@ const (
 )
 
 type PeerExchanger struct {
-	SampleSize      int
-	ResponseTimeout time.Duration
-	RequestInterval time.Duration
+	SampleSize         int
+	ResponseTimeout    time.Duration
+	RequestInterval    time.Duration
+	MaxSentPeers       int
+	MaxReceivedPeers   int
+	MaxConcurrentDials int
+	Services           uint64
+	// AdvertisedAddrs are this node's own reachable addresses, such
+	// as an onion address, which are sent along with exchanged peers
+	// and Services.
+	AdvertisedAddrs []string
+	PeerID          crypto.Hash
 	dialer          p2p.PeerDialer
+	addrs           *p2p.AddrManager
 	mux             *p2p.PeerMuxer
 	db              *leveldb.DB
 	cache           *util.Cache
@@ -32,28 +41,35 @@ type PeerExchanger struct {
 	mtx             sync.Mutex
 	lgr             log.Logger
 	doneCh          chan struct{}
+	readyCh         chan struct{}
 	obs             *util.Observable
 }
 
-func NewPeerExchanger(dialer p2p.PeerDialer, mux *p2p.PeerMuxer, db *leveldb.DB) *PeerExchanger {
+func NewPeerExchanger(dialer p2p.PeerDialer, addrs *p2p.AddrManager, mux *p2p.PeerMuxer, db *leveldb.DB) *PeerExchanger {
 	return &PeerExchanger{
-		SampleSize:      config.DefaultConfig.Tuning.PeerExchanger.SampleSize,
-		ResponseTimeout: config.ConvertDuration(config.DefaultConfig.Tuning.PeerExchanger.ResponseTimeoutMS, time.Millisecond),
-		RequestInterval: config.ConvertDuration(config.DefaultConfig.Tuning.PeerExchanger.RequestIntervalMS, time.Millisecond),
-		dialer:          dialer,
-		mux:             mux,
-		db:              db,
-		cache:           util.NewCache(),
-		activeDials:     make(map[crypto.Hash]bool),
-		doneCh:          make(chan struct{}),
-		obs:             util.NewObservable(),
-		lgr:             log.WithModule("peer-exchanger"),
+		SampleSize:         config.DefaultConfig.Tuning.PeerExchanger.SampleSize,
+		ResponseTimeout:    config.ConvertDuration(config.DefaultConfig.Tuning.PeerExchanger.ResponseTimeoutMS, time.Millisecond),
+		RequestInterval:    config.ConvertDuration(config.DefaultConfig.Tuning.PeerExchanger.RequestIntervalMS, time.Millisecond),
+		MaxSentPeers:       config.DefaultConfig.Tuning.PeerExchanger.MaxSentPeers,
+		MaxReceivedPeers:   config.DefaultConfig.Tuning.PeerExchanger.MaxReceivedPeers,
+		MaxConcurrentDials: config.DefaultConfig.Tuning.PeerExchanger.MaxConcurrentDials,
+		dialer:             dialer,
+		addrs:              addrs,
+		mux:                mux,
+		db:                 db,
+		cache:              util.NewCache(),
+		activeDials:        make(map[crypto.Hash]bool),
+		doneCh:             make(chan struct{}),
+		readyCh:            make(chan struct{}),
+		obs:                util.NewObservable(),
+		lgr:                log.WithModule("peer-exchanger"),
 	}
 }
 
 func (pe *PeerExchanger) Start() error {
 	pe.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypePeerReq, pe.handlePeerReq))
 	pe.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypePeerRes, pe.handlePeerRes))
+	close(pe.readyCh)
 
 	tick := time.NewTicker(pe.RequestInterval)
 
@@ -71,41 +87,43 @@ func (pe *PeerExchanger) Start() error {
 	}
 }
 
+// Ready is closed once its message handlers are registered.
+func (pe *PeerExchanger) Ready() <-chan struct{} {
+	return pe.readyCh
+}
+
 func (pe *PeerExchanger) Stop() error {
 	close(pe.doneCh)
 	return nil
 }
 
+func (pe *PeerExchanger) Health() error {
+	return nil
+}
+
 func (pe *PeerExchanger) handlePeerReq(peerID crypto.Hash, envelope *wire.Envelope) {
-	peerStream, err := store.StreamPeers(pe.db, false)
-	if err != nil {
-		pe.lgr.Error("error opening peer stream", "err", err)
-		return
+	maxSent := pe.MaxSentPeers
+	if maxSent > MaxSentPeerCount {
+		maxSent = MaxSentPeerCount
 	}
-	defer peerStream.Close()
-
 	var peers []*wire.Peer
-	for {
-		if len(peers) == MaxSentPeerCount {
-			break
-		}
-		peer, err := peerStream.Next()
+	for _, advertised := range pe.AdvertisedAddrs {
+		host, port, err := p2p.ParseAddr(advertised)
 		if err != nil {
-			pe.lgr.Error("error streaming stored peer", "err", err)
-			return
+			pe.lgr.Error("invalid advertised address", "addr", advertised, "err", err)
+			continue
 		}
-		if peer == nil {
-			break
+		peers = append(peers, newWirePeer(pe.PeerID, host, port, pe.Services))
+	}
+	for _, addr := range pe.addrs.Sample(maxSent - len(peers)) {
+		// there's no point in telling peers about nodes they can't
+		// connect to
+		if !p2p.AcceptsInbound(addr.Services) {
+			continue
 		}
-		peers = append(peers, &wire.Peer{
-			IP: net.ParseIP(peer.IP),
-			ID: peer.ID,
-		})
+		peers = append(peers, newWirePeer(addr.ID, addr.IP, addr.Port, addr.Services))
 	}
 
-	rand.Shuffle(len(peers), func(i, j int) {
-		peers[i], peers[j] = peers[j], peers[i]
-	})
 	msg := &wire.PeerRes{
 		Peers: peers,
 	}
@@ -124,18 +142,50 @@ func (pe *PeerExchanger) handlePeerRes(peerID crypto.Hash, envelope *wire.Envelo
 	}
 
 	pe.cache.Del(peerIDStr)
+	source, err := pe.mux.PeerByID(peerID)
+	if err != nil {
+		pe.lgr.Warn("received PeerRes from disconnected peer", "peer_id", peerID)
+		return
+	}
 	msg := envelope.Message.(*wire.PeerRes)
 	pe.lgr.Debug("received new peers", "source_peer_id", peerID, "count", len(msg.Peers))
-	for _, peer := range msg.Peers {
-		pe.dialPeer(peer)
+	var added int
+	for i, peer := range msg.Peers {
+		if i == pe.MaxReceivedPeers {
+			break
+		}
+		host := peer.Host
+		if host == "" {
+			host = peer.IP.String()
+		}
+		if !p2p.AcceptsInbound(peer.Services) {
+			continue
+		}
+		if pe.addrs.Add(peer.ID, host, int(peer.Port), peer.Services, source.RemoteIP(), false) {
+			added++
+		}
+	}
+	pe.lgr.Debug("added exchanged peers to address book", "source_peer_id", peerID, "added", added)
+
+	for i := 0; i < pe.MaxConcurrentDials; i++ {
+		addr := pe.addrs.Select(func(entry *store.AddrBookEntry) bool {
+			return entry.Tried ||
+				!p2p.AcceptsInbound(entry.Services) ||
+				pe.mux.HasPeerID(entry.ID) ||
+				pe.mux.HasOutboundPeerAddr(p2p.JoinAddr(entry.IP, entry.Port))
+		})
+		if addr == nil {
+			break
+		}
+		pe.dialPeer(addr)
 	}
 }
 
-func (pe *PeerExchanger) dialPeer(peer *wire.Peer) {
+func (pe *PeerExchanger) dialPeer(peer *store.AddrBookEntry) {
 	peerID := peer.ID
-	ipStr := peer.IP.String()
-	pe.lgr.Trace("dialing exchanged peer", "ip", ipStr, "peer_id", peerID)
-	err := pe.dialer.DialPeer(peerID, ipStr, false)
+	ipStr := peer.IP
+	pe.lgr.Trace("dialing exchanged peer", "ip", ipStr, "port", peer.Port, "peer_id", peerID)
+	err := pe.dialer.DialPeer(peerID, ipStr, peer.Port, false)
 	if err == p2p.ErrAlreadyConnecting {
 		pe.lgr.Trace("already connecting to exchanged peer", "ip", ipStr)
 		return
@@ -160,3 +210,21 @@ func (pe *PeerExchanger) dialPeer(peer *wire.Peer) {
 		pe.lgr.Error("failed to connect to exchanged peer", "ip", ipStr, "err", err)
 	}
 }
+
+func newWirePeer(id crypto.Hash, host string, port int, services uint64) *wire.Peer {
+	peer := &wire.Peer{
+		ID:       id,
+		Services: services,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		peer.IP = ip
+	} else {
+		peer.Host = host
+	}
+	// leave standard ports unset so that the message stays
+	// identical to what older nodes send
+	if port != p2p.StandardPort {
+		peer.Port = uint16(port)
+	}
+	return peer
+}
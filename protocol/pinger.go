@@ -57,6 +57,10 @@ func (p *Pinger) Stop() error {
 	return nil
 }
 
+func (p *Pinger) Health() error {
+	return nil
+}
+
 func (p *Pinger) handlePeerOpen(peerID crypto.Hash) {
 	p.wg.Add(1)
 	ctx, cancel := context.WithCancel(context.Background())
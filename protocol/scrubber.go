@@ -0,0 +1,201 @@
+package protocol
+
+import (
+	"fnd/blob"
+	"fnd/config"
+	"fnd/log"
+	"fnd/p2p"
+	"fnd/store"
+	"fnd/util"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+	"time"
+)
+
+// Scrubber periodically verifies every stored blob, and queues
+// corrupted blobs to be re-synced from peers by the Updater.
+type Scrubber struct {
+	// Interval is how often all blobs are verified. Background
+	// verification is disabled if it is zero.
+	Interval time.Duration
+	// Pause is how long to wait between verifying two blobs.
+	Pause      time.Duration
+	mux        *p2p.PeerMuxer
+	db         *leveldb.DB
+	bs         blob.Store
+	nameLocker util.MultiLocker
+	queue      *UpdateQueue
+	lastErr    error
+	quitCh     chan struct{}
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	lgr        log.Logger
+}
+
+type ScrubStats struct {
+	Checked       int
+	Corrupt       int
+	BadSignature  int
+	Orphaned      int
+	Banned        int
+	RepairsQueued int
+}
+
+func NewScrubber(mux *p2p.PeerMuxer, db *leveldb.DB, bs blob.Store, nameLocker util.MultiLocker, queue *UpdateQueue) *Scrubber {
+	return &Scrubber{
+		Interval:   config.ConvertDuration(config.DefaultConfig.Tuning.Scrubber.IntervalMS, time.Millisecond),
+		Pause:      config.ConvertDuration(config.DefaultConfig.Tuning.Scrubber.PauseMS, time.Millisecond),
+		mux:        mux,
+		db:         db,
+		bs:         bs,
+		nameLocker: nameLocker,
+		queue:      queue,
+		quitCh:     make(chan struct{}),
+		lgr:        log.WithModule("scrubber"),
+	}
+}
+
+// Start verifies blobs in the background every Interval until the
+// scrubber is stopped.
+func (s *Scrubber) Start() error {
+	if s.Interval <= 0 {
+		return nil
+	}
+	s.wg.Add(1)
+	go s.run()
+	return nil
+}
+
+func (s *Scrubber) run() {
+	defer s.wg.Done()
+	tick := time.NewTicker(s.Interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			stats, err := s.Scrub()
+			s.mu.Lock()
+			s.lastErr = err
+			s.mu.Unlock()
+			if err != nil {
+				s.lgr.Error("error scrubbing blobs", "err", err)
+				continue
+			}
+			s.lgr.Info(
+				"scrubbed blobs",
+				"checked", stats.Checked,
+				"corrupt", stats.Corrupt,
+				"bad_signature", stats.BadSignature,
+				"orphaned", stats.Orphaned,
+				"banned", stats.Banned,
+				"repairs_queued", stats.RepairsQueued,
+			)
+		case <-s.quitCh:
+			return
+		}
+	}
+}
+
+func (s *Scrubber) Stop() error {
+	close(s.quitCh)
+	s.wg.Wait()
+	return nil
+}
+
+// Health returns an error if the last scrub failed.
+func (s *Scrubber) Health() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastErr != nil {
+		return errors.Wrap(s.lastErr, "last scrub failed")
+	}
+	return nil
+}
+
+// Scrub verifies every stored blob. Names that are being updated are
+// skipped, since their content is about to change.
+func (s *Scrubber) Scrub() (*ScrubStats, error) {
+	stream, err := store.StreamHeaders(s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening header stream")
+	}
+	defer stream.Close()
+
+	stats := new(ScrubStats)
+	for {
+		header, err := stream.Next()
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading header")
+		}
+		if header == nil {
+			return stats, nil
+		}
+		res, err := s.Check(header.Name, true)
+		if errors.Is(err, ErrNameLocked) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		stats.Checked++
+		if res.Corrupt() {
+			stats.Corrupt++
+		}
+		if res.BadSignature {
+			stats.BadSignature++
+		}
+		if res.Orphaned {
+			stats.Orphaned++
+		}
+		if res.Banned {
+			stats.Banned++
+		}
+		if res.RepairQueued {
+			stats.RepairsQueued++
+		}
+
+		timer := time.NewTimer(s.Pause)
+		select {
+		case <-timer.C:
+		case <-s.quitCh:
+			timer.Stop()
+			return stats, nil
+		}
+	}
+}
+
+// Check verifies the stored blob for name. If repair is true and the
+// blob is corrupt, it is queued to be re-synced from connected peers.
+// Orphaned blobs and blobs with bad signatures are only reported,
+// since peers cannot serve valid content for them.
+func (s *Scrubber) Check(name string, repair bool) (*BlobVerification, error) {
+	if !s.nameLocker.TryRLock(name) {
+		return nil, ErrNameLocked
+	}
+	res, err := VerifyBlob(s.db, s.bs, name)
+	s.nameLocker.RUnlock(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error verifying blob")
+	}
+	if !res.OK() {
+		s.lgr.Warn(
+			"blob failed verification",
+			"name", name,
+			"corrupt_sectors", len(res.CorruptSectors),
+			"root_mismatch", res.RootMismatch,
+			"bad_signature", res.BadSignature,
+			"orphaned", res.Orphaned,
+			"banned", res.Banned,
+		)
+	}
+	if !repair || !res.Corrupt() || res.BadSignature || res.Orphaned || res.Banned {
+		return res, nil
+	}
+	if err := s.queue.EnqueueRepair(name, s.mux.PeerIDs()); err != nil {
+		s.lgr.Warn("error queueing blob repair", "name", name, "err", err)
+		return res, nil
+	}
+	res.RepairQueued = true
+	return res, nil
+}
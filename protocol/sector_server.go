@@ -1,7 +1,6 @@
 package protocol
 
 import (
-	"errors"
 	"fmt"
 	"fnd/blob"
 	"fnd/config"
@@ -11,35 +10,52 @@ import (
 	"fnd/store"
 	"fnd/util"
 	"fnd/wire"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
 	"time"
 )
 
 type SectorServer struct {
-	CacheExpiry time.Duration
-	mux         *p2p.PeerMuxer
-	db          *leveldb.DB
-	bs          blob.Store
-	nameLocker  util.MultiLocker
-	lgr         log.Logger
-	cache       *util.Cache
+	CacheExpiry     time.Duration
+	BatchAckTimeout time.Duration
+	MaxBatchStreams int
+	mux             *p2p.PeerMuxer
+	db              *leveldb.DB
+	bs              blob.Store
+	nameLocker      util.MultiLocker
+	lgr             log.Logger
+	cache           *util.Cache
+	streams         map[crypto.Hash]*batchStream
+	streamsMu       sync.Mutex
+}
+
+// batchStream tracks a SectorBatchReq being streamed to a peer.
+type batchStream struct {
+	name string
+	acks chan struct{}
 }
 
 func NewSectorServer(mux *p2p.PeerMuxer, db *leveldb.DB, bs blob.Store, nameLocker util.MultiLocker) *SectorServer {
 	return &SectorServer{
-		CacheExpiry: config.ConvertDuration(config.DefaultConfig.Tuning.SectorServer.CacheExpiryMS, time.Millisecond),
-		mux:         mux,
-		db:          db,
-		bs:          bs,
-		nameLocker:  nameLocker,
-		cache:       util.NewCache(),
-		lgr:         log.WithModule("sector-server"),
+		CacheExpiry:     config.ConvertDuration(config.DefaultConfig.Tuning.SectorServer.CacheExpiryMS, time.Millisecond),
+		BatchAckTimeout: config.ConvertDuration(config.DefaultConfig.Tuning.SectorServer.BatchAckTimeoutMS, time.Millisecond),
+		MaxBatchStreams: config.DefaultConfig.Tuning.SectorServer.MaxBatchStreams,
+		mux:             mux,
+		db:              db,
+		bs:              bs,
+		nameLocker:      nameLocker,
+		cache:           util.NewCache(),
+		streams:         make(map[crypto.Hash]*batchStream),
+		lgr:             log.WithModule("sector-server"),
 	}
 }
 
 func (s *SectorServer) Start() error {
 	s.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeTreeBaseReq, s.onTreeBaseReq))
 	s.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeSectorReq, s.onSectorReq))
+	s.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeSectorBatchReq, s.onSectorBatchReq))
+	s.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeSectorBatchAck, s.onSectorBatchAck))
 	return nil
 }
 
@@ -47,6 +63,10 @@ func (s *SectorServer) Stop() error {
 	return nil
 }
 
+func (s *SectorServer) Health() error {
+	return nil
+}
+
 func (s *SectorServer) onTreeBaseReq(peerID crypto.Hash, envelope *wire.Envelope) {
 	reqMsg := envelope.Message.(*wire.TreeBaseReq)
 	lgr := s.lgr.Sub(
@@ -84,54 +104,187 @@ func (s *SectorServer) onSectorReq(peerID crypto.Hash, envelope *wire.Envelope)
 		"peer_id", peerID,
 	)
 
-	if !s.nameLocker.TryRLock(reqMsg.Name) {
-		lgr.Info("dropping sector req for busy name")
-		return
-	}
-	header, err := store.GetHeader(s.db, reqMsg.Name)
+	sectors, err := s.readSectors(reqMsg.Name, []uint8{reqMsg.SectorID})
 	if errors.Is(err, leveldb.ErrNotFound) {
-		s.nameLocker.RUnlock(reqMsg.Name)
 		return
 	}
 	if err != nil {
-		lgr.Error("error getting blob header", "err", err)
-		s.nameLocker.RUnlock(reqMsg.Name)
+		lgr.Info("dropping sector req", "err", err)
 		return
 	}
-	cacheKey := fmt.Sprintf("%s:%d:%d", reqMsg.Name, header.Timestamp.Unix(), reqMsg.SectorID)
-	cached := s.cache.Get(cacheKey)
-	if cached != nil {
-		s.nameLocker.RUnlock(reqMsg.Name)
-		s.sendResponse(peerID, reqMsg.Name, reqMsg.SectorID, cached.(blob.Sector))
+	if len(sectors) == 0 {
+		lgr.Info("refusing to serve blocked sector", "sector_id", reqMsg.SectorID)
 		return
 	}
+	s.sendResponse(peerID, reqMsg.Name, reqMsg.SectorID, sectors[0].Sector)
+}
 
-	bl, err := s.bs.Open(reqMsg.Name)
-	if err != nil {
-		s.nameLocker.RUnlock(reqMsg.Name)
-		lgr.Error(
-			"failed to fetch blob",
-			"err", err,
-		)
+func (s *SectorServer) onSectorBatchReq(peerID crypto.Hash, envelope *wire.Envelope) {
+	reqMsg := envelope.Message.(*wire.SectorBatchReq)
+	lgr := s.lgr.Sub(
+		"name", reqMsg.Name,
+		"peer_id", peerID,
+	)
+	if reqMsg.Window == 0 {
+		lgr.Info("dropping sector batch req with empty window")
+		return
+	}
+
+	s.streamsMu.Lock()
+	if s.streams[peerID] != nil {
+		s.streamsMu.Unlock()
+		lgr.Info("dropping sector batch req while another is streaming")
+		return
+	}
+	if len(s.streams) >= s.MaxBatchStreams {
+		s.streamsMu.Unlock()
+		lgr.Info("dropping sector batch req, too many streams")
+		return
+	}
+	stream := &batchStream{
+		name: reqMsg.Name,
+		acks: make(chan struct{}, reqMsg.Window),
+	}
+	s.streams[peerID] = stream
+	s.streamsMu.Unlock()
+
+	go func() {
+		s.streamSectors(peerID, reqMsg, stream)
+		s.streamsMu.Lock()
+		delete(s.streams, peerID)
+		s.streamsMu.Unlock()
+	}()
+}
+
+func (s *SectorServer) onSectorBatchAck(peerID crypto.Hash, envelope *wire.Envelope) {
+	msg := envelope.Message.(*wire.SectorBatchAck)
+	s.streamsMu.Lock()
+	stream := s.streams[peerID]
+	s.streamsMu.Unlock()
+	if stream == nil || stream.name != msg.Name {
 		return
 	}
+	select {
+	case stream.acks <- struct{}{}:
+	default:
+	}
+}
+
+// streamSectors sends the requested sectors in SectorBatchRes
+// messages. At most req.Window messages are unacknowledged at once,
+// and the stream stops if the peer doesn't acknowledge one within
+// BatchAckTimeout.
+func (s *SectorServer) streamSectors(peerID crypto.Hash, req *wire.SectorBatchReq, stream *batchStream) {
+	lgr := s.lgr.Sub(
+		"name", req.Name,
+		"peer_id", peerID,
+	)
+	ids := req.Sectors.IDs()
+	credits := int(req.Window)
+	var sent int
+	for len(ids) > 0 {
+		if credits == 0 {
+			timer := time.NewTimer(s.BatchAckTimeout)
+			select {
+			case <-stream.acks:
+				credits++
+				timer.Stop()
+			case <-timer.C:
+				lgr.Info("sector batch ack timed out", "sent", sent)
+				return
+			}
+		}
+
+		n := len(ids)
+		if n > wire.MaxSectorBatchResLen {
+			n = wire.MaxSectorBatchResLen
+		}
+		sectors, err := s.readSectors(req.Name, ids[:n])
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			lgr.Info("aborting sector batch", "err", err)
+			return
+		}
+		ids = ids[n:]
+		if len(sectors) == 0 {
+			continue
+		}
+		resMsg := &wire.SectorBatchRes{
+			Name:    req.Name,
+			Sectors: sectors,
+		}
+		if err := s.mux.Send(peerID, resMsg); err != nil {
+			lgr.Error("error serving sector batch response", "err", err)
+			return
+		}
+		sent += len(sectors)
+		credits--
+	}
+	lgr.Debug("served sector batch", "sector_count", sent)
+}
+
+// readSectors reads the given sectors of name, using the sector
+// cache where possible. Sectors whose hashes are blocked are left
+// out of the result.
+func (s *SectorServer) readSectors(name string, ids []uint8) ([]*wire.BatchSector, error) {
+	if !s.nameLocker.TryRLock(name) {
+		return nil, errors.New("name is busy")
+	}
+	defer s.nameLocker.RUnlock(name)
+	header, err := store.GetHeader(s.db, name)
+	if err != nil {
+		return nil, err
+	}
+	merkleBase, err := store.GetMerkleBase(s.db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var bl blob.Blob
 	defer func() {
+		if bl == nil {
+			return
+		}
 		if err := bl.Close(); err != nil {
 			s.lgr.Error("failed to close blob", "err", err)
 		}
 	}()
-	sector, err := bl.ReadSector(reqMsg.SectorID)
-	if err != nil {
-		s.nameLocker.RUnlock(reqMsg.Name)
-		lgr.Error(
-			"failed to read sector",
-			"err", err,
-		)
-		return
+	sectors := make([]*wire.BatchSector, 0, len(ids))
+	for _, id := range ids {
+		blocked, err := store.SectorIsBlocked(s.db, merkleBase[id])
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			continue
+		}
+		cacheKey := fmt.Sprintf("%s:%d:%d", name, header.Timestamp.Unix(), id)
+		if cached := s.cache.Get(cacheKey); cached != nil {
+			sectors = append(sectors, &wire.BatchSector{
+				ID:     id,
+				Sector: cached.(blob.Sector),
+			})
+			continue
+		}
+		if bl == nil {
+			bl, err = s.bs.Open(name)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to fetch blob")
+			}
+		}
+		sector, err := bl.ReadSector(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read sector")
+		}
+		s.cache.Set(cacheKey, sector, int64(s.CacheExpiry/time.Millisecond))
+		sectors = append(sectors, &wire.BatchSector{
+			ID:     id,
+			Sector: sector,
+		})
 	}
-	s.cache.Set(cacheKey, sector, int64(s.CacheExpiry/time.Millisecond))
-	s.nameLocker.RUnlock(reqMsg.Name)
-	s.sendResponse(peerID, reqMsg.Name, reqMsg.SectorID, sector)
+	return sectors, nil
 }
 
 func (s *SectorServer) sendResponse(peerID crypto.Hash, name string, sectorID uint8, sector blob.Sector) {
@@ -7,6 +7,7 @@ import (
 	"fnd/p2p"
 	"fnd/wire"
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
 	"sync"
 	"time"
 )
@@ -14,6 +15,7 @@ import (
 const (
 	DefaultSyncerTreeBaseResTimeout = 10 * time.Second
 	DefaultSyncerSectorResTimeout   = 15 * time.Second
+	DefaultSyncerBatchWindow        = 4
 )
 
 var (
@@ -40,6 +42,10 @@ func SyncTreeBases(opts *SyncTreeBasesOpts) (blob.MerkleBase, error) {
 		if !ok {
 			return newMerkleBase, ErrNoTreeBaseCandidates
 		}
+		// relay-only peers don't store blobs
+		if !opts.Mux.HasService(peerID, wire.ServiceBlobs) {
+			continue
+		}
 
 		var once sync.Once
 		unsubTreeBaseRes := opts.Mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeTreeBaseRes, func(recvPeerID crypto.Hash, res *wire.Envelope) {
@@ -88,13 +94,21 @@ func SyncTreeBases(opts *SyncTreeBasesOpts) (blob.MerkleBase, error) {
 }
 
 type SyncSectorsOpts struct {
-	Timeout       time.Duration
+	Timeout time.Duration
+	// BatchWindow is how many SectorBatchRes messages peers can send
+	// before waiting for an acknowledgement. It defaults to
+	// DefaultSyncerBatchWindow.
+	BatchWindow   uint8
 	Mux           *p2p.PeerMuxer
 	Tx            blob.Transaction
 	Peers         *PeerSet
 	MerkleBase    blob.MerkleBase
 	SectorsNeeded []uint8
 	Name          string
+	// DB is used to check sectors against the sector blocklist.
+	// Blocked sectors are stored as ZeroSector instead of being
+	// fetched. If DB is nil, no sectors are blocked.
+	DB *leveldb.DB
 }
 
 type sectorRes struct {
@@ -107,10 +121,23 @@ type reqdSectorsMap map[uint8][33]byte
 func SyncSectors(opts *SyncSectorsOpts) error {
 	l := log.WithModule("sector-syncer").Sub("name", opts.Name)
 	tx := opts.Tx
+	blocked := make(map[uint8]bool)
+	if opts.DB != nil {
+		blockedIDs, err := BlockedSectorIDs(opts.DB, opts.MerkleBase)
+		if err != nil {
+			return errors.Wrap(err, "error checking sector blocklist")
+		}
+		for _, id := range blockedIDs {
+			blocked[id] = true
+		}
+	}
 	reqdSectors := make(reqdSectorsMap)
 	for _, id := range opts.SectorsNeeded {
 		hash := opts.MerkleBase[id]
-		if hash == blob.EmptyBlobBaseHash {
+		if blocked[id] {
+			l.Info("skipping blocked sector", "sector_id", id)
+		}
+		if hash == blob.EmptyBlobBaseHash || blocked[id] {
 			if err := tx.WriteSector(id, blob.ZeroSector); err != nil {
 				return errors.Wrap(err, "error writing zero sector")
 			}
@@ -121,13 +148,23 @@ func SyncSectors(opts *SyncSectorsOpts) error {
 
 	neededLen := len(reqdSectors)
 	var attempts int
+	batchTried := make(map[crypto.Hash]bool)
 	for {
 		if attempts == 3 {
 			return ErrSyncerMaxAttempts
 		}
 
 		l.Trace("performing sync attempt", "attempts", attempts+1)
-		reqdSectors = syncLoop(opts, reqdSectors)
+		// peers that support batches can send every sector in one
+		// request. anything they don't send is requested one sector
+		// at a time.
+		if peerID, ok := nextBatchPeer(opts, batchTried); ok && len(reqdSectors) > 0 {
+			batchTried[peerID] = true
+			reqdSectors = batchSyncLoop(opts, reqdSectors, peerID)
+		}
+		if len(reqdSectors) > 0 {
+			reqdSectors = syncLoop(opts, reqdSectors)
+		}
 		remainingLen := len(reqdSectors)
 		l.Info(
 			"synced sectors",
@@ -180,6 +217,9 @@ func syncLoop(opts *SyncSectorsOpts, reqdSectors reqdSectorsMap) reqdSectorsMap
 					if sendCount == 7 {
 						break
 					}
+					if !opts.Mux.HasService(peerID, wire.ServiceBlobs) {
+						continue
+					}
 					err := opts.Mux.Send(peerID, &wire.SectorReq{
 						Name:     opts.Name,
 						SectorID: id,
@@ -259,6 +299,105 @@ sectorLoop:
 	return outReqdSectors
 }
 
+func nextBatchPeer(opts *SyncSectorsOpts, tried map[crypto.Hash]bool) (crypto.Hash, bool) {
+	iter := opts.Peers.Iterator()
+	for {
+		peerID, ok := iter()
+		if !ok {
+			return crypto.ZeroHash, false
+		}
+		if tried[peerID] {
+			continue
+		}
+		if opts.Mux.HasService(peerID, wire.ServiceBlobs) && opts.Mux.HasService(peerID, wire.ServiceSectorBatch) {
+			return peerID, true
+		}
+	}
+}
+
+// batchSyncLoop requests every needed sector from peerID in a single
+// SectorBatchReq, and acknowledges each SectorBatchRes as it is
+// processed. It returns the sectors that weren't received.
+func batchSyncLoop(opts *SyncSectorsOpts, reqdSectors reqdSectorsMap, peerID crypto.Hash) reqdSectorsMap {
+	lgr := log.WithModule("sync-loop").Sub("name", opts.Name, "peer_id", peerID)
+
+	outReqdSectors := make(reqdSectorsMap)
+	var sectors wire.SectorBitmap
+	for id, hash := range reqdSectors {
+		outReqdSectors[id] = hash
+		sectors.Set(id)
+	}
+	window := opts.BatchWindow
+	if window == 0 {
+		window = DefaultSyncerBatchWindow
+	}
+
+	resCh := make(chan *wire.SectorBatchRes, window)
+	unsubRes := opts.Mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeSectorBatchRes, func(recvPeerID crypto.Hash, envelope *wire.Envelope) {
+		msg := envelope.Message.(*wire.SectorBatchRes)
+		if recvPeerID != peerID || msg.Name != opts.Name {
+			return
+		}
+		select {
+		case resCh <- msg:
+		default:
+			lgr.Warn("peer exceeded sector batch window")
+		}
+	}))
+	defer unsubRes()
+
+	err := opts.Mux.Send(peerID, &wire.SectorBatchReq{
+		Name:    opts.Name,
+		Sectors: sectors,
+		Window:  window,
+	})
+	if err != nil {
+		lgr.Warn("error requesting sector batch", "err", err)
+		return outReqdSectors
+	}
+	lgr.Debug("requested sector batch", "sector_count", len(reqdSectors))
+
+	timeout := time.NewTimer(opts.Timeout)
+	defer timeout.Stop()
+	for len(outReqdSectors) > 0 {
+		select {
+		case msg := <-resCh:
+			for _, sector := range msg.Sectors {
+				expHash, ok := outReqdSectors[sector.ID]
+				if !ok {
+					lgr.Trace("received unnecessary sector", "sector_id", sector.ID)
+					continue
+				}
+				if expHash != awaitingSectorHash(sector.ID, blob.HashSector(sector.Sector)) {
+					lgr.Warn("invalid sector received", "sector_id", sector.ID)
+					continue
+				}
+				if err := opts.Tx.WriteSector(sector.ID, sector.Sector); err != nil {
+					lgr.Error("failed to write sector", "sector_id", sector.ID, "err", err)
+					continue
+				}
+				delete(outReqdSectors, sector.ID)
+			}
+			if !timeout.Stop() {
+				<-timeout.C
+			}
+			timeout.Reset(opts.Timeout)
+			if len(outReqdSectors) == 0 {
+				break
+			}
+			if err := opts.Mux.Send(peerID, &wire.SectorBatchAck{Name: opts.Name}); err != nil {
+				lgr.Warn("error acknowledging sector batch", "err", err)
+				return outReqdSectors
+			}
+		case <-timeout.C:
+			lgr.Warn("sector batch timed out", "remaining", len(outReqdSectors))
+			return outReqdSectors
+		}
+	}
+	lgr.Debug("synced sector batch", "sector_count", len(reqdSectors))
+	return outReqdSectors
+}
+
 func awaitingSectorHash(id uint8, hash crypto.Hash) [33]byte {
 	var buf [33]byte
 	buf[0] = id
@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fnd/blob"
 	"fnd/crypto"
+	"fnd/store"
 	"fnd/testutil/mockapp"
 	"fnd/util"
+	"fnd/wire"
 	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -67,6 +71,32 @@ func TestSyncTreeBases(t *testing.T) {
 				}
 			},
 		},
+		{
+			"skips relay-only peers",
+			func(t *testing.T, setup *syncTreeBasesSetup) {
+				ts := time.Now()
+				update := mockapp.FillBlobRandom(
+					t,
+					setup.rs.DB,
+					setup.rs.BlobStore,
+					setup.tp.RemoteSigner,
+					name,
+					ts,
+					ts,
+				)
+				setup.tp.LocalPeer.SetCapabilities(wire.ServiceUpdateInv, nil)
+
+				_, err := SyncTreeBases(&SyncTreeBasesOpts{
+					Mux: setup.tp.LocalMux,
+					Peers: NewPeerSet([]crypto.Hash{
+						crypto.HashPub(setup.tp.RemoteSigner.Pub()),
+					}),
+					MerkleRoot: update.MerkleRoot,
+					Name:       name,
+				})
+				require.True(t, errors.Is(err, ErrNoTreeBaseCandidates))
+			},
+		},
 		{
 			"aborts sync if all peers return invalid merkle bases",
 			func(t *testing.T, setup *syncTreeBasesSetup) {
@@ -205,3 +235,153 @@ func TestSyncTreeBases(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncSectors_Batch(t *testing.T) {
+	tp, peersDone := mockapp.ConnectTestPeers(t)
+	defer peersDone()
+	remoteStorage, remoteStorageDone := mockapp.CreateStorage(t)
+	defer remoteStorageDone()
+	localStorage, localStorageDone := mockapp.CreateStorage(t)
+	defer localStorageDone()
+	remoteSS := NewSectorServer(tp.RemoteMux, remoteStorage.DB, remoteStorage.BlobStore, util.NewMultiLocker())
+	require.NoError(t, remoteSS.Start())
+	defer require.NoError(t, remoteSS.Stop())
+
+	var reqCount int32
+	var batchReqCount int32
+	tp.RemoteMux.AddMessageHandler(func(peerID crypto.Hash, envelope *wire.Envelope) {
+		switch envelope.MessageType {
+		case wire.MessageTypeSectorReq:
+			atomic.AddInt32(&reqCount, 1)
+		case wire.MessageTypeSectorBatchReq:
+			atomic.AddInt32(&batchReqCount, 1)
+		}
+	})
+	tp.LocalPeer.SetCapabilities(wire.LegacyServices|wire.ServiceSectorBatch, nil)
+
+	name := "foobar"
+	ts := time.Now()
+	mockapp.FillBlobRandom(
+		t,
+		remoteStorage.DB,
+		remoteStorage.BlobStore,
+		tp.RemoteSigner,
+		name,
+		ts,
+		ts,
+	)
+	merkleBase, err := store.GetMerkleBase(remoteStorage.DB, name)
+	require.NoError(t, err)
+
+	bl, err := localStorage.BlobStore.Open(name)
+	require.NoError(t, err)
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	require.NoError(t, err)
+	var sectorsNeeded []uint8
+	for i := 0; i < blob.SectorCount; i++ {
+		sectorsNeeded = append(sectorsNeeded, uint8(i))
+	}
+	require.NoError(t, SyncSectors(&SyncSectorsOpts{
+		Timeout:       time.Second,
+		BatchWindow:   1,
+		Mux:           tp.LocalMux,
+		Tx:            tx,
+		Peers:         NewPeerSet([]crypto.Hash{crypto.HashPub(tp.RemoteSigner.Pub())}),
+		MerkleBase:    merkleBase,
+		SectorsNeeded: sectorsNeeded,
+		Name:          name,
+	}))
+	require.EqualValues(t, 1, atomic.LoadInt32(&batchReqCount))
+	require.EqualValues(t, 0, atomic.LoadInt32(&reqCount))
+
+	remoteBl, err := remoteStorage.BlobStore.Open(name)
+	require.NoError(t, err)
+	defer remoteBl.Close()
+	for _, id := range sectorsNeeded {
+		expected, err := remoteBl.ReadSector(id)
+		require.NoError(t, err)
+		actual, err := tx.ReadSector(id)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	require.NoError(t, tx.Rollback())
+}
+
+func TestSyncSectors_Blocked(t *testing.T) {
+	tp, peersDone := mockapp.ConnectTestPeers(t)
+	defer peersDone()
+	remoteStorage, remoteStorageDone := mockapp.CreateStorage(t)
+	defer remoteStorageDone()
+	localStorage, localStorageDone := mockapp.CreateStorage(t)
+	defer localStorageDone()
+	remoteSS := NewSectorServer(tp.RemoteMux, remoteStorage.DB, remoteStorage.BlobStore, util.NewMultiLocker())
+	require.NoError(t, remoteSS.Start())
+	defer require.NoError(t, remoteSS.Stop())
+	tp.LocalPeer.SetCapabilities(wire.LegacyServices|wire.ServiceSectorBatch, nil)
+
+	name := "foobar"
+	ts := time.Now()
+	mockapp.FillBlobRandom(
+		t,
+		remoteStorage.DB,
+		remoteStorage.BlobStore,
+		tp.RemoteSigner,
+		name,
+		ts,
+		ts,
+	)
+	merkleBase, err := store.GetMerkleBase(remoteStorage.DB, name)
+	require.NoError(t, err)
+	blockSector := func(db *leveldb.DB, hash crypto.Hash) {
+		require.NoError(t, store.WithTx(db, func(tx *leveldb.Transaction) error {
+			return store.SetSectorBlock(tx, &store.SectorBlock{
+				Hash:    hash,
+				Sources: []string{store.LocalBanSource},
+			})
+		}))
+	}
+	blockSector(remoteStorage.DB, merkleBase[7])
+	blockSector(localStorage.DB, merkleBase[7])
+
+	bl, err := localStorage.BlobStore.Open(name)
+	require.NoError(t, err)
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	require.NoError(t, err)
+	var sectorsNeeded []uint8
+	for i := 0; i < blob.SectorCount; i++ {
+		sectorsNeeded = append(sectorsNeeded, uint8(i))
+	}
+	opts := &SyncSectorsOpts{
+		Timeout:       time.Second,
+		Mux:           tp.LocalMux,
+		Tx:            tx,
+		Peers:         NewPeerSet([]crypto.Hash{crypto.HashPub(tp.RemoteSigner.Pub())}),
+		MerkleBase:    merkleBase,
+		SectorsNeeded: sectorsNeeded,
+		Name:          name,
+		DB:            localStorage.DB,
+	}
+	require.NoError(t, SyncSectors(opts))
+
+	remoteBl, err := remoteStorage.BlobStore.Open(name)
+	require.NoError(t, err)
+	defer remoteBl.Close()
+	for _, id := range sectorsNeeded {
+		expected, err := remoteBl.ReadSector(id)
+		require.NoError(t, err)
+		if id == 7 {
+			expected = blob.ZeroSector
+		}
+		actual, err := tx.ReadSector(id)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	// the remote refuses to serve the blocked sector
+	opts.DB = nil
+	opts.SectorsNeeded = []uint8{7}
+	require.Equal(t, ErrSyncerNoProgress, SyncSectors(opts))
+	require.NoError(t, tx.Rollback())
+}
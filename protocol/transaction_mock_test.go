@@ -39,6 +39,11 @@ func (t *TransactionMock) Truncate() error {
 	return args.Error(0)
 }
 
+func (t *TransactionMock) DirtySectors() ([]uint8, bool) {
+	args := t.Called()
+	return args.Get(0).([]uint8), args.Bool(1)
+}
+
 func (t *TransactionMock) Commit() error {
 	args := t.Called()
 	return args.Error(0)
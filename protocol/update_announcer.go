@@ -0,0 +1,236 @@
+package protocol
+
+import (
+	"fnd.localhost/handshake/primitives"
+	"fnd/config"
+	"fnd/crypto"
+	"fnd/log"
+	"fnd/p2p"
+	"fnd/store"
+	"fnd/util"
+	"fnd/wire"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+	"time"
+)
+
+// UpdateAnnouncer gossips updates as batched inventories of
+// (name, timestamp, hash) entries. Peers request the full update
+// only when they don't already have it. Peers that don't support
+// inventories are sent full updates instead.
+type UpdateAnnouncer struct {
+	BatchInterval  time.Duration
+	MaxBatchSize   int
+	RequestTimeout time.Duration
+	mux            *p2p.PeerMuxer
+	db             *leveldb.DB
+	pending        map[string]*wire.Update
+	requested      *util.Cache
+	flushCh        chan struct{}
+	quitCh         chan struct{}
+	readyCh        chan struct{}
+	mu             sync.Mutex
+	lgr            log.Logger
+}
+
+func NewUpdateAnnouncer(mux *p2p.PeerMuxer, db *leveldb.DB) *UpdateAnnouncer {
+	return &UpdateAnnouncer{
+		BatchInterval:  config.ConvertDuration(config.DefaultConfig.Tuning.UpdateAnnouncer.BatchIntervalMS, time.Millisecond),
+		MaxBatchSize:   config.DefaultConfig.Tuning.UpdateAnnouncer.MaxBatchSize,
+		RequestTimeout: config.ConvertDuration(config.DefaultConfig.Tuning.UpdateAnnouncer.RequestTimeoutMS, time.Millisecond),
+		mux:            mux,
+		db:             db,
+		pending:        make(map[string]*wire.Update),
+		requested:      util.NewCache(),
+		flushCh:        make(chan struct{}, 1),
+		quitCh:         make(chan struct{}),
+		readyCh:        make(chan struct{}),
+		lgr:            log.WithModule("update-announcer"),
+	}
+}
+
+func (u *UpdateAnnouncer) Start() error {
+	u.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeUpdateInv, u.onUpdateInv))
+	close(u.readyCh)
+
+	tick := time.NewTicker(u.BatchInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			u.Flush()
+		case <-u.flushCh:
+			u.Flush()
+		case <-u.quitCh:
+			return nil
+		}
+	}
+}
+
+// Ready is closed once the announcer's message handler is registered.
+func (u *UpdateAnnouncer) Ready() <-chan struct{} {
+	return u.readyCh
+}
+
+func (u *UpdateAnnouncer) Stop() error {
+	close(u.quitCh)
+	return nil
+}
+
+func (u *UpdateAnnouncer) Health() error {
+	return nil
+}
+
+// Announce queues update for the next batch, and returns the peers
+// that don't know about it yet. Only the newest pending update for
+// each name is announced.
+func (u *UpdateAnnouncer) Announce(update *wire.Update) []crypto.Hash {
+	u.mu.Lock()
+	existing := u.pending[update.Name]
+	if existing == nil || existing.Timestamp.Before(update.Timestamp) {
+		u.pending[update.Name] = update
+	}
+	full := len(u.pending) >= u.MaxBatchSize
+	u.mu.Unlock()
+
+	if full {
+		select {
+		case u.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return u.mux.GossipPeerIDs(update)
+}
+
+// Flush announces all pending updates immediately.
+func (u *UpdateAnnouncer) Flush() {
+	u.mu.Lock()
+	if len(u.pending) == 0 {
+		u.mu.Unlock()
+		return
+	}
+	pending := u.pending
+	u.pending = make(map[string]*wire.Update)
+	u.mu.Unlock()
+
+	var updates []*wire.Update
+	var hashes []crypto.Hash
+	for _, update := range pending {
+		hash, err := update.Hash()
+		if err != nil {
+			u.lgr.Error("error hashing update", "name", update.Name, "err", err)
+			continue
+		}
+		updates = append(updates, update)
+		hashes = append(hashes, hash)
+	}
+
+	var recipCount int
+	for _, peerID := range u.mux.PeerIDs() {
+		var entries []*wire.UpdateInvEntry
+		var legacy []*wire.Update
+		for i, update := range updates {
+			if u.mux.HasGossiped(peerID, hashes[i]) {
+				continue
+			}
+			u.mux.MarkGossiped(peerID, hashes[i])
+			entries = append(entries, &wire.UpdateInvEntry{
+				Name:      update.Name,
+				Timestamp: update.Timestamp,
+				Hash:      hashes[i],
+			})
+			legacy = append(legacy, update)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		recipCount++
+
+		if !u.mux.HasService(peerID, wire.ServiceUpdateInv) {
+			for _, update := range legacy {
+				if err := u.mux.Send(peerID, update); err != nil {
+					u.lgr.Warn("error sending update", "peer_id", peerID, "name", update.Name, "err", err)
+					break
+				}
+			}
+			continue
+		}
+
+		for len(entries) > 0 {
+			n := len(entries)
+			if n > wire.MaxUpdateInvEntries {
+				n = wire.MaxUpdateInvEntries
+			}
+			if err := u.mux.Send(peerID, &wire.UpdateInv{Entries: entries[:n]}); err != nil {
+				u.lgr.Warn("error sending update inventory", "peer_id", peerID, "err", err)
+				break
+			}
+			entries = entries[n:]
+		}
+	}
+
+	u.lgr.Debug("announced updates", "update_count", len(updates), "recipient_count", recipCount)
+}
+
+func (u *UpdateAnnouncer) onUpdateInv(peerID crypto.Hash, envelope *wire.Envelope) {
+	msg := envelope.Message.(*wire.UpdateInv)
+	initialImportComplete, err := store.GetInitialImportComplete(u.db)
+	if err != nil {
+		u.lgr.Error("error getting initial import complete", "err", err)
+		return
+	}
+
+	for _, entry := range msg.Entries {
+		// the sender already has this update, so don't announce it
+		// back to them.
+		u.mux.MarkGossiped(peerID, entry.Hash)
+		if !initialImportComplete {
+			continue
+		}
+
+		storedTimestamp, err := u.storedTimestamp(entry.Name)
+		if err != nil {
+			u.lgr.Debug("ignoring announced update", "name", entry.Name, "reason", err)
+			continue
+		}
+		if !storedTimestamp.Before(entry.Timestamp) {
+			continue
+		}
+		if u.requested.Has(entry.Hash.String()) {
+			continue
+		}
+
+		err = u.mux.Send(peerID, &wire.UpdateReq{
+			Name:      entry.Name,
+			Timestamp: storedTimestamp,
+		})
+		if err != nil {
+			u.lgr.Warn("error requesting announced update", "peer_id", peerID, "name", entry.Name, "err", err)
+			return
+		}
+		u.requested.Set(entry.Hash.String(), true, int64(u.RequestTimeout/time.Millisecond))
+		u.lgr.Debug("requested announced update", "peer_id", peerID, "name", entry.Name)
+	}
+}
+
+func (u *UpdateAnnouncer) storedTimestamp(name string) (time.Time, error) {
+	if err := primitives.ValidateName(name); err != nil {
+		return time.Time{}, errors.Wrap(err, "name is invalid")
+	}
+	banned, err := store.NameIsBanned(u.db, name)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error reading name ban state")
+	}
+	if banned {
+		return time.Time{}, errors.New("name is banned")
+	}
+	header, err := store.GetHeader(u.db, name)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return time.Unix(0, 0), nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error getting name header")
+	}
+	return header.Timestamp, nil
+}
@@ -0,0 +1,157 @@
+package protocol
+
+import (
+	"fnd/blob"
+	"fnd/crypto"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"testing"
+	"time"
+)
+
+func TestUpdateAnnouncer_Announce(t *testing.T) {
+	tp, done := mockapp.ConnectTestPeers(t)
+	defer done()
+	db, dbDone := setupDB(t)
+	defer dbDone()
+
+	msgCh := make(chan *wire.Envelope, 10)
+	unsub := tp.RemoteMux.AddMessageHandler(func(id crypto.Hash, envelope *wire.Envelope) {
+		msgCh <- envelope
+	})
+	defer unsub()
+
+	announcer := NewUpdateAnnouncer(tp.LocalMux, db)
+	remotePeerID := crypto.HashPub(tp.RemoteSigner.Pub())
+	update := &wire.Update{
+		Name:      "testname",
+		Timestamp: time.Unix(10, 0),
+	}
+
+	// peers without inventory support are sent the full update
+	require.Equal(t, []crypto.Hash{remotePeerID}, announcer.Announce(update))
+	announcer.Flush()
+	envelope := requireEnvelope(t, msgCh)
+	require.Equal(t, wire.MessageTypeUpdate, envelope.MessageType)
+	require.True(t, update.Equals(envelope.Message))
+
+	// updates are only announced once per peer
+	require.Empty(t, announcer.Announce(update))
+	announcer.Flush()
+	requireNoEnvelope(t, msgCh)
+
+	tp.LocalPeer.SetCapabilities(wire.ServiceUpdateInv, nil)
+	older := &wire.Update{
+		Name:      "othername",
+		Timestamp: time.Unix(20, 0),
+	}
+	newer := &wire.Update{
+		Name:      "othername",
+		Timestamp: time.Unix(30, 0),
+	}
+	announcer.Announce(newer)
+	announcer.Announce(older)
+	announcer.Flush()
+	envelope = requireEnvelope(t, msgCh)
+	require.Equal(t, wire.MessageTypeUpdateInv, envelope.MessageType)
+	newerHash, err := newer.Hash()
+	require.NoError(t, err)
+	require.True(t, envelope.Message.Equals(&wire.UpdateInv{
+		Entries: []*wire.UpdateInvEntry{
+			{
+				Name:      "othername",
+				Timestamp: newer.Timestamp,
+				Hash:      newerHash,
+			},
+		},
+	}))
+}
+
+func TestUpdateAnnouncer_RequestsUnknownUpdates(t *testing.T) {
+	tp, done := mockapp.ConnectTestPeers(t)
+	defer done()
+	db, dbDone := setupDB(t)
+	defer dbDone()
+
+	require.NoError(t, store.WithTx(db, func(tx *leveldb.Transaction) error {
+		if err := store.SetInitialImportCompleteTx(tx); err != nil {
+			return err
+		}
+		return store.SetHeaderTx(tx, &store.Header{
+			Name:      "knownname",
+			Timestamp: time.Unix(20, 0),
+		}, blob.MakeTreeFromBase(blob.ZeroMerkleBase))
+	}))
+
+	msgCh := make(chan *wire.Envelope, 10)
+	unsub := tp.RemoteMux.AddMessageHandler(func(id crypto.Hash, envelope *wire.Envelope) {
+		msgCh <- envelope
+	})
+	defer unsub()
+
+	announcer := NewUpdateAnnouncer(tp.LocalMux, db)
+	remotePeerID := crypto.HashPub(tp.RemoteSigner.Pub())
+	inv := &wire.UpdateInv{
+		Entries: []*wire.UpdateInvEntry{
+			{
+				Name:      "knownname",
+				Timestamp: time.Unix(10, 0),
+				Hash:      crypto.Rand32(),
+			},
+			{
+				Name:      "knownname",
+				Timestamp: time.Unix(30, 0),
+				Hash:      crypto.Rand32(),
+			},
+			{
+				Name:      "unknownname",
+				Timestamp: time.Unix(30, 0),
+				Hash:      crypto.Rand32(),
+			},
+		},
+	}
+	invEnvelope := &wire.Envelope{
+		MessageType: wire.MessageTypeUpdateInv,
+		Message:     inv,
+	}
+	announcer.onUpdateInv(remotePeerID, invEnvelope)
+
+	envelope := requireEnvelope(t, msgCh)
+	require.True(t, envelope.Message.Equals(&wire.UpdateReq{
+		Name:      "knownname",
+		Timestamp: time.Unix(20, 0),
+	}))
+	envelope = requireEnvelope(t, msgCh)
+	require.True(t, envelope.Message.Equals(&wire.UpdateReq{
+		Name:      "unknownname",
+		Timestamp: time.Unix(0, 0),
+	}))
+	for _, entry := range inv.Entries {
+		require.True(t, tp.LocalMux.HasGossiped(remotePeerID, entry.Hash))
+	}
+
+	// in-flight requests aren't repeated
+	announcer.onUpdateInv(remotePeerID, invEnvelope)
+	requireNoEnvelope(t, msgCh)
+}
+
+func requireEnvelope(t *testing.T, ch chan *wire.Envelope) *wire.Envelope {
+	select {
+	case envelope := <-ch:
+		return envelope
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func requireNoEnvelope(t *testing.T, ch chan *wire.Envelope) {
+	select {
+	case envelope := <-ch:
+		t.Fatalf("unexpected message %s", envelope.MessageType)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
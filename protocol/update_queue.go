@@ -34,6 +34,7 @@ type UpdateQueue struct {
 	db                *leveldb.DB
 	entries           map[string]*UpdateQueueItem
 	quitCh            chan struct{}
+	readyCh           chan struct{}
 	queue             []string
 	queueLen          int32
 	mu                sync.Mutex
@@ -49,7 +50,10 @@ type UpdateQueueItem struct {
 	Signature    crypto.Signature
 	Pub          *btcec.PublicKey
 	Height       int
-	Disposed     int32
+	// Repair is set if the item re-syncs the corrupted content of the
+	// stored header rather than applying a new update.
+	Repair   bool
+	Disposed int32
 }
 
 func (u *UpdateQueueItem) Dispose() {
@@ -64,12 +68,14 @@ func NewUpdateQueue(mux *p2p.PeerMuxer, db *leveldb.DB) *UpdateQueue {
 		db:                db,
 		entries:           make(map[string]*UpdateQueueItem),
 		quitCh:            make(chan struct{}),
+		readyCh:           make(chan struct{}),
 		lgr:               log.WithModule("update-queue"),
 	}
 }
 
 func (u *UpdateQueue) Start() error {
 	u.mux.AddMessageHandler(p2p.PeerMessageHandlerForType(wire.MessageTypeUpdate, u.onUpdate))
+	close(u.readyCh)
 	timer := time.NewTicker(5 * time.Second)
 	for {
 		select {
@@ -81,11 +87,20 @@ func (u *UpdateQueue) Start() error {
 	}
 }
 
+// Ready is closed once the queue's message handler is registered.
+func (u *UpdateQueue) Ready() <-chan struct{} {
+	return u.readyCh
+}
+
 func (u *UpdateQueue) Stop() error {
 	close(u.quitCh)
 	return nil
 }
 
+func (u *UpdateQueue) Health() error {
+	return nil
+}
+
 func (u *UpdateQueue) Enqueue(peerID crypto.Hash, update *wire.Update) error {
 	// use atomic below to prevent having to lock mu
 	// during expensive name validation calls when
@@ -102,7 +117,7 @@ func (u *UpdateQueue) Enqueue(peerID crypto.Hash, update *wire.Update) error {
 		return ErrInitialImportIncomplete
 	}
 
-	nameInfo, err := u.validateUpdate(update.Name, update.Timestamp, update.MerkleRoot, update.ReservedRoot, update.Signature)
+	nameInfo, err := validateUpdate(u.db, update.Name, update.Timestamp, update.MerkleRoot, update.ReservedRoot, update.Signature)
 	if err != nil {
 		return errors.Wrap(err, "name failed validation")
 	}
@@ -165,6 +180,48 @@ func (u *UpdateQueue) Enqueue(peerID crypto.Hash, update *wire.Update) error {
 	return nil
 }
 
+// EnqueueRepair queues the stored header for name to be re-synced from
+// the given peers, replacing any content that does not match it.
+// Pending updates for the name take precedence, since they replace
+// the content anyway.
+func (u *UpdateQueue) EnqueueRepair(name string, peerIDs []crypto.Hash) error {
+	if len(peerIDs) == 0 {
+		return errors.New("no peers to repair from")
+	}
+	if atomic.LoadInt32(&u.queueLen) >= u.MaxLen {
+		return ErrUpdateQueueMaxLen
+	}
+	header, err := store.GetHeader(u.db, name)
+	if err != nil {
+		return errors.Wrap(err, "error getting name header")
+	}
+	nameInfo, err := store.GetNameInfo(u.db, name)
+	if err != nil {
+		return errors.Wrap(err, "error reading name info")
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.entries[name]; ok {
+		return nil
+	}
+	u.entries[name] = &UpdateQueueItem{
+		PeerIDs:      NewPeerSet(peerIDs),
+		Name:         name,
+		Timestamp:    header.Timestamp,
+		MerkleRoot:   header.MerkleRoot,
+		ReservedRoot: header.ReservedRoot,
+		Signature:    header.Signature,
+		Pub:          nameInfo.PublicKey,
+		Height:       nameInfo.ImportHeight,
+		Repair:       true,
+	}
+	u.queue = append(u.queue, name)
+	atomic.AddInt32(&u.queueLen, 1)
+	u.lgr.Info("enqueued repair", "name", name, "timestamp", header.Timestamp)
+	return nil
+}
+
 func (u *UpdateQueue) Dequeue() *UpdateQueueItem {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -179,18 +236,18 @@ func (u *UpdateQueue) Dequeue() *UpdateQueueItem {
 	return ret
 }
 
-func (u *UpdateQueue) validateUpdate(name string, ts time.Time, mr crypto.Hash, rr crypto.Hash, sig crypto.Signature) (*store.NameInfo, error) {
+func validateUpdate(db *leveldb.DB, name string, ts time.Time, mr crypto.Hash, rr crypto.Hash, sig crypto.Signature) (*store.NameInfo, error) {
 	if err := primitives.ValidateName(name); err != nil {
 		return nil, errors.Wrap(err, "update name is invalid")
 	}
-	banned, err := store.NameIsBanned(u.db, name)
+	banned, err := store.NameIsBanned(db, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading name ban state")
 	}
 	if banned {
 		return nil, errors.New("name is banned")
 	}
-	info, err := store.GetNameInfo(u.db, name)
+	info, err := store.GetNameInfo(db, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading name info")
 	}
@@ -45,7 +45,7 @@ func TestUpdateQueue_Enqueue_InvalidBeforeEnqueue(t *testing.T) {
 		if err := store.SetInitialImportCompleteTx(tx); err != nil {
 			return err
 		}
-		if err := store.BanName(tx, "banned"); err != nil {
+		if err := store.BanName(tx, "banned", store.LocalBanSource); err != nil {
 			return err
 		}
 		if err := store.SetNameInfoTx(tx, "banned", pub, 10); err != nil {
@@ -55,7 +55,7 @@ func TestUpdateQueue_Enqueue_InvalidBeforeEnqueue(t *testing.T) {
 			if err := store.SetNameInfoTx(tx, header.Name, pub, 10); err != nil {
 				return err
 			}
-			if err := store.SetHeaderTx(tx, header, blob.ZeroMerkleBase); err != nil {
+			if err := store.SetHeaderTx(tx, header, blob.MakeTreeFromBase(blob.ZeroMerkleBase)); err != nil {
 				return err
 			}
 		}
@@ -162,7 +162,7 @@ func TestUpdateQueue_Enqueue_InvalidAfterEnqueue(t *testing.T) {
 		if err := store.SetNameInfoTx(tx, header.Name, pub, 10); err != nil {
 			return err
 		}
-		if err := store.SetHeaderTx(tx, header, blob.ZeroMerkleBase); err != nil {
+		if err := store.SetHeaderTx(tx, header, blob.MakeTreeFromBase(blob.ZeroMerkleBase)); err != nil {
 			return err
 		}
 		return nil
@@ -202,7 +202,7 @@ func TestUpdateQueue_EnqueueDequeue(t *testing.T) {
 		if err := store.SetNameInfoTx(tx, header.Name, pub, 10); err != nil {
 			return err
 		}
-		if err := store.SetHeaderTx(tx, header, blob.ZeroMerkleBase); err != nil {
+		if err := store.SetHeaderTx(tx, header, blob.MakeTreeFromBase(blob.ZeroMerkleBase)); err != nil {
 			return err
 		}
 		return nil
@@ -36,6 +36,10 @@ func (u *UpdateServer) Stop() error {
 	return nil
 }
 
+func (u *UpdateServer) Health() error {
+	return nil
+}
+
 func (u *UpdateServer) UpdateReqHandler(peerID crypto.Hash, envelope *wire.Envelope) {
 	msg := envelope.Message.(*wire.UpdateReq)
 	u.lgr.Debug("receive update req", "name", msg.Name, "ts", msg.Timestamp)
@@ -79,10 +83,11 @@ func (u *UpdateServer) UpdateReqHandler(peerID crypto.Hash, envelope *wire.Envel
 	}
 
 	err = u.mux.Send(peerID, &wire.Update{
-		Name:       msg.Name,
-		Timestamp:  header.Timestamp,
-		MerkleRoot: header.MerkleRoot,
-		Signature:  header.Signature,
+		Name:         msg.Name,
+		Timestamp:    header.Timestamp,
+		MerkleRoot:   header.MerkleRoot,
+		ReservedRoot: header.ReservedRoot,
+		Signature:    header.Signature,
 	})
 	if err != nil {
 		u.lgr.Error("error serving update", "name", msg.Name, "err", err)
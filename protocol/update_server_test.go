@@ -72,7 +72,7 @@ func TestUpdateServer(t *testing.T) {
 					return store.SetHeaderTx(tx, &store.Header{
 						Name:      "future",
 						Timestamp: time.Unix(5, 0),
-					}, blob.ZeroMerkleBase)
+					}, blob.MakeTreeFromBase(blob.ZeroMerkleBase))
 				}))
 			},
 			func(t *testing.T) {
@@ -90,7 +90,7 @@ func TestUpdateServer(t *testing.T) {
 					return store.SetHeaderTx(tx, &store.Header{
 						Name:      "equal",
 						Timestamp: time.Unix(10, 0),
-					}, blob.ZeroMerkleBase)
+					}, blob.MakeTreeFromBase(blob.ZeroMerkleBase))
 				}))
 			},
 			func(t *testing.T) {
@@ -114,7 +114,7 @@ func TestUpdateServer(t *testing.T) {
 						Timestamp:  ts,
 						MerkleRoot: tree.Root(),
 						Signature:  sig,
-					}, blob.ZeroMerkleBase)
+					}, tree)
 				}))
 			},
 			func(t *testing.T) {
@@ -3,6 +3,7 @@ package protocol
 import (
 	"fnd/blob"
 	"fnd/config"
+	"fnd/crypto"
 	"fnd/log"
 	"fnd/p2p"
 	"fnd/store"
@@ -19,35 +20,51 @@ var (
 	ErrUpdaterMerkleRootMismatch  = errors.New("updater merkle root mismatch")
 	ErrNameLocked                 = errors.New("name is locked")
 	ErrInsufficientTimebank       = errors.New("insufficient timebank")
+	ErrUpdaterRepairOutdated      = errors.New("repaired header is outdated")
 
 	updaterLogger = log.WithModule("updater")
+
+	updateTimebankParams = &TimebankParams{
+		TimebankDuration:     48 * time.Hour,
+		MinUpdateInterval:    2 * time.Minute,
+		FullUpdatesPerPeriod: 2,
+	}
 )
 
 type Updater struct {
 	PollInterval time.Duration
 	Workers      int
+	StallTimeout time.Duration
 	mux          *p2p.PeerMuxer
 	db           *leveldb.DB
 	queue        *UpdateQueue
+	announcer    *UpdateAnnouncer
 	nameLocker   util.MultiLocker
 	bs           blob.Store
 	obs          *util.Observable
+	processing   map[string]time.Time
 	quitCh       chan struct{}
+	readyCh      chan struct{}
 	wg           sync.WaitGroup
+	mu           sync.Mutex
 	lgr          log.Logger
 }
 
-func NewUpdater(mux *p2p.PeerMuxer, db *leveldb.DB, queue *UpdateQueue, nameLocker util.MultiLocker, bs blob.Store) *Updater {
+func NewUpdater(mux *p2p.PeerMuxer, db *leveldb.DB, queue *UpdateQueue, announcer *UpdateAnnouncer, nameLocker util.MultiLocker, bs blob.Store) *Updater {
 	return &Updater{
 		PollInterval: config.ConvertDuration(config.DefaultConfig.Tuning.Updater.PollIntervalMS, time.Millisecond),
 		Workers:      config.DefaultConfig.Tuning.Updater.Workers,
+		StallTimeout: config.ConvertDuration(config.DefaultConfig.Tuning.Updater.StallTimeoutMS, time.Millisecond),
 		mux:          mux,
 		db:           db,
 		queue:        queue,
+		announcer:    announcer,
 		nameLocker:   nameLocker,
 		bs:           bs,
 		obs:          util.NewObservable(),
+		processing:   make(map[string]time.Time),
 		quitCh:       make(chan struct{}),
+		readyCh:      make(chan struct{}),
 		lgr:          log.WithModule("updater"),
 	}
 }
@@ -57,16 +74,35 @@ func (u *Updater) Start() error {
 		u.wg.Add(1)
 		go u.runWorker()
 	}
+	close(u.readyCh)
 	u.wg.Wait()
 	return nil
 }
 
+// Ready is closed once the update workers are running.
+func (u *Updater) Ready() <-chan struct{} {
+	return u.readyCh
+}
+
 func (u *Updater) Stop() error {
 	close(u.quitCh)
 	u.wg.Wait()
 	return nil
 }
 
+// Health returns an error if any update has been processing for
+// longer than StallTimeout.
+func (u *Updater) Health() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for name, start := range u.processing {
+		if time.Since(start) > u.StallTimeout {
+			return errors.Errorf("updater stalled processing %s", name)
+		}
+	}
+	return nil
+}
+
 func (u *Updater) OnUpdateProcessed(hdlr func(item *UpdateQueueItem, err error)) util.Unsubscriber {
 	return u.obs.On("update:processed", hdlr)
 }
@@ -85,12 +121,20 @@ func (u *Updater) runWorker() {
 
 			cfg := &UpdateConfig{
 				Mux:        u.mux,
+				Announcer:  u.announcer,
 				DB:         u.db,
 				NameLocker: u.nameLocker,
 				BlobStore:  u.bs,
 				Item:       item,
 			}
-			if err := UpdateBlob(cfg); err != nil {
+			u.mu.Lock()
+			u.processing[item.Name] = time.Now()
+			u.mu.Unlock()
+			err := UpdateBlob(cfg)
+			u.mu.Lock()
+			delete(u.processing, item.Name)
+			u.mu.Unlock()
+			if err != nil {
 				u.obs.Emit("update:processed", item, err)
 				u.lgr.Error("error processing update", "name", item.Name, "err", err)
 				continue
@@ -104,7 +148,10 @@ func (u *Updater) runWorker() {
 }
 
 type UpdateConfig struct {
-	Mux        *p2p.PeerMuxer
+	Mux *p2p.PeerMuxer
+	// Announcer batches the gossip of processed updates. If it is
+	// nil, updates are gossiped to peers immediately.
+	Announcer  *UpdateAnnouncer
 	DB         *leveldb.DB
 	NameLocker util.MultiLocker
 	BlobStore  blob.Store
@@ -119,7 +166,11 @@ func UpdateBlob(cfg *UpdateConfig) error {
 	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		return errors.Wrap(err, "error getting header")
 	}
-	if header != nil && header.Timestamp.Equal(item.Timestamp) {
+	if item.Repair {
+		if header == nil || !header.Timestamp.Equal(item.Timestamp) {
+			return ErrUpdaterRepairOutdated
+		}
+	} else if header != nil && header.Timestamp.Equal(item.Timestamp) {
 		return ErrUpdaterAlreadySynchronized
 	}
 
@@ -150,17 +201,24 @@ func UpdateBlob(cfg *UpdateConfig) error {
 	}()
 
 	var sectorsNeeded []uint8
+	var prevTree blob.MerkleTree
 	var prevUpdateTime time.Time
 	var prevTimebank int
 	var payableSectorCount int
 	if header == nil {
 		sectorsNeeded = blob.ZeroMerkleBase.DiffWith(newMerkleBase)
 	} else {
-		base, err := store.GetMerkleBase(cfg.DB, item.Name)
+		if item.Repair {
+			// the stored tree describes what the blob should
+			// contain, so diff against what it does contain
+			prevTree, err = blob.Merkleize(blob.NewReader(bl))
+		} else {
+			prevTree, err = store.GetMerkleTree(cfg.DB, item.Name)
+		}
 		if err != nil {
-			return errors.Wrap(err, "error getting merkle base")
+			return errors.Wrap(err, "error getting merkle tree")
 		}
-		sectorsNeeded = base.DiffWith(newMerkleBase)
+		sectorsNeeded = prevTree.ProtocolBase().DiffWith(newMerkleBase)
 		prevUpdateTime = header.ReceivedAt
 		prevTimebank = header.Timebank
 	}
@@ -195,18 +253,20 @@ func UpdateBlob(cfg *UpdateConfig) error {
 		"payable", payableSectorCount,
 	)
 
-	newTimebank := CheckTimebank(&TimebankParams{
-		TimebankDuration:     48 * time.Hour,
-		MinUpdateInterval:    2 * time.Minute,
-		FullUpdatesPerPeriod: 2,
-	}, prevUpdateTime, prevTimebank, payableSectorCount)
-	l.Debug(
-		"calculated new timebank",
-		"prev", prevTimebank,
-		"new", newTimebank,
-	)
-	if newTimebank == -1 {
-		return ErrInsufficientTimebank
+	// repairs restore content the name already paid for
+	newTimebank := prevTimebank
+	receivedAt := prevUpdateTime
+	if !item.Repair {
+		newTimebank = CheckTimebank(updateTimebankParams, prevUpdateTime, prevTimebank, payableSectorCount)
+		l.Debug(
+			"calculated new timebank",
+			"prev", prevTimebank,
+			"new", newTimebank,
+		)
+		if newTimebank == -1 {
+			return ErrInsufficientTimebank
+		}
+		receivedAt = time.Now()
 	}
 
 	tx, err := bl.Transaction()
@@ -222,6 +282,7 @@ func UpdateBlob(cfg *UpdateConfig) error {
 		MerkleBase:    newMerkleBase,
 		SectorsNeeded: sectorsNeeded,
 		Name:          item.Name,
+		DB:            cfg.DB,
 	})
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -230,7 +291,35 @@ func UpdateBlob(cfg *UpdateConfig) error {
 		return errors.Wrap(err, "error during sync")
 	}
 
-	tree, err := blob.Merkleize(blob.NewReader(tx))
+	// blocked sectors are stored as zeros, so their leaves are
+	// restored from the synced merkle base
+	blockedIDs, err := BlockedSectorIDs(cfg.DB, newMerkleBase)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			updaterLogger.Error("error rolling back blob transaction", "err", err)
+		}
+		return errors.Wrap(err, "error checking sector blocklist")
+	}
+	blockedLeaves := make(map[uint8]crypto.Hash)
+	for _, id := range blockedIDs {
+		blockedLeaves[id] = newMerkleBase[id]
+	}
+	tree, err := blob.UpdateMerkleTree(prevTree, tx)
+	if err == nil {
+		tree.UpdateBase(blockedLeaves)
+	}
+	if err == nil && tree.Root() != item.MerkleRoot {
+		// the cached tree may be stale, so fall back to
+		// rehashing the whole blob
+		tree, err = blob.Merkleize(blob.NewReader(tx))
+		if err == nil && len(blockedLeaves) > 0 {
+			base := tree.ProtocolBase()
+			for id, hash := range blockedLeaves {
+				base[id] = hash
+			}
+			tree = blob.MakeTreeFromBase(base)
+		}
+	}
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			updaterLogger.Error("error rolling back blob transaction", "err", err)
@@ -251,9 +340,9 @@ func UpdateBlob(cfg *UpdateConfig) error {
 			MerkleRoot:   item.MerkleRoot,
 			Signature:    item.Signature,
 			ReservedRoot: item.ReservedRoot,
-			ReceivedAt:   time.Now(),
+			ReceivedAt:   receivedAt,
 			Timebank:     newTimebank,
-		}, tree.ProtocolBase())
+		}, tree)
 	})
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -262,6 +351,10 @@ func UpdateBlob(cfg *UpdateConfig) error {
 		return errors.Wrap(err, "error storing header")
 	}
 	tx.Commit()
+	if item.Repair {
+		l.Info("repaired corrupted blob", "sector_count", len(sectorsNeeded))
+		return nil
+	}
 
 	height, err := store.GetLastNameImportHeight(cfg.DB)
 	if err != nil {
@@ -280,6 +373,10 @@ func UpdateBlob(cfg *UpdateConfig) error {
 		Signature:    item.Signature,
 		ReservedRoot: item.ReservedRoot,
 	}
-	p2p.GossipAll(cfg.Mux, update)
+	if cfg.Announcer == nil {
+		p2p.GossipAll(cfg.Mux, update)
+		return nil
+	}
+	cfg.Announcer.Announce(update)
 	return nil
 }
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"errors"
+	"fnd/blob"
 	"fnd/crypto"
 	"fnd/p2p"
 	"fnd/store"
@@ -312,6 +313,61 @@ func TestUpdater(t *testing.T) {
 				}
 			},
 		},
+		{
+			"repairs corrupted sectors without charging the timebank",
+			func(t *testing.T, setup *updaterTestSetup) {
+				ts := time.Now()
+				receivedAt := ts.Add(-time.Minute)
+				update := mockapp.FillBlobRandom(
+					t,
+					setup.rs.DB,
+					setup.rs.BlobStore,
+					setup.tp.RemoteSigner,
+					name,
+					ts,
+					ts,
+				)
+				remoteBl, err := setup.rs.BlobStore.Open(name)
+				require.NoError(t, err)
+				mockapp.FillBlobReader(
+					t,
+					setup.ls.DB,
+					setup.ls.BlobStore,
+					setup.tp.RemoteSigner,
+					name,
+					ts,
+					receivedAt,
+					blob.NewReader(remoteBl),
+				)
+				require.NoError(t, remoteBl.Close())
+				corruptSector(t, setup.ls.BlobStore, name, 7)
+
+				cfg := &UpdateConfig{
+					Mux:        setup.tp.LocalMux,
+					DB:         setup.ls.DB,
+					NameLocker: util.NewMultiLocker(),
+					BlobStore:  setup.ls.BlobStore,
+					Item: &UpdateQueueItem{
+						PeerIDs: NewPeerSet([]crypto.Hash{
+							crypto.HashPub(setup.tp.RemoteSigner.Pub()),
+						}),
+						Name:         name,
+						Timestamp:    update.Timestamp,
+						MerkleRoot:   update.MerkleRoot,
+						ReservedRoot: update.ReservedRoot,
+						Signature:    update.Signature,
+						Pub:          setup.tp.RemoteSigner.Pub(),
+						Repair:       true,
+					},
+				}
+				require.NoError(t, UpdateBlob(cfg))
+				mockapp.RequireBlobsEqual(t, setup.ls.BlobStore, setup.rs.BlobStore, name)
+				header, err := store.GetHeader(setup.ls.DB, name)
+				require.NoError(t, err)
+				require.True(t, header.ReceivedAt.Equal(receivedAt))
+				require.Equal(t, 0, header.Timebank)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -333,3 +389,13 @@ func TestUpdater(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdater_Health(t *testing.T) {
+	updater := NewUpdater(nil, nil, nil, nil, nil, nil)
+	updater.StallTimeout = time.Minute
+	require.NoError(t, updater.Health())
+	updater.processing["foo"] = time.Now()
+	require.NoError(t, updater.Health())
+	updater.processing["foo"] = time.Now().Add(-2 * time.Minute)
+	require.Error(t, updater.Health())
+}
@@ -0,0 +1,117 @@
+package protocol
+
+import (
+	"fnd/blob"
+	"fnd/crypto"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// BlobVerification is the result of checking a stored blob against
+// its header.
+type BlobVerification struct {
+	Name string
+	// CorruptSectors lists the sectors whose content does not match
+	// the stored merkle base.
+	CorruptSectors []uint8
+	// RootMismatch is set if the blob's content does not hash to the
+	// header's merkle root.
+	RootMismatch bool
+	// BadSignature is set if the header is not signed by the name's
+	// current public key.
+	BadSignature bool
+	// Orphaned is set if the blob's name is no longer known.
+	Orphaned bool
+	// Banned is set if the blob's name is banned.
+	Banned bool
+	// RepairQueued is set if the blob was queued to be re-synced
+	// from peers.
+	RepairQueued bool
+}
+
+// Corrupt returns true if the blob's content needs to be re-synced.
+func (v *BlobVerification) Corrupt() bool {
+	return v.RootMismatch || len(v.CorruptSectors) > 0
+}
+
+// OK returns true if no problems were found.
+func (v *BlobVerification) OK() bool {
+	return !v.Corrupt() && !v.BadSignature && !v.Orphaned && !v.Banned
+}
+
+// VerifyBlob re-merkleizes the stored blob for name and checks it
+// against the name's header, stored merkle base, and current name
+// info. Blocked sectors are stored as ZeroSector, so their hashes are
+// taken from the stored merkle base. Callers should hold the name's
+// lock so that the blob is not updated while it is checked.
+func VerifyBlob(db *leveldb.DB, bs blob.Store, name string) (*BlobVerification, error) {
+	header, err := store.GetHeader(db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting header")
+	}
+	res := &BlobVerification{
+		Name: name,
+	}
+
+	banned, err := store.NameIsBanned(db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading name ban state")
+	}
+	if banned {
+		// the blobs of banned names are deleted, so there
+		// is no content to check
+		res.Banned = true
+		return res, nil
+	}
+
+	info, err := store.GetNameInfo(db, name)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		res.Orphaned = true
+	} else if err != nil {
+		return nil, errors.Wrap(err, "error getting name info")
+	} else {
+		h := blob.SealHash(name, header.Timestamp, header.MerkleRoot, header.ReservedRoot)
+		res.BadSignature = !crypto.VerifySigPub(info.PublicKey, header.Signature, h)
+	}
+
+	storedBase, err := store.GetMerkleBase(db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting merkle base")
+	}
+	actual, err := merkleizeStoredBlob(bs, name)
+	if err != nil {
+		return nil, err
+	}
+	blockedIDs, err := BlockedSectorIDs(db, storedBase)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking sector blocklist")
+	}
+	actualBase := actual.ProtocolBase()
+	for _, id := range blockedIDs {
+		actualBase[id] = storedBase[id]
+	}
+	res.CorruptSectors = storedBase.DiffWith(actualBase)
+	res.RootMismatch = blob.MakeTreeFromBase(actualBase).Root() != header.MerkleRoot
+	return res, nil
+}
+
+func merkleizeStoredBlob(bs blob.Store, name string) (blob.MerkleTree, error) {
+	exists, err := bs.Exists(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking blob existence")
+	}
+	if !exists {
+		return blob.EmptyBlobMerkleTree(), nil
+	}
+	bl, err := bs.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening blob")
+	}
+	defer bl.Close()
+	tree, err := blob.Merkleize(blob.NewReader(bl))
+	if err != nil {
+		return nil, errors.Wrap(err, "error merkleizing blob")
+	}
+	return tree, nil
+}
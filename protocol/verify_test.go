@@ -0,0 +1,101 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"fnd/blob"
+	"fnd/crypto"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/testutil/testcrypto"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"testing"
+	"time"
+)
+
+func TestVerifyBlob(t *testing.T) {
+	setup := func(t *testing.T, signer crypto.Signer) (*mockapp.TestStorage, func()) {
+		storage, done := mockapp.CreateStorage(t)
+		mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, "foo", time.Now(), time.Now())
+		return storage, done
+	}
+	setNameInfo := func(t *testing.T, db *leveldb.DB, signer crypto.Signer) {
+		require.NoError(t, store.WithTx(db, func(tx *leveldb.Transaction) error {
+			return store.SetNameInfoTx(tx, "foo", signer.Pub(), 10)
+		}))
+	}
+
+	t.Run("passes intact blobs", func(t *testing.T) {
+		signer := testcrypto.FixedSigner(t)
+		storage, done := setup(t, signer)
+		defer done()
+		setNameInfo(t, storage.DB, signer)
+
+		res, err := VerifyBlob(storage.DB, storage.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.OK())
+	})
+
+	t.Run("reports corrupted sectors", func(t *testing.T) {
+		signer := testcrypto.FixedSigner(t)
+		storage, done := setup(t, signer)
+		defer done()
+		setNameInfo(t, storage.DB, signer)
+		corruptSector(t, storage.BlobStore, "foo", 3)
+
+		res, err := VerifyBlob(storage.DB, storage.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.Corrupt())
+		require.True(t, res.RootMismatch)
+		require.Equal(t, []uint8{3}, res.CorruptSectors)
+		require.False(t, res.BadSignature)
+	})
+
+	t.Run("reports headers signed by another key", func(t *testing.T) {
+		storage, done := setup(t, testcrypto.FixedSigner(t))
+		defer done()
+		setNameInfo(t, storage.DB, testcrypto.NewRandomSigner())
+
+		res, err := VerifyBlob(storage.DB, storage.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.BadSignature)
+		require.False(t, res.Corrupt())
+	})
+
+	t.Run("reports orphaned blobs", func(t *testing.T) {
+		storage, done := setup(t, testcrypto.FixedSigner(t))
+		defer done()
+
+		res, err := VerifyBlob(storage.DB, storage.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.Orphaned)
+		require.False(t, res.OK())
+	})
+
+	t.Run("reports banned names", func(t *testing.T) {
+		signer := testcrypto.FixedSigner(t)
+		storage, done := setup(t, signer)
+		defer done()
+		setNameInfo(t, storage.DB, signer)
+		require.NoError(t, store.WithTx(storage.DB, func(tx *leveldb.Transaction) error {
+			return store.BanName(tx, "foo", store.LocalBanSource)
+		}))
+
+		res, err := VerifyBlob(storage.DB, storage.BlobStore, "foo")
+		require.NoError(t, err)
+		require.True(t, res.Banned)
+	})
+}
+
+func corruptSector(t *testing.T, bs blob.Store, name string, id uint8) {
+	bl, err := bs.Open(name)
+	require.NoError(t, err)
+	defer bl.Close()
+	tx, err := bl.Transaction()
+	require.NoError(t, err)
+	var sector blob.Sector
+	_, err = rand.Read(sector[:])
+	require.NoError(t, err)
+	require.NoError(t, tx.WriteSector(id, sector))
+	require.NoError(t, tx.Commit())
+}
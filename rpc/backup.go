@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"context"
+	apiv1 "fnd/rpc/v1"
+	"io"
+)
+
+// Backup streams an archive of the node to w. The archive can be
+// restored with backup.Restore.
+func Backup(client apiv1.Footnotev1Client, w io.Writer) error {
+	return BackupContext(context.Background(), client, w)
+}
+
+func BackupContext(ctx context.Context, client apiv1.Footnotev1Client, w io.Writer) error {
+	stream, err := client.Backup(ctx, &apiv1.Empty{})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(res.Data); err != nil {
+			return err
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/btcsuite/btcd/btcec"
 	"fnd/crypto"
+	"fnd/protocol"
 	apiv1 "fnd/rpc/v1"
 	"fnd/store"
 	"github.com/pkg/errors"
@@ -56,6 +57,58 @@ func ListBlobInfoContext(ctx context.Context, client apiv1.Footnotev1Client, sta
 	}
 }
 
+type VerifyBlobResult struct {
+	protocol.BlobVerification
+	// Skipped is set if the blob was not checked because it was
+	// being updated.
+	Skipped bool
+}
+
+func VerifyBlobs(client apiv1.Footnotev1Client, names []string, repair bool, cb func(res *VerifyBlobResult) bool) error {
+	return VerifyBlobsContext(context.Background(), client, names, repair, cb)
+}
+
+// VerifyBlobsContext checks the given stored blobs, or every stored
+// blob if names is empty. If repair is true, corrupted blobs are
+// queued to be re-synced from peers.
+func VerifyBlobsContext(ctx context.Context, client apiv1.Footnotev1Client, names []string, repair bool, cb func(res *VerifyBlobResult) bool) error {
+	stream, err := client.VerifyBlobs(ctx, &apiv1.VerifyBlobsReq{
+		Names:  names,
+		Repair: repair,
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		parsed := &VerifyBlobResult{
+			BlobVerification: protocol.BlobVerification{
+				Name:         res.Name,
+				RootMismatch: res.RootMismatch,
+				BadSignature: res.BadSignature,
+				Orphaned:     res.Orphaned,
+				Banned:       res.Banned,
+				RepairQueued: res.RepairQueued,
+			},
+			Skipped: res.Skipped,
+		}
+		for _, id := range res.CorruptSectors {
+			parsed.CorruptSectors = append(parsed.CorruptSectors, uint8(id))
+		}
+		if !cb(parsed) {
+			return nil
+		}
+	}
+}
+
 func parseBlobInfoRes(res *apiv1.BlobInfoRes) (*store.BlobInfo, error) {
 	pub, err := btcec.ParsePubKey(res.PublicKey, btcec.S256())
 	if err != nil {
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	apiv1 "fnd/rpc/v1"
+	"io"
+	"time"
+)
+
+type TxInfo struct {
+	ID               uint32
+	Name             string
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
+	TTL              time.Duration
+	DirtySectorCount int
+	Truncated        bool
+	Persisted        bool
+}
+
+// KeepAliveTx renews the TTL of an open blob transaction, and returns
+// when it now expires. A zero ttl keeps the transaction's current TTL.
+func KeepAliveTx(client apiv1.Footnotev1Client, txID uint32, ttl time.Duration) (time.Time, error) {
+	return KeepAliveTxContext(context.Background(), client, txID, ttl)
+}
+
+func KeepAliveTxContext(ctx context.Context, client apiv1.Footnotev1Client, txID uint32, ttl time.Duration) (time.Time, error) {
+	res, err := client.KeepAliveTx(ctx, &apiv1.KeepAliveTxReq{
+		TxID:       txID,
+		TtlSeconds: uint32(ttl / time.Second),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(res.ExpiresAt), 0), nil
+}
+
+func AbortTx(client apiv1.Footnotev1Client, txID uint32) error {
+	return AbortTxContext(context.Background(), client, txID)
+}
+
+func AbortTxContext(ctx context.Context, client apiv1.Footnotev1Client, txID uint32) error {
+	_, err := client.AbortTx(ctx, &apiv1.AbortTxReq{
+		TxID: txID,
+	})
+	return err
+}
+
+func ListTxs(client apiv1.Footnotev1Client, cb func(info *TxInfo) bool) error {
+	return ListTxsContext(context.Background(), client, cb)
+}
+
+func ListTxsContext(ctx context.Context, client apiv1.Footnotev1Client, cb func(info *TxInfo) bool) error {
+	stream, err := client.ListTxs(ctx, &apiv1.Empty{})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		info := &TxInfo{
+			ID:               res.TxID,
+			Name:             res.Name,
+			CreatedAt:        time.Unix(int64(res.CreatedAt), 0),
+			ExpiresAt:        time.Unix(int64(res.ExpiresAt), 0),
+			TTL:              time.Duration(res.TtlSeconds) * time.Second,
+			DirtySectorCount: int(res.DirtySectorCount),
+			Truncated:        res.Truncated,
+			Persisted:        res.Persisted,
+		}
+		if !cb(info) {
+			return nil
+		}
+	}
+}
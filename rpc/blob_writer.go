@@ -11,6 +11,9 @@ import (
 )
 
 type BlobWriter struct {
+	// TTL is how long the node keeps the transaction without
+	// activity. If it is zero, the node's default is used.
+	TTL       time.Duration
 	client    apiv1.Footnotev1Client
 	signer    crypto.Signer
 	name      string
@@ -36,7 +39,8 @@ func (b *BlobWriter) Open() error {
 		panic("writer committed")
 	}
 	checkoutRes, err := b.client.Checkout(context.Background(), &apiv1.CheckoutReq{
-		Name: b.name,
+		Name:       b.name,
+		TtlSeconds: uint32(b.TTL / time.Second),
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to check out blob")
@@ -46,6 +50,39 @@ func (b *BlobWriter) Open() error {
 	return nil
 }
 
+// Resume continues writing to a transaction that was opened earlier,
+// for example by a writer in another process. offset is where the
+// next Write lands.
+func (b *BlobWriter) Resume(txID uint32, offset int64) {
+	if b.opened {
+		panic("writer already open")
+	}
+	if b.committed {
+		panic("writer committed")
+	}
+	b.txID = txID
+	b.offset = offset
+	b.opened = true
+}
+
+func (b *BlobWriter) TxID() uint32 {
+	return b.txID
+}
+
+// KeepAlive renews the transaction's TTL.
+func (b *BlobWriter) KeepAlive() error {
+	if !b.opened {
+		panic("writer not open")
+	}
+	if b.committed {
+		panic("writer committed")
+	}
+	if _, err := KeepAliveTx(b.client, b.txID, b.TTL); err != nil {
+		return errors.Wrap(err, "error renewing transaction")
+	}
+	return nil
+}
+
 func (b *BlobWriter) Truncate() error {
 	if !b.opened {
 		panic("writer not open")
@@ -137,6 +174,29 @@ func (b *BlobWriter) Write(p []byte) (int, error) {
 }
 
 func (b *BlobWriter) Commit(broadcast bool) error {
+	req, err := b.stage(false)
+	if err != nil {
+		return err
+	}
+	if err := req.Sign(b.signer); err != nil {
+		return errors.Wrap(err, "error sealing blob")
+	}
+	if err := SubmitCommit(b.client, req, broadcast); err != nil {
+		return err
+	}
+	b.committed = true
+	return nil
+}
+
+// Stage returns an unsigned request to commit the written data. The
+// node keeps the transaction open for at least StagedTransactionTTL,
+// capped at its maximum transaction TTL, so the request can be signed
+// elsewhere and sent with SubmitCommit.
+func (b *BlobWriter) Stage() (*CommitRequest, error) {
+	return b.stage(true)
+}
+
+func (b *BlobWriter) stage(offline bool) (*CommitRequest, error) {
 	if !b.opened {
 		panic("writer not open")
 	}
@@ -144,27 +204,13 @@ func (b *BlobWriter) Commit(broadcast bool) error {
 		panic("writer committed")
 	}
 	precommitRes, err := b.client.PreCommit(context.Background(), &apiv1.PreCommitReq{
-		TxID: b.txID,
+		TxID:   b.txID,
+		Staged: offline,
 	})
 	if err != nil {
-		return errors.Wrap(err, "error retrieving precommit")
+		return nil, errors.Wrap(err, "error retrieving precommit")
 	}
-	ts := time.Now()
 	var mr crypto.Hash
 	copy(mr[:], precommitRes.MerkleRoot)
-	sig, err := blob.SignSeal(b.signer, b.name, ts, mr, crypto.ZeroHash)
-	if err != nil {
-		return errors.Wrap(err, "error sealing blob")
-	}
-	_, err = b.client.Commit(context.Background(), &apiv1.CommitReq{
-		TxID:      b.txID,
-		Timestamp: uint64(ts.Unix()),
-		Signature: sig[:],
-		Broadcast: broadcast,
-	})
-	if err != nil {
-		return errors.Wrap(err, "error sending commit")
-	}
-	b.committed = true
-	return nil
+	return NewCommitRequest(b.txID, b.name, time.Now(), mr, crypto.ZeroHash), nil
 }
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fnd/protocol"
+	apiv1 "fnd/rpc/v1"
+)
+
+// ExportBundle bundles the blob for name so that it can be imported
+// into a node without network access to this one.
+func ExportBundle(client apiv1.Footnotev1Client, name string) (*protocol.Bundle, error) {
+	return ExportBundleContext(context.Background(), client, name)
+}
+
+func ExportBundleContext(ctx context.Context, client apiv1.Footnotev1Client, name string) (*protocol.Bundle, error) {
+	res, err := client.ExportBundle(ctx, &apiv1.ExportBundleReq{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	bundle := new(protocol.Bundle)
+	if err := bundle.Decode(bytes.NewReader(res.Bundle)); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func ImportBundle(client apiv1.Footnotev1Client, bundle *protocol.Bundle, broadcast bool) error {
+	return ImportBundleContext(context.Background(), client, bundle, broadcast)
+}
+
+func ImportBundleContext(ctx context.Context, client apiv1.Footnotev1Client, bundle *protocol.Bundle, broadcast bool) error {
+	var buf bytes.Buffer
+	if err := bundle.Encode(&buf); err != nil {
+		return err
+	}
+	_, err := client.ImportBundle(ctx, &apiv1.ImportBundleReq{
+		Bundle:    buf.Bytes(),
+		Broadcast: broadcast,
+	})
+	return err
+}
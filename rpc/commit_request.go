@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fnd/blob"
+	"fnd/crypto"
+	apiv1 "fnd/rpc/v1"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// CommitRequest holds everything needed to sign and commit a staged
+// blob transaction, so that the signature can be made on a machine
+// without access to the node.
+type CommitRequest struct {
+	TxID         uint32
+	Name         string
+	Timestamp    time.Time
+	MerkleRoot   crypto.Hash
+	ReservedRoot crypto.Hash
+	SealHash     crypto.Hash
+	// Signature is zero until the request is signed.
+	Signature crypto.Signature
+}
+
+func NewCommitRequest(txID uint32, name string, ts time.Time, merkleRoot crypto.Hash, reservedRoot crypto.Hash) *CommitRequest {
+	// commits carry timestamps with second precision
+	ts = time.Unix(ts.Unix(), 0)
+	return &CommitRequest{
+		TxID:         txID,
+		Name:         name,
+		Timestamp:    ts,
+		MerkleRoot:   merkleRoot,
+		ReservedRoot: reservedRoot,
+		SealHash:     blob.SealHash(name, ts, merkleRoot, reservedRoot),
+	}
+}
+
+func (c *CommitRequest) Signed() bool {
+	return c.Signature != crypto.Signature{}
+}
+
+// Sign signs the request. The seal hash is recomputed from the
+// request's other fields rather than trusted.
+func (c *CommitRequest) Sign(signer crypto.Signer) error {
+	h := blob.SealHash(c.Name, c.Timestamp, c.MerkleRoot, c.ReservedRoot)
+	if h != c.SealHash {
+		return errors.New("seal hash does not match commit request")
+	}
+	sig, err := signer.Sign(h)
+	if err != nil {
+		return errors.Wrap(err, "error signing commit request")
+	}
+	c.Signature = sig
+	return nil
+}
+
+func (c *CommitRequest) MarshalJSON() ([]byte, error) {
+	var sig string
+	if c.Signed() {
+		sig = c.Signature.String()
+	}
+	out := &struct {
+		TxID         uint32 `json:"tx_id"`
+		Name         string `json:"name"`
+		Timestamp    uint64 `json:"timestamp"`
+		MerkleRoot   string `json:"merkle_root"`
+		ReservedRoot string `json:"reserved_root"`
+		SealHash     string `json:"seal_hash"`
+		Signature    string `json:"signature"`
+	}{
+		c.TxID,
+		c.Name,
+		uint64(c.Timestamp.Unix()),
+		c.MerkleRoot.String(),
+		c.ReservedRoot.String(),
+		c.SealHash.String(),
+		sig,
+	}
+	return json.Marshal(out)
+}
+
+func (c *CommitRequest) UnmarshalJSON(b []byte) error {
+	in := &struct {
+		TxID         uint32 `json:"tx_id"`
+		Name         string `json:"name"`
+		Timestamp    uint64 `json:"timestamp"`
+		MerkleRoot   string `json:"merkle_root"`
+		ReservedRoot string `json:"reserved_root"`
+		SealHash     string `json:"seal_hash"`
+		Signature    string `json:"signature"`
+	}{}
+	if err := json.Unmarshal(b, in); err != nil {
+		return err
+	}
+	mr, err := crypto.NewHashFromHex(in.MerkleRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid merkle root")
+	}
+	rr, err := crypto.NewHashFromHex(in.ReservedRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid reserved root")
+	}
+	sh, err := crypto.NewHashFromHex(in.SealHash)
+	if err != nil {
+		return errors.Wrap(err, "invalid seal hash")
+	}
+	var sig crypto.Signature
+	if in.Signature != "" {
+		sigB, err := hex.DecodeString(in.Signature)
+		if err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+		sig, err = crypto.NewSignatureFromBytes(sigB)
+		if err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+	}
+
+	c.TxID = in.TxID
+	c.Name = in.Name
+	c.Timestamp = time.Unix(int64(in.Timestamp), 0)
+	c.MerkleRoot = mr
+	c.ReservedRoot = rr
+	c.SealHash = sh
+	c.Signature = sig
+	return nil
+}
+
+// SubmitCommit commits the staged transaction described by a signed
+// commit request.
+func SubmitCommit(client apiv1.Footnotev1Client, req *CommitRequest, broadcast bool) error {
+	return SubmitCommitContext(context.Background(), client, req, broadcast)
+}
+
+func SubmitCommitContext(ctx context.Context, client apiv1.Footnotev1Client, req *CommitRequest, broadcast bool) error {
+	if !req.Signed() {
+		return errors.New("commit request is not signed")
+	}
+	_, err := client.Commit(ctx, &apiv1.CommitReq{
+		TxID:      req.TxID,
+		Timestamp: uint64(req.Timestamp.Unix()),
+		Signature: req.Signature[:],
+		Broadcast: broadcast,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error sending commit")
+	}
+	return nil
+}
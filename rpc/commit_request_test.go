@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fnd/blob"
+	"fnd/crypto"
+	apiv1 "fnd/rpc/v1"
+	"fnd/testutil/testcrypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+type stagingClient struct {
+	apiv1.Footnotev1Client
+	root   crypto.Hash
+	commit *apiv1.CommitReq
+}
+
+func (s *stagingClient) Checkout(context.Context, *apiv1.CheckoutReq, ...grpc.CallOption) (*apiv1.CheckoutRes, error) {
+	return &apiv1.CheckoutRes{
+		TxID: 7,
+	}, nil
+}
+
+func (s *stagingClient) PreCommit(context.Context, *apiv1.PreCommitReq, ...grpc.CallOption) (*apiv1.PreCommitRes, error) {
+	return &apiv1.PreCommitRes{
+		MerkleRoot: s.root[:],
+	}, nil
+}
+
+func (s *stagingClient) Commit(_ context.Context, req *apiv1.CommitReq, _ ...grpc.CallOption) (*apiv1.CommitRes, error) {
+	s.commit = req
+	return &apiv1.CommitRes{}, nil
+}
+
+func TestCommitRequest(t *testing.T) {
+	client := &stagingClient{
+		root: blob.EmptyBlobMerkleTree().Root(),
+	}
+	wr := NewBlobWriter(client, nil, "foo")
+	require.NoError(t, wr.Open())
+	req, err := wr.Stage()
+	require.NoError(t, err)
+	require.False(t, req.Signed())
+	require.Error(t, SubmitCommit(client, req, false))
+
+	// requests travel to the signer and back as JSON
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	unsigned := new(CommitRequest)
+	require.NoError(t, json.Unmarshal(data, unsigned))
+	require.Equal(t, req, unsigned)
+
+	tampered := *unsigned
+	tampered.MerkleRoot = crypto.Rand32()
+	signer := testcrypto.FixedSigner(t)
+	require.Error(t, tampered.Sign(signer))
+
+	require.NoError(t, unsigned.Sign(signer))
+	data, err = json.Marshal(unsigned)
+	require.NoError(t, err)
+	signed := new(CommitRequest)
+	require.NoError(t, json.Unmarshal(data, signed))
+	require.True(t, signed.Signed())
+
+	require.NoError(t, SubmitCommit(client, signed, true))
+	require.Equal(t, uint32(7), client.commit.TxID)
+	require.Equal(t, uint64(req.Timestamp.Unix()), client.commit.Timestamp)
+	require.True(t, client.commit.Broadcast)
+	var sig crypto.Signature
+	copy(sig[:], client.commit.Signature)
+	h := blob.SealHash("foo", req.Timestamp, client.root, crypto.ZeroHash)
+	require.True(t, crypto.VerifySigPub(signer.Pub(), sig, h))
+}
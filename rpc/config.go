@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"context"
+	apiv1 "fnd/rpc/v1"
+)
+
+type ReloadConfigResult struct {
+	Applied         []string
+	RestartRequired []string
+}
+
+func ReloadConfig(client apiv1.Footnotev1Client) (*ReloadConfigResult, error) {
+	return ReloadConfigContext(context.Background(), client)
+}
+
+func ReloadConfigContext(ctx context.Context, client apiv1.Footnotev1Client) (*ReloadConfigResult, error) {
+	res, err := client.ReloadConfig(ctx, &apiv1.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &ReloadConfigResult{
+		Applied:         res.Applied,
+		RestartRequired: res.RestartRequired,
+	}, nil
+}
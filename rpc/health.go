@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fnd/log"
+	"fnd/service"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultHealthWatchInterval = time.Second
+)
+
+// HealthFunc checks the health of every component of a node.
+type HealthFunc func() *service.Report
+
+// grpcHealthServer implements the standard gRPC health checking
+// protocol. The empty service name reports whether the node is
+// ready. Any other service name reports the health of the node
+// component with that name.
+type grpcHealthServer struct {
+	health        HealthFunc
+	watchInterval time.Duration
+}
+
+func (h *grpcHealthServer) Check(_ context.Context, req *healthv1.HealthCheckRequest) (*healthv1.HealthCheckResponse, error) {
+	st, ok := servingStatus(h.health(), req.Service)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthv1.HealthCheckResponse{
+		Status: st,
+	}, nil
+}
+
+func (h *grpcHealthServer) Watch(req *healthv1.HealthCheckRequest, stream healthv1.Health_WatchServer) error {
+	ticker := time.NewTicker(h.watchInterval)
+	defer ticker.Stop()
+	last := healthv1.HealthCheckResponse_UNKNOWN
+	first := true
+	for {
+		st, ok := servingStatus(h.health(), req.Service)
+		if !ok {
+			st = healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if first || st != last {
+			if err := stream.Send(&healthv1.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			first = false
+			last = st
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		}
+	}
+}
+
+func servingStatus(report *service.Report, name string) (healthv1.HealthCheckResponse_ServingStatus, bool) {
+	if name == "" {
+		if report.Ready() {
+			return healthv1.HealthCheckResponse_SERVING, true
+		}
+		return healthv1.HealthCheckResponse_NOT_SERVING, true
+	}
+	check := report.Component(name)
+	if check == nil {
+		return healthv1.HealthCheckResponse_UNKNOWN, false
+	}
+	if check.Err != nil {
+		return healthv1.HealthCheckResponse_NOT_SERVING, true
+	}
+	return healthv1.HealthCheckResponse_SERVING, true
+}
+
+// HealthServer serves /healthz and /readyz over HTTP. /healthz
+// succeeds as long as no component is broken, and /readyz succeeds
+// once every component is healthy.
+type HealthServer struct {
+	host   string
+	port   int
+	health HealthFunc
+	mux    *http.ServeMux
+	srv    *http.Server
+	lgr    log.Logger
+}
+
+var _ service.Service = (*HealthServer)(nil)
+
+type healthRes struct {
+	Status string                    `json:"status"`
+	Checks map[string]healthCheckRes `json:"checks"`
+}
+
+type healthCheckRes struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewHealthServer(host string, port int, health HealthFunc) *HealthServer {
+	h := &HealthServer{
+		host:   host,
+		port:   port,
+		health: health,
+		lgr:    log.WithModule("health-server"),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		report := h.health()
+		h.writeReport(w, report, report.Live())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := h.health()
+		h.writeReport(w, report, report.Ready())
+	})
+	h.mux = mux
+	h.srv = &http.Server{
+		Handler: h,
+	}
+	return h
+}
+
+func (h *HealthServer) Start() error {
+	lis, err := net.Listen("tcp", net.JoinHostPort(h.host, strconv.Itoa(h.port)))
+	if err != nil {
+		return err
+	}
+	h.lgr.Info("health server listening", "addr", lis.Addr())
+	if err := h.srv.Serve(lis); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (h *HealthServer) Stop() error {
+	return h.srv.Close()
+}
+
+func (h *HealthServer) Health() error {
+	return nil
+}
+
+func (h *HealthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *HealthServer) writeReport(w http.ResponseWriter, report *service.Report, ok bool) {
+	res := &healthRes{
+		Status: "ok",
+		Checks: make(map[string]healthCheckRes),
+	}
+	if !ok {
+		res.Status = "unavailable"
+	}
+	for _, check := range report.Checks {
+		checkRes := healthCheckRes{
+			Status: "ok",
+		}
+		if check.Err != nil {
+			checkRes.Status = "failing"
+			if service.IsNotReady(check.Err) {
+				checkRes.Status = "not_ready"
+			}
+			checkRes.Error = check.Err.Error()
+		}
+		res.Checks[check.Component] = checkRes
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		h.lgr.Error("error writing health response", "err", err)
+	}
+}
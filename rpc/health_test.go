@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fnd/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthServer(t *testing.T) {
+	report := &service.Report{
+		Checks: []service.Check{
+			{Component: "updater"},
+			{Component: "name_importer", Err: service.NotReady(errors.New("initial name import is not complete"))},
+		},
+	}
+	health := func() *service.Report {
+		return report
+	}
+	srv := NewHealthServer("127.0.0.1", 0, health)
+
+	get := func(path string) (int, *healthRes) {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		res := new(healthRes)
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+		return rec.Code, res
+	}
+
+	code, res := get("/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", res.Status)
+	code, res = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "unavailable", res.Status)
+	require.Equal(t, "ok", res.Checks["updater"].Status)
+	require.Equal(t, "not_ready", res.Checks["name_importer"].Status)
+	require.Equal(t, "not ready: initial name import is not complete", res.Checks["name_importer"].Error)
+
+	report.Checks[0].Err = errors.New("updater stalled processing foo")
+	code, res = get("/healthz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "failing", res.Checks["updater"].Status)
+
+	report.Checks[0].Err = nil
+	report.Checks[1].Err = nil
+	code, _ = get("/readyz")
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestGRPCHealthServer_Check(t *testing.T) {
+	report := &service.Report{
+		Checks: []service.Check{
+			{Component: "updater"},
+			{Component: "name_importer", Err: service.NotReady(errors.New("syncing"))},
+		},
+	}
+	srv := &grpcHealthServer{
+		health: func() *service.Report {
+			return report
+		},
+	}
+
+	tests := []struct {
+		name   string
+		status healthv1.HealthCheckResponse_ServingStatus
+	}{
+		{"", healthv1.HealthCheckResponse_NOT_SERVING},
+		{"updater", healthv1.HealthCheckResponse_SERVING},
+		{"name_importer", healthv1.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tt := range tests {
+		res, err := srv.Check(context.Background(), &healthv1.HealthCheckRequest{Service: tt.name})
+		require.NoError(t, err)
+		require.Equal(t, tt.status, res.Status, tt.name)
+	}
+
+	_, err := srv.Check(context.Background(), &healthv1.HealthCheckRequest{Service: "nope"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	report.Checks[1].Err = nil
+	res, err := srv.Check(context.Background(), &healthv1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthv1.HealthCheckResponse_SERVING, res.Status)
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"context"
+	apiv1 "fnd/rpc/v1"
+)
+
+type LogLevels struct {
+	Level   string
+	Modules []*ModuleLogLevel
+}
+
+type ModuleLogLevel struct {
+	Module     string
+	Level      string
+	Overridden bool
+}
+
+func ListLogLevels(client apiv1.Footnotev1Client) (*LogLevels, error) {
+	return ListLogLevelsContext(context.Background(), client)
+}
+
+func ListLogLevelsContext(ctx context.Context, client apiv1.Footnotev1Client) (*LogLevels, error) {
+	res, err := client.ListLogLevels(ctx, &apiv1.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	levels := &LogLevels{
+		Level: res.Level,
+	}
+	for _, module := range res.Modules {
+		levels.Modules = append(levels.Modules, &ModuleLogLevel{
+			Module:     module.Module,
+			Level:      module.Level,
+			Overridden: module.Overridden,
+		})
+	}
+	return levels, nil
+}
+
+// SetLogLevel changes a module's log level. An empty module changes
+// the global level, and an empty level makes the module use the
+// global level again.
+func SetLogLevel(client apiv1.Footnotev1Client, module string, level string) error {
+	return SetLogLevelContext(context.Background(), client, module, level)
+}
+
+func SetLogLevelContext(ctx context.Context, client apiv1.Footnotev1Client, module string, level string) error {
+	_, err := client.SetLogLevel(ctx, &apiv1.SetLogLevelReq{
+		Module: module,
+		Level:  level,
+	})
+	return err
+}
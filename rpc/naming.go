@@ -0,0 +1,171 @@
+package rpc
+
+import (
+	"context"
+	"fnd/protocol"
+	apiv1 "fnd/rpc/v1"
+	"fnd/store"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+	"io"
+)
+
+type NameImportStatus struct {
+	LastImportHeight      int
+	InitialImportComplete bool
+}
+
+func GetNameInfo(client apiv1.Footnotev1Client, name string) (*store.NameInfo, error) {
+	return GetNameInfoContext(context.Background(), client, name)
+}
+
+func GetNameInfoContext(ctx context.Context, client apiv1.Footnotev1Client, name string) (*store.NameInfo, error) {
+	res, err := client.GetNameInfo(ctx, &apiv1.NameInfoReq{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseGetNamesRes(res)
+}
+
+func ListNames(client apiv1.Footnotev1Client, prefix string, start string, cb func(info *store.NameInfo) bool) error {
+	return ListNamesContext(context.Background(), client, prefix, start, cb)
+}
+
+func ListNamesContext(ctx context.Context, client apiv1.Footnotev1Client, prefix string, start string, cb func(info *store.NameInfo) bool) error {
+	stream, err := client.ListNames(ctx, &apiv1.GetNamesReq{
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		parsed, err := parseGetNamesRes(res)
+		if err != nil {
+			return err
+		}
+		if !cb(parsed) {
+			return nil
+		}
+	}
+}
+
+func GetNameImportStatus(client apiv1.Footnotev1Client) (*NameImportStatus, error) {
+	return GetNameImportStatusContext(context.Background(), client)
+}
+
+func GetNameImportStatusContext(ctx context.Context, client apiv1.Footnotev1Client) (*NameImportStatus, error) {
+	res, err := client.GetNameImportStatus(ctx, &apiv1.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &NameImportStatus{
+		LastImportHeight:      int(res.LastImportHeight),
+		InitialImportComplete: res.InitialImportComplete,
+	}, nil
+}
+
+func BanName(client apiv1.Footnotev1Client, name string) error {
+	return BanNameContext(context.Background(), client, name)
+}
+
+func BanNameContext(ctx context.Context, client apiv1.Footnotev1Client, name string) error {
+	_, err := client.BanName(ctx, &apiv1.BanNameReq{
+		Name: name,
+	})
+	return err
+}
+
+func UnbanName(client apiv1.Footnotev1Client, name string) error {
+	return UnbanNameContext(context.Background(), client, name)
+}
+
+func UnbanNameContext(ctx context.Context, client apiv1.Footnotev1Client, name string) error {
+	_, err := client.UnbanName(ctx, &apiv1.UnbanNameReq{
+		Name: name,
+	})
+	return err
+}
+
+func ListNameBans(client apiv1.Footnotev1Client, cb func(ban *store.NameBan) bool) error {
+	return ListNameBansContext(context.Background(), client, cb)
+}
+
+func ListNameBansContext(ctx context.Context, client apiv1.Footnotev1Client, cb func(ban *store.NameBan) bool) error {
+	stream, err := client.ListNameBans(ctx, &apiv1.Empty{})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ban := &store.NameBan{
+			Name:    res.Name,
+			Sources: res.Sources,
+		}
+		if !cb(ban) {
+			return nil
+		}
+	}
+}
+
+func ListBlockedContent(client apiv1.Footnotev1Client, cb func(content *protocol.BlockedContent) bool) error {
+	return ListBlockedContentContext(context.Background(), client, cb)
+}
+
+func ListBlockedContentContext(ctx context.Context, client apiv1.Footnotev1Client, cb func(content *protocol.BlockedContent) bool) error {
+	stream, err := client.ListBlockedContent(ctx, &apiv1.Empty{})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		content := &protocol.BlockedContent{
+			Name: res.Name,
+		}
+		for _, id := range res.SectorIDs {
+			content.SectorIDs = append(content.SectorIDs, uint8(id))
+		}
+		if !cb(content) {
+			return nil
+		}
+	}
+}
+
+func parseGetNamesRes(res *apiv1.GetNamesRes) (*store.NameInfo, error) {
+	pub, err := btcec.ParsePubKey(res.PublicKey, btcec.S256())
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing public key")
+	}
+	return &store.NameInfo{
+		Name:         res.Name,
+		PublicKey:    pub,
+		ImportHeight: int(res.ImportHeight),
+	}, nil
+}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"encoding/hex"
+	"fnd/p2p"
 	apiv1 "fnd/rpc/v1"
 	"io"
 )
@@ -10,11 +11,35 @@ import (
 type Peer struct {
 	ID          string
 	IP          string
+	Port        int
 	Banned      bool
 	Whitelisted bool
 	Connected   bool
 	TxBytes     uint64
 	RxBytes     uint64
+	Usage       []*MessageUsage
+	// Services are the services a connected peer advertised during
+	// the handshake.
+	Services uint64
+}
+
+// MessageUsage counts the messages of one type received from a
+// connected peer. Dropped messages exceeded the peer's rate limits.
+type MessageUsage struct {
+	MessageType string
+	Received    uint64
+	Dropped     uint64
+	RxBytes     uint64
+}
+
+// DroppedMessages returns the total number of messages dropped from
+// the peer.
+func (p *Peer) DroppedMessages() uint64 {
+	var dropped uint64
+	for _, usage := range p.Usage {
+		dropped += usage.Dropped
+	}
+	return dropped
 }
 
 func ListPeers(client apiv1.Footnotev1Client) ([]*Peer, error) {
@@ -38,14 +63,26 @@ func ListPeersContext(ctx context.Context, client apiv1.Footnotev1Client) ([]*Pe
 			return nil, err
 		}
 
+		var usage []*MessageUsage
+		for _, msgUsage := range res.Usage {
+			usage = append(usage, &MessageUsage{
+				MessageType: msgUsage.MessageType,
+				Received:    msgUsage.Received,
+				Dropped:     msgUsage.Dropped,
+				RxBytes:     msgUsage.RxBytes,
+			})
+		}
 		peers = append(peers, &Peer{
 			ID:          hex.EncodeToString(res.PeerID),
 			IP:          res.Ip,
+			Port:        int(res.Port),
 			Banned:      res.Banned,
 			Whitelisted: res.Whitelisted,
 			Connected:   res.Connected,
 			TxBytes:     res.TxBytes,
 			RxBytes:     res.RxBytes,
+			Usage:       usage,
+			Services:    res.Services,
 		})
 	}
 	return peers, nil
@@ -101,19 +138,26 @@ func GetStatusContext(ctx context.Context, client apiv1.Footnotev1Client) (*Stat
 	}, nil
 }
 
-func AddPeer(client apiv1.Footnotev1Client, peerID string, ip string, verify bool) error {
-	return AddPeerContext(context.Background(), client, peerID, ip, verify)
+// AddPeer dials a peer. addr is an IP address with an optional
+// port, formatted as described by p2p.ParseAddr.
+func AddPeer(client apiv1.Footnotev1Client, peerID string, addr string, verify bool) error {
+	return AddPeerContext(context.Background(), client, peerID, addr, verify)
 }
 
-func AddPeerContext(ctx context.Context, client apiv1.Footnotev1Client, peerID string, ip string, verify bool) error {
+func AddPeerContext(ctx context.Context, client apiv1.Footnotev1Client, peerID string, addr string, verify bool) error {
 	pIDBytes, err := hex.DecodeString(peerID)
 	if err != nil {
 		return err
 	}
+	ip, port, err := p2p.ParseAddr(addr)
+	if err != nil {
+		return err
+	}
 
 	_, err = client.AddPeer(ctx, &apiv1.AddPeerReq{
 		PeerID:       pIDBytes,
 		Ip:           ip,
+		Port:         uint32(port),
 		VerifyPeerID: verify,
 	})
 	return err
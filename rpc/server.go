@@ -1,11 +1,15 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
+	"fnd.localhost/handshake/primitives"
 	"fnd/blob"
+	"fnd/config"
 	"fnd/crypto"
 	"fnd/log"
 	"fnd/p2p"
+	"fnd/protocol"
 	apiv1 "fnd/rpc/v1"
 	"fnd/store"
 	"fnd/util"
@@ -13,14 +17,24 @@ import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"google.golang.org/grpc"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"io"
+	"math"
 	"net"
+	"sort"
 	"strconv"
 	"sync/atomic"
 	"time"
 )
 
 const (
-	TransactionExpiry = 15000
+	// StagedTransactionTTL is the minimum TTL of transactions staged
+	// for offline signing, up to the maximum transaction TTL.
+	StagedTransactionTTL = 24 * time.Hour
+	BackupChunkSize      = 1024 * 1024
+	// NameLockTimeout is how long moderation RPCs wait for a busy
+	// name before giving up.
+	NameLockTimeout = 10 * time.Second
 )
 
 var emptyRes = &apiv1.Empty{}
@@ -31,9 +45,30 @@ type Opts struct {
 	PeerManager p2p.PeerManager
 	NameLocker  util.MultiLocker
 	Mux         *p2p.PeerMuxer
+	Announcer   *protocol.UpdateAnnouncer
 	DB          *leveldb.DB
 	Host        string
 	Port        int
+	// Health, if set, is served via the standard gRPC health
+	// checking service.
+	Health HealthFunc
+	// ReloadConfig, if set, reloads the node's config file and
+	// returns the changed settings that were applied and the ones
+	// that need a restart.
+	ReloadConfig func() (applied []string, restartRequired []string, err error)
+	// Scrubber, if set, serves blob verification requests.
+	Scrubber *protocol.Scrubber
+	// Backup, if set, writes an archive of the node to w.
+	Backup func(w io.Writer) error
+	// TransactionTTL is how long blob transactions are kept without
+	// activity, unless clients ask for another TTL. Requested TTLs
+	// are capped at MaxTransactionTTL.
+	TransactionTTL    time.Duration
+	MaxTransactionTTL time.Duration
+	// PersistTransactions stores the dirty sectors of blob
+	// transactions in the database, so that clients can resume them
+	// after a restart.
+	PersistTransactions bool
 }
 
 type Server struct {
@@ -41,19 +76,23 @@ type Server struct {
 	host       string
 	port       int
 	mux        *p2p.PeerMuxer
+	announcer  *protocol.UpdateAnnouncer
 	db         *leveldb.DB
 	bs         blob.Store
 	pm         p2p.PeerManager
 	nameLocker util.MultiLocker
+	scrubber   *protocol.Scrubber
+	health     HealthFunc
+	reload     func() ([]string, []string, error)
+	backup     func(w io.Writer) error
+	txTTL      time.Duration
+	maxTxTTL   time.Duration
+	persistTxs bool
 	txStore    *util.Cache
 	lgr        log.Logger
 	lastTxID   uint32
 	srv        *grpc.Server
-}
-
-type awaitingTx struct {
-	blob blob.Blob
-	tx   blob.Transaction
+	readyCh    chan struct{}
 }
 
 func NewServer(opts *Opts) *Server {
@@ -64,37 +103,59 @@ func NewServer(opts *Opts) *Server {
 		host:       opts.Host,
 		port:       opts.Port,
 		mux:        opts.Mux,
+		announcer:  opts.Announcer,
 		db:         opts.DB,
 		bs:         opts.BlobStore,
 		pm:         opts.PeerManager,
 		nameLocker: opts.NameLocker,
+		scrubber:   opts.Scrubber,
+		health:     opts.Health,
+		reload:     opts.ReloadConfig,
+		backup:     opts.Backup,
+		txTTL:      opts.TransactionTTL,
+		maxTxTTL:   opts.MaxTransactionTTL,
+		persistTxs: opts.PersistTransactions,
 		txStore:    util.NewCache(),
 		lgr:        lgr,
+		readyCh:    make(chan struct{}),
 	}
-	srv.txStore.ReaperFunc = func(pub string, val interface{}) {
-		awaiting := val.(*awaitingTx)
-		err := awaiting.tx.Rollback()
-		if err == nil {
-			lgr.Info("reaped stale blob transaction", "pub", pub)
-		} else {
-			lgr.Error("failed to remove stale blob transaction", "err", err, "pub", pub)
-		}
-		if err := awaiting.blob.Close(); err != nil {
-			lgr.Error("error closing blob", "err", err)
-		}
+	if srv.txTTL == 0 {
+		srv.txTTL = config.ConvertDuration(config.DefaultConfig.RPC.TransactionTTLMS, time.Millisecond)
+	}
+	if srv.maxTxTTL == 0 {
+		srv.maxTxTTL = config.ConvertDuration(config.DefaultConfig.RPC.MaxTransactionTTLMS, time.Millisecond)
+	}
+	srv.txStore.ReaperFunc = func(_ string, val interface{}) {
+		srv.reapTx(val.(*awaitingTx))
+	}
+	srv.srv = grpc.NewServer()
+	apiv1.RegisterFootnotev1Server(srv.srv, srv)
+	if opts.Health != nil {
+		healthv1.RegisterHealthServer(srv.srv, &grpcHealthServer{
+			health:        opts.Health,
+			watchInterval: DefaultHealthWatchInterval,
+		})
 	}
 	return srv
 }
 
+// Start serves RPC requests until the server is stopped.
 func (s *Server) Start() error {
+	if err := s.restoreTxs(); err != nil {
+		return errors.Wrap(err, "error restoring blob transactions")
+	}
 	lis, err := net.Listen("tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
 	if err != nil {
 		return err
 	}
-	s.srv = grpc.NewServer()
-	apiv1.RegisterFootnotev1Server(s.srv, s)
-	go s.srv.Serve(lis)
-	return nil
+	close(s.readyCh)
+	return s.srv.Serve(lis)
+}
+
+// Ready is closed once persisted transactions are restored and the
+// server is listening.
+func (s *Server) Ready() <-chan struct{} {
+	return s.readyCh
 }
 
 func (s *Server) Stop() error {
@@ -102,6 +163,10 @@ func (s *Server) Stop() error {
 	return nil
 }
 
+func (s *Server) Health() error {
+	return nil
+}
+
 func (s *Server) GetStatus(context.Context, *apiv1.Empty) (*apiv1.GetStatusRes, error) {
 	in, out := s.mux.PeerCount()
 	peerCount := in + out
@@ -125,14 +190,25 @@ func (s *Server) AddPeer(_ context.Context, req *apiv1.AddPeerReq) (*apiv1.Empty
 	}
 	var peerId crypto.Hash
 	copy(peerId[:], req.PeerID)
-	if err := s.pm.DialPeer(peerId, req.Ip, req.VerifyPeerID); err != nil {
+	// older clients send the address as a single string
+	ip, port, err := p2p.ParseAddr(req.Ip)
+	if err != nil {
+		return nil, err
+	}
+	if req.Port > math.MaxUint16 {
+		return nil, errors.New("invalid port")
+	}
+	if req.Port != 0 {
+		port = int(req.Port)
+	}
+	if err := s.pm.DialPeer(peerId, ip, port, req.VerifyPeerID); err != nil {
 		return nil, err
 	}
 	return emptyRes, nil
 }
 
 func (s *Server) BanPeer(_ context.Context, req *apiv1.BanPeerReq) (*apiv1.Empty, error) {
-	ip := net.ParseIP(req.Ip).To4()
+	ip := net.ParseIP(req.Ip)
 	if ip == nil {
 		return nil, errors.New("invalid IP")
 	}
@@ -159,7 +235,7 @@ func (s *Server) BanPeer(_ context.Context, req *apiv1.BanPeerReq) (*apiv1.Empty
 }
 
 func (s *Server) UnbanPeer(_ context.Context, req *apiv1.UnbanPeerReq) (*apiv1.Empty, error) {
-	ip := net.ParseIP(req.Ip).To4()
+	ip := net.ParseIP(req.Ip)
 	if ip == nil {
 		return emptyRes, errors.New("invalid IP")
 	}
@@ -194,20 +270,37 @@ func (s *Server) ListPeers(req *apiv1.ListPeersReq, stream apiv1.Footnotev1_List
 		var txBytes uint64
 		var rxBytes uint64
 		var connected bool
+		var services uint64
+		var usage []*apiv1.MessageUsage
 		livePeer := connectedPeers[peer.ID]
 		if livePeer != nil {
 			txBytes, rxBytes = livePeer.BandwidthUsage()
 			connected = true
+			services = livePeer.Services()
+			for msgType, msgUsage := range s.mux.PeerUsage(peer.ID) {
+				usage = append(usage, &apiv1.MessageUsage{
+					MessageType: msgType.String(),
+					Received:    msgUsage.Received,
+					Dropped:     msgUsage.Dropped,
+					RxBytes:     msgUsage.RxBytes,
+				})
+			}
+			sort.Slice(usage, func(i, j int) bool {
+				return usage[i].MessageType < usage[j].MessageType
+			})
 		}
 
 		peerRes := &apiv1.ListPeersRes{
 			PeerID:      peer.ID[:],
 			Ip:          peer.IP,
+			Port:        uint32(peer.Port),
 			Banned:      peer.IsBanned(),
 			Whitelisted: peer.Whitelisted,
 			Connected:   connected,
 			TxBytes:     txBytes,
 			RxBytes:     rxBytes,
+			Usage:       usage,
+			Services:    services,
 		}
 		if err := stream.Send(peerRes); err != nil {
 			return err
@@ -223,23 +316,35 @@ func (s *Server) Checkout(ctx context.Context, req *apiv1.CheckoutReq) (*apiv1.C
 	}
 	tx, err := bl.Transaction()
 	if err != nil {
+		bl.Close()
 		return nil, err
 	}
 
-	s.txStore.Set(strconv.FormatUint(uint64(txID), 32), &awaitingTx{
-		blob: bl,
-		tx:   tx,
-	}, TransactionExpiry)
+	now := time.Now()
+	awaiting := &awaitingTx{
+		id:        txID,
+		blob:      bl,
+		tx:        tx,
+		createdAt: now,
+		ttl:       s.clampTxTTL(time.Duration(req.TtlSeconds) * time.Second),
+		persisted: s.persistTxs,
+	}
+	if err := s.putTx(awaiting); err != nil {
+		tx.Rollback()
+		bl.Close()
+		return nil, err
+	}
 
 	return &apiv1.CheckoutRes{
-		TxID: txID,
+		TxID:      txID,
+		ExpiresAt: uint64(awaiting.ExpiresAt().Unix()),
 	}, nil
 }
 
 func (s *Server) WriteAt(ctx context.Context, req *apiv1.WriteAtReq) (*apiv1.WriteAtRes, error) {
-	awaiting := s.txStore.Get(strconv.FormatUint(uint64(req.TxID), 32)).(*awaitingTx)
-	if awaiting == nil {
-		return nil, errors.New("transaction ID not found")
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
 	}
 	tx := awaiting.tx
 	// we want clients to handle partial writes
@@ -250,34 +355,49 @@ func (s *Server) WriteAt(ctx context.Context, req *apiv1.WriteAtReq) (*apiv1.Wri
 	if err != nil {
 		res.WriteErr = err.Error()
 	}
+	if n > 0 {
+		if err := s.persistTxWrite(awaiting, int64(req.Offset), n); err != nil {
+			return nil, errors.Wrap(err, "error persisting write")
+		}
+	}
 	return res, nil
 }
 
 func (s *Server) Truncate(ctx context.Context, req *apiv1.TruncateReq) (*apiv1.Empty, error) {
-	awaiting := s.txStore.Get(strconv.FormatUint(uint64(req.TxID), 32)).(*awaitingTx)
-	if awaiting == nil {
-		return nil, errors.New("transaction ID not found")
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
 	}
 
 	tx := awaiting.tx
 	if err := tx.Truncate(); err != nil {
 		return nil, errors.Wrap(err, "error truncating blob")
 	}
+	if err := s.persistTxTruncate(awaiting); err != nil {
+		return nil, errors.Wrap(err, "error persisting truncation")
+	}
 
 	return emptyRes, nil
 }
 
 func (s *Server) PreCommit(ctx context.Context, req *apiv1.PreCommitReq) (*apiv1.PreCommitRes, error) {
-	awaiting := s.txStore.Get(strconv.FormatUint(uint64(req.TxID), 32))
-	if awaiting == nil {
-		return nil, errors.New("transaction ID not found")
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
 	}
 
-	tx := awaiting.(*awaitingTx).tx
-	mt, err := blob.Merkleize(blob.NewReader(tx))
+	tx := awaiting.tx
+	mt, err := s.updateMerkleTree(tx)
 	if err != nil {
 		return nil, errors.Wrap(err, "error generating blob merkle root")
 	}
+	// staged commits are signed offline, so keep the transaction
+	// around until the signature arrives
+	if ttl := s.clampTxTTL(StagedTransactionTTL); req.Staged && awaiting.TTL() < ttl {
+		if err := s.renewTx(awaiting, ttl); err != nil {
+			return nil, err
+		}
+	}
 
 	return &apiv1.PreCommitRes{
 		MerkleRoot: mt.Root().Bytes(),
@@ -285,10 +405,9 @@ func (s *Server) PreCommit(ctx context.Context, req *apiv1.PreCommitReq) (*apiv1
 }
 
 func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.CommitRes, error) {
-	id := strconv.FormatUint(uint64(req.TxID), 32)
-	awaiting := s.txStore.Get(id).(*awaitingTx)
-	if awaiting == nil {
-		return nil, errors.New("transaction ID not found")
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
 	}
 
 	tx := awaiting.tx
@@ -297,7 +416,7 @@ func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.Commi
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting name info")
 	}
-	mt, err := blob.Merkleize(blob.NewReader(tx))
+	mt, err := s.updateMerkleTree(tx)
 	if err != nil {
 		return nil, errors.Wrap(err, "error generating blob merkle root")
 	}
@@ -307,7 +426,16 @@ func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.Commi
 	ts := time.Unix(int64(req.Timestamp), 0)
 	h := blob.SealHash(name, ts, mt.Root(), crypto.ZeroHash)
 	if !crypto.VerifySigPub(info.PublicKey, sig, h) {
-		return nil, errors.New("signature verification failed")
+		// the stored tree may be stale, so fall back to rehashing
+		// the whole blob
+		mt, err = blob.Merkleize(blob.NewReader(tx))
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating blob merkle root")
+		}
+		h = blob.SealHash(name, ts, mt.Root(), crypto.ZeroHash)
+		if !crypto.VerifySigPub(info.PublicKey, sig, h) {
+			return nil, errors.New("signature verification failed")
+		}
 	}
 
 	if !s.nameLocker.TryLock(name) {
@@ -323,7 +451,7 @@ func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.Commi
 			Signature:    sig,
 			ReservedRoot: crypto.ZeroHash,
 			ReceivedAt:   time.Now(),
-		}, mt.ProtocolBase())
+		}, mt)
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error storing header")
@@ -331,15 +459,13 @@ func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.Commi
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "error committing blob")
 	}
-	if err := awaiting.blob.Close(); err != nil {
-		return nil, errors.Wrap(err, "error closing blob")
+	if err := s.releaseTx(awaiting); err != nil {
+		return nil, err
 	}
 
-	s.txStore.Del(id)
-
 	var recips []crypto.Hash
 	if req.Broadcast {
-		recips, _ = p2p.GossipAll(s.mux, &wire.Update{
+		recips = s.announcer.Announce(&wire.Update{
 			Name:       name,
 			Timestamp:  ts,
 			MerkleRoot: mt.Root(),
@@ -351,6 +477,58 @@ func (s *Server) Commit(ctx context.Context, req *apiv1.CommitReq) (*apiv1.Commi
 	return &apiv1.CommitRes{}, nil
 }
 
+func (s *Server) KeepAliveTx(_ context.Context, req *apiv1.KeepAliveTxReq) (*apiv1.KeepAliveTxRes, error) {
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
+	}
+	ttl := awaiting.TTL()
+	if req.TtlSeconds > 0 {
+		ttl = s.clampTxTTL(time.Duration(req.TtlSeconds) * time.Second)
+	}
+	if err := s.renewTx(awaiting, ttl); err != nil {
+		return nil, err
+	}
+	return &apiv1.KeepAliveTxRes{
+		ExpiresAt: uint64(awaiting.ExpiresAt().Unix()),
+	}, nil
+}
+
+func (s *Server) AbortTx(_ context.Context, req *apiv1.AbortTxReq) (*apiv1.Empty, error) {
+	awaiting, err := s.getTx(req.TxID)
+	if err != nil {
+		return nil, err
+	}
+	if err := awaiting.tx.Rollback(); err != nil {
+		return nil, errors.Wrap(err, "error rolling back transaction")
+	}
+	if err := s.releaseTx(awaiting); err != nil {
+		return nil, err
+	}
+	s.lgr.Info("aborted blob transaction", "tx_id", req.TxID, "name", awaiting.tx.Name())
+	return emptyRes, nil
+}
+
+func (s *Server) ListTxs(_ *apiv1.Empty, srv apiv1.Footnotev1_ListTxsServer) error {
+	for _, awaiting := range s.listTxs() {
+		dirty, truncated := awaiting.tx.DirtySectors()
+		err := srv.Send(&apiv1.TxInfoRes{
+			TxID:             awaiting.id,
+			Name:             awaiting.tx.Name(),
+			CreatedAt:        uint64(awaiting.createdAt.Unix()),
+			ExpiresAt:        uint64(awaiting.ExpiresAt().Unix()),
+			TtlSeconds:       uint32(awaiting.TTL() / time.Second),
+			DirtySectorCount: uint32(len(dirty)),
+			Truncated:        truncated,
+			Persisted:        awaiting.persisted,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error sending transaction info")
+		}
+	}
+	return nil
+}
+
 func (s *Server) ReadAt(_ context.Context, req *apiv1.ReadAtReq) (*apiv1.ReadAtRes, error) {
 	if req.Offset > blob.Size {
 		return nil, errors.New("offset is beyond blob bounds")
@@ -384,6 +562,61 @@ func (s *Server) ReadAt(_ context.Context, req *apiv1.ReadAtReq) (*apiv1.ReadAtR
 	}, nil
 }
 
+func (s *Server) ReadSectors(_ context.Context, req *apiv1.ReadSectorsReq) (*apiv1.ReadSectorsRes, error) {
+	if req.StartSectorID >= blob.SectorCount {
+		return nil, errors.New("start sector is beyond blob bounds")
+	}
+	if req.StartSectorID+req.Count > blob.SectorCount {
+		return nil, errors.New("read is beyond blob bounds")
+	}
+
+	name := req.Name
+	if !s.nameLocker.TryRLock(name) {
+		return nil, errors.New("name is busy")
+	}
+	defer s.nameLocker.RUnlock(name)
+	header, err := store.GetHeader(s.db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting header")
+	}
+	info, err := store.GetNameInfo(s.db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting name info")
+	}
+	tree, err := store.GetMerkleTree(s.db, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting merkle tree")
+	}
+	bl, err := s.bs.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening blob for reading")
+	}
+	defer bl.Close()
+
+	res := &apiv1.ReadSectorsRes{
+		Name:         name,
+		PublicKey:    info.PublicKey.SerializeCompressed(),
+		Timestamp:    uint64(header.Timestamp.Unix()),
+		MerkleRoot:   header.MerkleRoot[:],
+		ReservedRoot: header.ReservedRoot[:],
+		Signature:    header.Signature[:],
+	}
+	for i := req.StartSectorID; i < req.StartSectorID+req.Count; i++ {
+		id := uint8(i)
+		sector, err := bl.ReadSector(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading sector")
+		}
+		proof := blob.MakeSectorProof(tree, id)
+		res.Sectors = append(res.Sectors, &apiv1.ProvenSector{
+			SectorID: uint32(id),
+			Data:     sector[:],
+			Proof:    proof[:],
+		})
+	}
+	return res, nil
+}
+
 func (s *Server) GetBlobInfo(_ context.Context, req *apiv1.BlobInfoReq) (*apiv1.BlobInfoRes, error) {
 	name := req.Name
 	header, err := store.GetHeader(s.db, name)
@@ -440,20 +673,348 @@ func (s *Server) ListBlobInfo(req *apiv1.ListBlobInfoReq, srv apiv1.Footnotev1_L
 	}
 }
 
+func (s *Server) VerifyBlobs(req *apiv1.VerifyBlobsReq, srv apiv1.Footnotev1_VerifyBlobsServer) error {
+	if s.scrubber == nil {
+		return errors.New("blob verification is not supported")
+	}
+	verify := func(name string) error {
+		res, err := s.scrubber.Check(name, req.Repair)
+		if errors.Is(err, protocol.ErrNameLocked) {
+			return srv.Send(&apiv1.VerifyBlobRes{
+				Name:    name,
+				Skipped: true,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		apiRes := &apiv1.VerifyBlobRes{
+			Name:         name,
+			RootMismatch: res.RootMismatch,
+			BadSignature: res.BadSignature,
+			Orphaned:     res.Orphaned,
+			Banned:       res.Banned,
+			RepairQueued: res.RepairQueued,
+		}
+		for _, id := range res.CorruptSectors {
+			apiRes.CorruptSectors = append(apiRes.CorruptSectors, uint32(id))
+		}
+		return srv.Send(apiRes)
+	}
+
+	if len(req.Names) > 0 {
+		for _, name := range req.Names {
+			if err := verify(name); err != nil {
+				return errors.Wrap(err, "error verifying blob")
+			}
+		}
+		return nil
+	}
+
+	stream, err := store.StreamHeaders(s.db)
+	if err != nil {
+		return errors.Wrap(err, "error opening header stream")
+	}
+	defer stream.Close()
+	for {
+		header, err := stream.Next()
+		if err != nil {
+			return errors.Wrap(err, "error reading header")
+		}
+		if header == nil {
+			return nil
+		}
+		if err := verify(header.Name); err != nil {
+			return errors.Wrap(err, "error verifying blob")
+		}
+	}
+}
+
+func (s *Server) ExportBundle(_ context.Context, req *apiv1.ExportBundleReq) (*apiv1.ExportBundleRes, error) {
+	bundle, err := protocol.ExportBundle(s.db, s.bs, s.nameLocker, req.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error exporting bundle")
+	}
+	var buf bytes.Buffer
+	if err := bundle.Encode(&buf); err != nil {
+		return nil, errors.Wrap(err, "error encoding bundle")
+	}
+	return &apiv1.ExportBundleRes{
+		Bundle: buf.Bytes(),
+	}, nil
+}
+
+func (s *Server) ImportBundle(_ context.Context, req *apiv1.ImportBundleReq) (*apiv1.Empty, error) {
+	bundle := new(protocol.Bundle)
+	if err := bundle.Decode(bytes.NewReader(req.Bundle)); err != nil {
+		return nil, err
+	}
+	err := protocol.ImportBundle(&protocol.ImportBundleConfig{
+		Mux:        s.mux,
+		Announcer:  s.announcer,
+		DB:         s.db,
+		NameLocker: s.nameLocker,
+		BlobStore:  s.bs,
+		Bundle:     bundle,
+		Broadcast:  req.Broadcast,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error importing bundle")
+	}
+	return emptyRes, nil
+}
+
 func (s *Server) SendUpdate(_ context.Context, req *apiv1.SendUpdateReq) (*apiv1.SendUpdateRes, error) {
 	header, err := store.GetHeader(s.db, req.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	recips, _ := p2p.GossipAll(s.mux, &wire.Update{
-		Name:       req.Name,
-		Timestamp:  header.Timestamp,
-		MerkleRoot: header.MerkleRoot,
-		Signature:  header.Signature,
+	recips := s.announcer.Announce(&wire.Update{
+		Name:         req.Name,
+		Timestamp:    header.Timestamp,
+		MerkleRoot:   header.MerkleRoot,
+		ReservedRoot: header.ReservedRoot,
+		Signature:    header.Signature,
 	})
 
 	return &apiv1.SendUpdateRes{
 		RecipientCount: uint32(len(recips)),
 	}, nil
 }
+
+func (s *Server) GetNameInfo(_ context.Context, req *apiv1.NameInfoReq) (*apiv1.GetNamesRes, error) {
+	info, err := store.GetNameInfo(s.db, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetNamesRes{
+		Name:         info.Name,
+		PublicKey:    info.PublicKey.SerializeCompressed(),
+		ImportHeight: uint32(info.ImportHeight),
+	}, nil
+}
+
+func (s *Server) ListNames(req *apiv1.GetNamesReq, srv apiv1.Footnotev1_ListNamesServer) error {
+	stream, err := store.StreamNameInfoPrefix(s.db, req.Prefix, req.Start)
+	if err != nil {
+		return errors.Wrap(err, "error opening name stream")
+	}
+	defer stream.Close()
+
+	var sent uint32
+	for req.Count == 0 || sent < req.Count {
+		info, err := stream.Next()
+		if err != nil {
+			return errors.Wrap(err, "error reading name info")
+		}
+		if info == nil {
+			return nil
+		}
+		res := &apiv1.GetNamesRes{
+			Name:         info.Name,
+			PublicKey:    info.PublicKey.SerializeCompressed(),
+			ImportHeight: uint32(info.ImportHeight),
+		}
+		if err := srv.Send(res); err != nil {
+			return errors.Wrap(err, "error sending name info")
+		}
+		sent++
+	}
+	return nil
+}
+
+func (s *Server) GetNameImportStatus(context.Context, *apiv1.Empty) (*apiv1.NameImportStatusRes, error) {
+	height, err := store.GetLastNameImportHeight(s.db)
+	if err != nil {
+		return nil, err
+	}
+	complete, err := store.GetInitialImportComplete(s.db)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.NameImportStatusRes{
+		LastImportHeight:      uint32(height),
+		InitialImportComplete: complete,
+	}, nil
+}
+
+func (s *Server) BanName(_ context.Context, req *apiv1.BanNameReq) (*apiv1.Empty, error) {
+	if err := primitives.ValidateName(req.Name); err != nil {
+		return nil, errors.Wrap(err, "invalid name")
+	}
+	// the ban is only stored once the blob can be deleted with it
+	if !util.LockTimeout(s.nameLocker, req.Name, NameLockTimeout) {
+		return nil, errors.New("name is busy")
+	}
+	defer s.nameLocker.Unlock(req.Name)
+	err := store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		return store.BanName(tx, req.Name, store.LocalBanSource)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error storing name ban")
+	}
+	if err := protocol.DeleteBannedBlob(s.bs, req.Name); err != nil {
+		return nil, err
+	}
+	return emptyRes, nil
+}
+
+func (s *Server) UnbanName(_ context.Context, req *apiv1.UnbanNameReq) (*apiv1.Empty, error) {
+	ban, err := store.GetNameBan(s.db, req.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting name ban")
+	}
+	if ban == nil || !ban.HasSource(store.LocalBanSource) {
+		return nil, errors.New("name is not banned locally")
+	}
+	err = store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		_, err := store.UnbanName(tx, req.Name, store.LocalBanSource)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error removing name ban")
+	}
+	return emptyRes, nil
+}
+
+func (s *Server) ListNameBans(_ *apiv1.Empty, srv apiv1.Footnotev1_ListNameBansServer) error {
+	bans, err := store.ListNameBans(s.db)
+	if err != nil {
+		return errors.Wrap(err, "error listing name bans")
+	}
+	for _, ban := range bans {
+		res := &apiv1.NameBanRes{
+			Name:    ban.Name,
+			Sources: ban.Sources,
+		}
+		if err := srv.Send(res); err != nil {
+			return errors.Wrap(err, "error sending name ban")
+		}
+	}
+	return nil
+}
+
+func (s *Server) ListBlockedContent(_ *apiv1.Empty, srv apiv1.Footnotev1_ListBlockedContentServer) error {
+	content, err := protocol.FindBlockedContent(s.db)
+	if err != nil {
+		return errors.Wrap(err, "error finding blocked content")
+	}
+	for _, c := range content {
+		res := &apiv1.BlockedContentRes{
+			Name: c.Name,
+		}
+		for _, id := range c.SectorIDs {
+			res.SectorIDs = append(res.SectorIDs, uint32(id))
+		}
+		if err := srv.Send(res); err != nil {
+			return errors.Wrap(err, "error sending blocked content")
+		}
+	}
+	return nil
+}
+
+func (s *Server) ListLogLevels(context.Context, *apiv1.Empty) (*apiv1.ListLogLevelsRes, error) {
+	level := log.GetLevel()
+	overrides := log.ModuleLevels()
+	modules := log.Modules()
+	for module := range overrides {
+		idx := sort.SearchStrings(modules, module)
+		if idx == len(modules) || modules[idx] != module {
+			modules = append(modules, module)
+			sort.Strings(modules)
+		}
+	}
+
+	res := &apiv1.ListLogLevelsRes{
+		Level: level.String(),
+	}
+	for _, module := range modules {
+		moduleLevel, overridden := overrides[module]
+		if !overridden {
+			moduleLevel = level
+		}
+		res.Modules = append(res.Modules, &apiv1.ModuleLogLevel{
+			Module:     module,
+			Level:      moduleLevel.String(),
+			Overridden: overridden,
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) SetLogLevel(_ context.Context, req *apiv1.SetLogLevelReq) (*apiv1.Empty, error) {
+	if req.Module != "" && req.Level == "" {
+		log.ClearModuleLevel(req.Module)
+		s.lgr.Info("cleared module log level", "log_module", req.Module)
+		return emptyRes, nil
+	}
+	level, err := log.NewLevel(req.Level)
+	if err != nil {
+		return nil, err
+	}
+	if req.Module == "" {
+		log.SetLevel(level)
+		s.lgr.Info("set log level", "level", level.String())
+		return emptyRes, nil
+	}
+	log.SetModuleLevel(req.Module, level)
+	s.lgr.Info("set module log level", "log_module", req.Module, "level", level.String())
+	return emptyRes, nil
+}
+
+func (s *Server) ReloadConfig(context.Context, *apiv1.Empty) (*apiv1.ReloadConfigRes, error) {
+	if s.reload == nil {
+		return nil, errors.New("config reloading is not supported")
+	}
+	applied, restartRequired, err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.ReloadConfigRes{
+		Applied:         applied,
+		RestartRequired: restartRequired,
+	}, nil
+}
+
+func (s *Server) Backup(_ *apiv1.Empty, srv apiv1.Footnotev1_BackupServer) error {
+	if s.backup == nil {
+		return errors.New("backups are not supported")
+	}
+	return s.backup(&backupChunkWriter{srv: srv})
+}
+
+// backupChunkWriter streams writes to the client in chunks that fit
+// within gRPC's message size limit.
+type backupChunkWriter struct {
+	srv apiv1.Footnotev1_BackupServer
+}
+
+func (w *backupChunkWriter) Write(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		end := n + BackupChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if err := w.srv.Send(&apiv1.BackupChunk{Data: p[n:end]}); err != nil {
+			return n, errors.Wrap(err, "error sending backup chunk")
+		}
+		n = end
+	}
+	return n, nil
+}
+
+// updateMerkleTree applies the transaction's dirty sectors to the
+// name's stored merkle tree. Blobs without a stored tree may still
+// hold data, so they are hashed in full.
+func (s *Server) updateMerkleTree(tx blob.Transaction) (blob.MerkleTree, error) {
+	prev, err := store.GetMerkleTree(s.db, tx.Name())
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return blob.Merkleize(blob.NewReader(tx))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting merkle tree")
+	}
+	return blob.UpdateMerkleTree(prev, tx)
+}
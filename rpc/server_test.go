@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fnd/blob"
+	"fnd/crypto"
+	apiv1 "fnd/rpc/v1"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/testutil/testcrypto"
+	"fnd/util"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServer_Ready(t *testing.T) {
+	storage, done := mockapp.CreateStorage(t)
+	defer done()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	newServer := func(port int) *Server {
+		return NewServer(&Opts{
+			Host:       "127.0.0.1",
+			Port:       port,
+			DB:         storage.DB,
+			BlobStore:  storage.BlobStore,
+			NameLocker: util.NewMultiLocker(),
+		})
+	}
+
+	// a server that can't listen never becomes ready
+	srv := newServer(lis.Addr().(*net.TCPAddr).Port)
+	require.Error(t, srv.Start())
+	select {
+	case <-srv.Ready():
+		t.Fatal("server is ready without listening")
+	default:
+	}
+
+	srv = newServer(0)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Start()
+	}()
+	select {
+	case <-srv.Ready():
+	case err := <-errCh:
+		t.Fatalf("server exited before it was ready: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server never became ready")
+	}
+	require.NoError(t, srv.Stop())
+	require.NoError(t, <-errCh)
+}
+
+func TestServer_CommitMerkleTree(t *testing.T) {
+	storage, done := mockapp.CreateStorage(t)
+	defer done()
+	signer := testcrypto.FixedSigner(t)
+	srv := NewServer(&Opts{
+		DB:         storage.DB,
+		BlobStore:  storage.BlobStore,
+		NameLocker: util.NewMultiLocker(),
+	})
+	ctx := context.Background()
+	ts := time.Now()
+
+	// writes sector 1 of the blob through the RPC server, and returns
+	// the root of what the blob should hold afterwards
+	write := func(name string) (uint32, crypto.Hash) {
+		bl, err := storage.BlobStore.Open(name)
+		require.NoError(t, err)
+		expected, err := ioutil.ReadAll(blob.NewReader(bl))
+		require.NoError(t, err)
+		require.NoError(t, bl.Close())
+		data := []byte("hello world")
+		copy(expected[blob.SectorLen:], data)
+		tree, err := blob.Merkleize(bytes.NewReader(expected))
+		require.NoError(t, err)
+
+		checkout, err := srv.Checkout(ctx, &apiv1.CheckoutReq{
+			Name: name,
+		})
+		require.NoError(t, err)
+		_, err = srv.WriteAt(ctx, &apiv1.WriteAtReq{
+			TxID:   checkout.TxID,
+			Offset: blob.SectorLen,
+			Data:   data,
+		})
+		require.NoError(t, err)
+		return checkout.TxID, tree.Root()
+	}
+	commit := func(name string, txID uint32, root crypto.Hash) {
+		sig, err := blob.SignSeal(signer, name, ts, root, crypto.ZeroHash)
+		require.NoError(t, err)
+		_, err = srv.Commit(ctx, &apiv1.CommitReq{
+			TxID:      txID,
+			Timestamp: uint64(ts.Unix()),
+			Signature: sig[:],
+		})
+		require.NoError(t, err)
+		tree, err := store.GetMerkleTree(storage.DB, name)
+		require.NoError(t, err)
+		require.Equal(t, root, tree.Root())
+	}
+	require.NoError(t, store.WithTx(storage.DB, func(tx *leveldb.Transaction) error {
+		for _, name := range []string{"foo", "bar"} {
+			if err := store.SetNameInfoTx(tx, name, signer.Pub(), 10); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	t.Run("blobs without headers are hashed in full", func(t *testing.T) {
+		bl, err := storage.BlobStore.Open("foo")
+		require.NoError(t, err)
+		tx, err := bl.Transaction()
+		require.NoError(t, err)
+		var sector blob.Sector
+		_, err = rand.Read(sector[:])
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteSector(0, sector))
+		require.NoError(t, tx.Commit())
+		require.NoError(t, bl.Close())
+
+		txID, root := write("foo")
+		precommit, err := srv.PreCommit(ctx, &apiv1.PreCommitReq{
+			TxID: txID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, root.Bytes(), precommit.MerkleRoot)
+		commit("foo", txID, root)
+	})
+
+	t.Run("commits fall back when the stored tree is stale", func(t *testing.T) {
+		mockapp.FillBlobRandom(t, storage.DB, storage.BlobStore, signer, "bar", ts.Add(-time.Hour), ts)
+		bl, err := storage.BlobStore.Open("bar")
+		require.NoError(t, err)
+		tx, err := bl.Transaction()
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteSector(3, blob.ZeroSector))
+		require.NoError(t, tx.Commit())
+		require.NoError(t, bl.Close())
+
+		txID, root := write("bar")
+		commit("bar", txID, root)
+	})
+}
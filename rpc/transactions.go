@@ -0,0 +1,271 @@
+package rpc
+
+import (
+	"fnd/blob"
+	"fnd/store"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var errTxNotFound = errors.New("transaction ID not found")
+
+type awaitingTx struct {
+	id        uint32
+	blob      blob.Blob
+	tx        blob.Transaction
+	createdAt time.Time
+	persisted bool
+	mu        sync.Mutex
+	ttl       time.Duration
+	expiresAt time.Time
+}
+
+func (a *awaitingTx) TTL() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.ttl
+}
+
+func (a *awaitingTx) ExpiresAt() time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.expiresAt
+}
+
+func (a *awaitingTx) setExpiry(ttl time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ttl = ttl
+	a.expiresAt = time.Now().Add(ttl)
+}
+
+func (a *awaitingTx) stagedTx() *store.StagedTx {
+	_, truncated := a.tx.DirtySectors()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &store.StagedTx{
+		ID:        a.id,
+		Name:      a.tx.Name(),
+		Truncated: truncated,
+		CreatedAt: a.createdAt,
+		ExpiresAt: a.expiresAt,
+		TTL:       a.ttl,
+	}
+}
+
+func txKey(id uint32) string {
+	return strconv.FormatUint(uint64(id), 32)
+}
+
+// getTx returns the open transaction with the given ID. Every use of
+// a transaction renews its TTL.
+func (s *Server) getTx(id uint32) (*awaitingTx, error) {
+	awaiting, ok := s.txStore.Get(txKey(id)).(*awaitingTx)
+	if !ok {
+		return nil, errTxNotFound
+	}
+	if err := s.renewTx(awaiting, awaiting.TTL()); err != nil {
+		return nil, err
+	}
+	return awaiting, nil
+}
+
+// putTx adds a new or restored transaction to the cache.
+func (s *Server) putTx(awaiting *awaitingTx) error {
+	ttl := awaiting.TTL()
+	awaiting.setExpiry(ttl)
+	s.txStore.Set(txKey(awaiting.id), awaiting, int64(ttl/time.Millisecond))
+	return s.persistTx(awaiting)
+}
+
+// renewTx extends a cached transaction's TTL. The renewal happens in
+// the cache under a single lock, so a transaction the reaper has
+// already rolled back is never put back.
+func (s *Server) renewTx(awaiting *awaitingTx, ttl time.Duration) error {
+	if _, ok := s.txStore.Touch(txKey(awaiting.id), int64(ttl/time.Millisecond)); !ok {
+		return errTxNotFound
+	}
+	awaiting.setExpiry(ttl)
+	return s.persistTx(awaiting)
+}
+
+func (s *Server) persistTx(awaiting *awaitingTx) error {
+	if !awaiting.persisted {
+		return nil
+	}
+	err := store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		return store.SetStagedTxTx(tx, awaiting.stagedTx())
+	})
+	if err != nil {
+		return errors.Wrap(err, "error persisting transaction")
+	}
+	return nil
+}
+
+// releaseTx forgets a transaction that was committed or rolled back.
+func (s *Server) releaseTx(awaiting *awaitingTx) error {
+	s.txStore.Del(txKey(awaiting.id))
+	if err := awaiting.blob.Close(); err != nil {
+		return errors.Wrap(err, "error closing blob")
+	}
+	return s.deletePersistedTx(awaiting)
+}
+
+// reapTx rolls back an expired transaction. It is called by the
+// transaction cache, so it must not use the cache.
+func (s *Server) reapTx(awaiting *awaitingTx) {
+	err := awaiting.tx.Rollback()
+	if err == nil {
+		s.lgr.Info("reaped stale blob transaction", "tx_id", awaiting.id)
+	} else {
+		s.lgr.Error("failed to remove stale blob transaction", "err", err, "tx_id", awaiting.id)
+	}
+	if err := awaiting.blob.Close(); err != nil {
+		s.lgr.Error("error closing blob", "err", err)
+	}
+	if err := s.deletePersistedTx(awaiting); err != nil {
+		s.lgr.Error("error deleting persisted blob transaction", "err", err, "tx_id", awaiting.id)
+	}
+}
+
+func (s *Server) deletePersistedTx(awaiting *awaitingTx) error {
+	if !awaiting.persisted {
+		return nil
+	}
+	err := store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		return store.DeleteStagedTxTx(tx, awaiting.id)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error deleting persisted transaction")
+	}
+	return nil
+}
+
+// persistTxWrite stores the sectors touched by a write of n bytes at
+// off.
+func (s *Server) persistTxWrite(awaiting *awaitingTx, off int64, n int) error {
+	if !awaiting.persisted {
+		return nil
+	}
+	first := off / blob.SectorLen
+	last := (off + int64(n) - 1) / blob.SectorLen
+	sectors := make(map[uint8]blob.Sector)
+	for i := first; i <= last; i++ {
+		id := uint8(i)
+		sector, err := awaiting.tx.ReadSector(id)
+		if err != nil {
+			return errors.Wrap(err, "error reading sector")
+		}
+		sectors[id] = sector
+	}
+	return store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		for id, sector := range sectors {
+			if err := store.SetStagedTxSectorTx(tx, awaiting.id, id, sector); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Server) persistTxTruncate(awaiting *awaitingTx) error {
+	if !awaiting.persisted {
+		return nil
+	}
+	return store.WithTx(s.db, func(tx *leveldb.Transaction) error {
+		if err := store.TruncateStagedTxSectorsTx(tx, awaiting.id); err != nil {
+			return err
+		}
+		return store.SetStagedTxTx(tx, awaiting.stagedTx())
+	})
+}
+
+// restoreTxs reopens the transactions persisted before the last
+// shutdown. Their TTLs restart, so that downtime doesn't count
+// against them.
+func (s *Server) restoreTxs() error {
+	stxs, err := store.GetStagedTxs(s.db)
+	if err != nil {
+		return err
+	}
+	for _, stx := range stxs {
+		if stx.ID > s.lastTxID {
+			s.lastTxID = stx.ID
+		}
+		awaiting, err := s.restoreTx(stx)
+		if err != nil {
+			return errors.Wrapf(err, "error restoring transaction %d", stx.ID)
+		}
+		if err := s.putTx(awaiting); err != nil {
+			awaiting.tx.Rollback()
+			awaiting.blob.Close()
+			return err
+		}
+		s.lgr.Info("restored blob transaction", "tx_id", stx.ID, "name", stx.Name)
+	}
+	return nil
+}
+
+func (s *Server) restoreTx(stx *store.StagedTx) (*awaitingTx, error) {
+	sectors, err := store.GetStagedTxSectors(s.db, stx.ID)
+	if err != nil {
+		return nil, err
+	}
+	bl, err := s.bs.Open(stx.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening blob")
+	}
+	tx, err := bl.Transaction()
+	if err != nil {
+		bl.Close()
+		return nil, errors.Wrap(err, "error opening transaction")
+	}
+	if stx.Truncated {
+		err = tx.Truncate()
+	}
+	for id, sector := range sectors {
+		if err != nil {
+			break
+		}
+		err = tx.WriteSector(id, sector)
+	}
+	if err != nil {
+		tx.Rollback()
+		bl.Close()
+		return nil, errors.Wrap(err, "error replaying transaction")
+	}
+	return &awaitingTx{
+		id:        stx.ID,
+		blob:      bl,
+		tx:        tx,
+		createdAt: stx.CreatedAt,
+		persisted: true,
+		ttl:       stx.TTL,
+	}, nil
+}
+
+func (s *Server) listTxs() []*awaitingTx {
+	var out []*awaitingTx
+	s.txStore.Range(func(_ string, val interface{}) bool {
+		out = append(out, val.(*awaitingTx))
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].id < out[j].id
+	})
+	return out
+}
+
+func (s *Server) clampTxTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return s.txTTL
+	}
+	if ttl > s.maxTxTTL {
+		return s.maxTxTTL
+	}
+	return ttl
+}
@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"fnd/blob"
+	apiv1 "fnd/rpc/v1"
+	"fnd/store"
+	"fnd/testutil/mockapp"
+	"fnd/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestServer_Transactions(t *testing.T) {
+	storage, done := mockapp.CreateStorage(t)
+	defer done()
+	newServer := func() *Server {
+		srv := NewServer(&Opts{
+			DB:                  storage.DB,
+			BlobStore:           storage.BlobStore,
+			NameLocker:          util.NewMultiLocker(),
+			TransactionTTL:      time.Minute,
+			MaxTransactionTTL:   time.Hour,
+			PersistTransactions: true,
+		})
+		require.NoError(t, srv.restoreTxs())
+		return srv
+	}
+	ctx := context.Background()
+	data := []byte("hello world")
+
+	srv := newServer()
+	checkout, err := srv.Checkout(ctx, &apiv1.CheckoutReq{
+		Name:       "foo",
+		TtlSeconds: 2 * 60 * 60,
+	})
+	require.NoError(t, err)
+	_, err = srv.WriteAt(ctx, &apiv1.WriteAtReq{
+		TxID:   checkout.TxID,
+		Offset: blob.SectorLen - 5,
+		Data:   data,
+	})
+	require.NoError(t, err)
+	// requested TTLs are capped
+	txs := srv.listTxs()
+	require.Len(t, txs, 1)
+	require.Equal(t, time.Hour, txs[0].TTL())
+	_, err = srv.KeepAliveTx(ctx, &apiv1.KeepAliveTxReq{
+		TxID:       checkout.TxID,
+		TtlSeconds: 60,
+	})
+	require.NoError(t, err)
+	precommit, err := srv.PreCommit(ctx, &apiv1.PreCommitReq{
+		TxID: checkout.TxID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, txs[0].TTL())
+	// staged commits are kept for offline signing, up to the maximum
+	// TTL
+	_, err = srv.PreCommit(ctx, &apiv1.PreCommitReq{
+		TxID:   checkout.TxID,
+		Staged: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, txs[0].TTL())
+	keepAlive, err := srv.KeepAliveTx(ctx, &apiv1.KeepAliveTxReq{
+		TxID:       checkout.TxID,
+		TtlSeconds: 60,
+	})
+	require.NoError(t, err)
+	require.InDelta(t, time.Now().Add(time.Minute).Unix(), int64(keepAlive.ExpiresAt), 1)
+
+	t.Run("restores persisted transactions", func(t *testing.T) {
+		restarted := newServer()
+		txs := restarted.listTxs()
+		require.Len(t, txs, 1)
+		require.Equal(t, checkout.TxID, txs[0].id)
+		restoredPrecommit, err := restarted.PreCommit(ctx, &apiv1.PreCommitReq{
+			TxID: checkout.TxID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, precommit.MerkleRoot, restoredPrecommit.MerkleRoot)
+
+		next, err := restarted.Checkout(ctx, &apiv1.CheckoutReq{
+			Name: "bar",
+		})
+		require.NoError(t, err)
+		require.Greater(t, next.TxID, checkout.TxID)
+		_, err = restarted.AbortTx(ctx, &apiv1.AbortTxReq{
+			TxID: next.TxID,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("aborted transactions are deleted", func(t *testing.T) {
+		_, err := srv.AbortTx(ctx, &apiv1.AbortTxReq{
+			TxID: checkout.TxID,
+		})
+		require.NoError(t, err)
+		require.Empty(t, srv.listTxs())
+		_, err = srv.WriteAt(ctx, &apiv1.WriteAtReq{
+			TxID: checkout.TxID,
+			Data: data,
+		})
+		require.Error(t, err)
+		stxs, err := store.GetStagedTxs(storage.DB)
+		require.NoError(t, err)
+		require.Empty(t, stxs)
+	})
+
+	t.Run("expired transactions are deleted", func(t *testing.T) {
+		srv := newServer()
+		srv.txTTL = 50 * time.Millisecond
+		checkout, err := srv.Checkout(ctx, &apiv1.CheckoutReq{
+			Name: "foo",
+		})
+		require.NoError(t, err)
+		time.Sleep(300 * time.Millisecond)
+		_, err = srv.WriteAt(ctx, &apiv1.WriteAtReq{
+			TxID: checkout.TxID,
+			Data: data,
+		})
+		require.Error(t, err)
+		stxs, err := store.GetStagedTxs(storage.DB)
+		require.NoError(t, err)
+		require.Empty(t, stxs)
+	})
+
+	t.Run("reaped transactions are not renewed", func(t *testing.T) {
+		srv := newServer()
+		srv.txTTL = 50 * time.Millisecond
+		checkout, err := srv.Checkout(ctx, &apiv1.CheckoutReq{
+			Name: "foo",
+		})
+		require.NoError(t, err)
+		awaiting := srv.listTxs()[0]
+		time.Sleep(300 * time.Millisecond)
+		require.Error(t, srv.renewTx(awaiting, time.Minute))
+		require.Empty(t, srv.listTxs())
+		_, err = srv.KeepAliveTx(ctx, &apiv1.KeepAliveTxReq{
+			TxID:       checkout.TxID,
+			TtlSeconds: 60,
+		})
+		require.Error(t, err)
+	})
+}
@@ -151,8 +151,9 @@ type GetNamesReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Start  string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count  uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
 }
 
 func (x *GetNamesReq) Reset() {
@@ -201,13 +202,21 @@ func (x *GetNamesReq) GetCount() uint32 {
 	return 0
 }
 
+func (x *GetNamesReq) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
 type GetNamesRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	PublicKey []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey    []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	ImportHeight uint32 `protobuf:"varint,3,opt,name=importHeight,proto3" json:"importHeight,omitempty"`
 }
 
 func (x *GetNamesRes) Reset() {
@@ -256,18 +265,23 @@ func (x *GetNamesRes) GetPublicKey() []byte {
 	return nil
 }
 
-type AddPeerReq struct {
+func (x *GetNamesRes) GetImportHeight() uint32 {
+	if x != nil {
+		return x.ImportHeight
+	}
+	return 0
+}
+
+type NameInfoReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PeerID       []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
-	Ip           string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	VerifyPeerID bool   `protobuf:"varint,3,opt,name=verifyPeerID,proto3" json:"verifyPeerID,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *AddPeerReq) Reset() {
-	*x = AddPeerReq{}
+func (x *NameInfoReq) Reset() {
+	*x = NameInfoReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -275,13 +289,13 @@ func (x *AddPeerReq) Reset() {
 	}
 }
 
-func (x *AddPeerReq) String() string {
+func (x *NameInfoReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddPeerReq) ProtoMessage() {}
+func (*NameInfoReq) ProtoMessage() {}
 
-func (x *AddPeerReq) ProtoReflect() protoreflect.Message {
+func (x *NameInfoReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -293,43 +307,29 @@ func (x *AddPeerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddPeerReq.ProtoReflect.Descriptor instead.
-func (*AddPeerReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use NameInfoReq.ProtoReflect.Descriptor instead.
+func (*NameInfoReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AddPeerReq) GetPeerID() []byte {
-	if x != nil {
-		return x.PeerID
-	}
-	return nil
-}
-
-func (x *AddPeerReq) GetIp() string {
+func (x *NameInfoReq) GetName() string {
 	if x != nil {
-		return x.Ip
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AddPeerReq) GetVerifyPeerID() bool {
-	if x != nil {
-		return x.VerifyPeerID
-	}
-	return false
-}
-
-type BanPeerReq struct {
+type NameImportStatusRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip         string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	DurationMS uint32 `protobuf:"varint,2,opt,name=durationMS,proto3" json:"durationMS,omitempty"`
+	LastImportHeight      uint32 `protobuf:"varint,1,opt,name=lastImportHeight,proto3" json:"lastImportHeight,omitempty"`
+	InitialImportComplete bool   `protobuf:"varint,2,opt,name=initialImportComplete,proto3" json:"initialImportComplete,omitempty"`
 }
 
-func (x *BanPeerReq) Reset() {
-	*x = BanPeerReq{}
+func (x *NameImportStatusRes) Reset() {
+	*x = NameImportStatusRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -337,13 +337,13 @@ func (x *BanPeerReq) Reset() {
 	}
 }
 
-func (x *BanPeerReq) String() string {
+func (x *NameImportStatusRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BanPeerReq) ProtoMessage() {}
+func (*NameImportStatusRes) ProtoMessage() {}
 
-func (x *BanPeerReq) ProtoReflect() protoreflect.Message {
+func (x *NameImportStatusRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -355,35 +355,35 @@ func (x *BanPeerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BanPeerReq.ProtoReflect.Descriptor instead.
-func (*BanPeerReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use NameImportStatusRes.ProtoReflect.Descriptor instead.
+func (*NameImportStatusRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *BanPeerReq) GetIp() string {
+func (x *NameImportStatusRes) GetLastImportHeight() uint32 {
 	if x != nil {
-		return x.Ip
+		return x.LastImportHeight
 	}
-	return ""
+	return 0
 }
 
-func (x *BanPeerReq) GetDurationMS() uint32 {
+func (x *NameImportStatusRes) GetInitialImportComplete() bool {
 	if x != nil {
-		return x.DurationMS
+		return x.InitialImportComplete
 	}
-	return 0
+	return false
 }
 
-type UnbanPeerReq struct {
+type BanNameReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *UnbanPeerReq) Reset() {
-	*x = UnbanPeerReq{}
+func (x *BanNameReq) Reset() {
+	*x = BanNameReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,13 +391,13 @@ func (x *UnbanPeerReq) Reset() {
 	}
 }
 
-func (x *UnbanPeerReq) String() string {
+func (x *BanNameReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnbanPeerReq) ProtoMessage() {}
+func (*BanNameReq) ProtoMessage() {}
 
-func (x *UnbanPeerReq) ProtoReflect() protoreflect.Message {
+func (x *BanNameReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,26 +409,28 @@ func (x *UnbanPeerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnbanPeerReq.ProtoReflect.Descriptor instead.
-func (*UnbanPeerReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use BanNameReq.ProtoReflect.Descriptor instead.
+func (*BanNameReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *UnbanPeerReq) GetIp() string {
+func (x *BanNameReq) GetName() string {
 	if x != nil {
-		return x.Ip
+		return x.Name
 	}
 	return ""
 }
 
-type ListPeersReq struct {
+type UnbanNameReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *ListPeersReq) Reset() {
-	*x = ListPeersReq{}
+func (x *UnbanNameReq) Reset() {
+	*x = UnbanNameReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,13 +438,13 @@ func (x *ListPeersReq) Reset() {
 	}
 }
 
-func (x *ListPeersReq) String() string {
+func (x *UnbanNameReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPeersReq) ProtoMessage() {}
+func (*UnbanNameReq) ProtoMessage() {}
 
-func (x *ListPeersReq) ProtoReflect() protoreflect.Message {
+func (x *UnbanNameReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -454,27 +456,29 @@ func (x *ListPeersReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPeersReq.ProtoReflect.Descriptor instead.
-func (*ListPeersReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnbanNameReq.ProtoReflect.Descriptor instead.
+func (*UnbanNameReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{7}
 }
 
-type ListPeersRes struct {
+func (x *UnbanNameReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type NameBanRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PeerID      []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
-	Ip          string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Banned      bool   `protobuf:"varint,3,opt,name=banned,proto3" json:"banned,omitempty"`
-	Connected   bool   `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`
-	TxBytes     uint64 `protobuf:"varint,5,opt,name=txBytes,proto3" json:"txBytes,omitempty"`
-	RxBytes     uint64 `protobuf:"varint,6,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
-	Whitelisted bool   `protobuf:"varint,7,opt,name=whitelisted,proto3" json:"whitelisted,omitempty"`
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Sources []string `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
 }
 
-func (x *ListPeersRes) Reset() {
-	*x = ListPeersRes{}
+func (x *NameBanRes) Reset() {
+	*x = NameBanRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -482,13 +486,13 @@ func (x *ListPeersRes) Reset() {
 	}
 }
 
-func (x *ListPeersRes) String() string {
+func (x *NameBanRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPeersRes) ProtoMessage() {}
+func (*NameBanRes) ProtoMessage() {}
 
-func (x *ListPeersRes) ProtoReflect() protoreflect.Message {
+func (x *NameBanRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -500,70 +504,36 @@ func (x *ListPeersRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPeersRes.ProtoReflect.Descriptor instead.
-func (*ListPeersRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use NameBanRes.ProtoReflect.Descriptor instead.
+func (*NameBanRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListPeersRes) GetPeerID() []byte {
-	if x != nil {
-		return x.PeerID
-	}
-	return nil
-}
-
-func (x *ListPeersRes) GetIp() string {
+func (x *NameBanRes) GetName() string {
 	if x != nil {
-		return x.Ip
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ListPeersRes) GetBanned() bool {
-	if x != nil {
-		return x.Banned
-	}
-	return false
-}
-
-func (x *ListPeersRes) GetConnected() bool {
-	if x != nil {
-		return x.Connected
-	}
-	return false
-}
-
-func (x *ListPeersRes) GetTxBytes() uint64 {
-	if x != nil {
-		return x.TxBytes
-	}
-	return 0
-}
-
-func (x *ListPeersRes) GetRxBytes() uint64 {
-	if x != nil {
-		return x.RxBytes
-	}
-	return 0
-}
-
-func (x *ListPeersRes) GetWhitelisted() bool {
+func (x *NameBanRes) GetSources() []string {
 	if x != nil {
-		return x.Whitelisted
+		return x.Sources
 	}
-	return false
+	return nil
 }
 
-type CheckoutReq struct {
+type BlockedContentRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SectorIDs []uint32 `protobuf:"varint,2,rep,packed,name=sectorIDs,proto3" json:"sectorIDs,omitempty"`
 }
 
-func (x *CheckoutReq) Reset() {
-	*x = CheckoutReq{}
+func (x *BlockedContentRes) Reset() {
+	*x = BlockedContentRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -571,13 +541,13 @@ func (x *CheckoutReq) Reset() {
 	}
 }
 
-func (x *CheckoutReq) String() string {
+func (x *BlockedContentRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutReq) ProtoMessage() {}
+func (*BlockedContentRes) ProtoMessage() {}
 
-func (x *CheckoutReq) ProtoReflect() protoreflect.Message {
+func (x *BlockedContentRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -589,28 +559,36 @@ func (x *CheckoutReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutReq.ProtoReflect.Descriptor instead.
-func (*CheckoutReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlockedContentRes.ProtoReflect.Descriptor instead.
+func (*BlockedContentRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CheckoutReq) GetName() string {
+func (x *BlockedContentRes) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-type CheckoutRes struct {
+func (x *BlockedContentRes) GetSectorIDs() []uint32 {
+	if x != nil {
+		return x.SectorIDs
+	}
+	return nil
+}
+
+type ListLogLevelsRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxID uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Level   string            `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Modules []*ModuleLogLevel `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
 }
 
-func (x *CheckoutRes) Reset() {
-	*x = CheckoutRes{}
+func (x *ListLogLevelsRes) Reset() {
+	*x = ListLogLevelsRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -618,13 +596,13 @@ func (x *CheckoutRes) Reset() {
 	}
 }
 
-func (x *CheckoutRes) String() string {
+func (x *ListLogLevelsRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutRes) ProtoMessage() {}
+func (*ListLogLevelsRes) ProtoMessage() {}
 
-func (x *CheckoutRes) ProtoReflect() protoreflect.Message {
+func (x *ListLogLevelsRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -636,30 +614,37 @@ func (x *CheckoutRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutRes.ProtoReflect.Descriptor instead.
-func (*CheckoutRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListLogLevelsRes.ProtoReflect.Descriptor instead.
+func (*ListLogLevelsRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CheckoutRes) GetTxID() uint32 {
+func (x *ListLogLevelsRes) GetLevel() string {
 	if x != nil {
-		return x.TxID
+		return x.Level
 	}
-	return 0
+	return ""
 }
 
-type WriteAtReq struct {
+func (x *ListLogLevelsRes) GetModules() []*ModuleLogLevel {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type ModuleLogLevel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxID   uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
-	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Module     string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Level      string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Overridden bool   `protobuf:"varint,3,opt,name=overridden,proto3" json:"overridden,omitempty"`
 }
 
-func (x *WriteAtReq) Reset() {
-	*x = WriteAtReq{}
+func (x *ModuleLogLevel) Reset() {
+	*x = ModuleLogLevel{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,13 +652,13 @@ func (x *WriteAtReq) Reset() {
 	}
 }
 
-func (x *WriteAtReq) String() string {
+func (x *ModuleLogLevel) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteAtReq) ProtoMessage() {}
+func (*ModuleLogLevel) ProtoMessage() {}
 
-func (x *WriteAtReq) ProtoReflect() protoreflect.Message {
+func (x *ModuleLogLevel) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -685,43 +670,43 @@ func (x *WriteAtReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteAtReq.ProtoReflect.Descriptor instead.
-func (*WriteAtReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ModuleLogLevel.ProtoReflect.Descriptor instead.
+func (*ModuleLogLevel) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *WriteAtReq) GetTxID() uint32 {
+func (x *ModuleLogLevel) GetModule() string {
 	if x != nil {
-		return x.TxID
+		return x.Module
 	}
-	return 0
+	return ""
 }
 
-func (x *WriteAtReq) GetOffset() uint32 {
+func (x *ModuleLogLevel) GetLevel() string {
 	if x != nil {
-		return x.Offset
+		return x.Level
 	}
-	return 0
+	return ""
 }
 
-func (x *WriteAtReq) GetData() []byte {
+func (x *ModuleLogLevel) GetOverridden() bool {
 	if x != nil {
-		return x.Data
+		return x.Overridden
 	}
-	return nil
+	return false
 }
 
-type WriteAtRes struct {
+type SetLogLevelReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BytesWritten uint32 `protobuf:"varint,1,opt,name=bytesWritten,proto3" json:"bytesWritten,omitempty"`
-	WriteErr     string `protobuf:"bytes,2,opt,name=writeErr,proto3" json:"writeErr,omitempty"`
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Level  string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
 }
 
-func (x *WriteAtRes) Reset() {
-	*x = WriteAtRes{}
+func (x *SetLogLevelReq) Reset() {
+	*x = SetLogLevelReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -729,13 +714,13 @@ func (x *WriteAtRes) Reset() {
 	}
 }
 
-func (x *WriteAtRes) String() string {
+func (x *SetLogLevelReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteAtRes) ProtoMessage() {}
+func (*SetLogLevelReq) ProtoMessage() {}
 
-func (x *WriteAtRes) ProtoReflect() protoreflect.Message {
+func (x *SetLogLevelReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -747,35 +732,36 @@ func (x *WriteAtRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteAtRes.ProtoReflect.Descriptor instead.
-func (*WriteAtRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetLogLevelReq.ProtoReflect.Descriptor instead.
+func (*SetLogLevelReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *WriteAtRes) GetBytesWritten() uint32 {
+func (x *SetLogLevelReq) GetModule() string {
 	if x != nil {
-		return x.BytesWritten
+		return x.Module
 	}
-	return 0
+	return ""
 }
 
-func (x *WriteAtRes) GetWriteErr() string {
+func (x *SetLogLevelReq) GetLevel() string {
 	if x != nil {
-		return x.WriteErr
+		return x.Level
 	}
 	return ""
 }
 
-type TruncateReq struct {
+type ReloadConfigRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxID uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Applied         []string `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+	RestartRequired []string `protobuf:"bytes,2,rep,name=restartRequired,proto3" json:"restartRequired,omitempty"`
 }
 
-func (x *TruncateReq) Reset() {
-	*x = TruncateReq{}
+func (x *ReloadConfigRes) Reset() {
+	*x = ReloadConfigRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -783,13 +769,13 @@ func (x *TruncateReq) Reset() {
 	}
 }
 
-func (x *TruncateReq) String() string {
+func (x *ReloadConfigRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TruncateReq) ProtoMessage() {}
+func (*ReloadConfigRes) ProtoMessage() {}
 
-func (x *TruncateReq) ProtoReflect() protoreflect.Message {
+func (x *ReloadConfigRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -801,26 +787,35 @@ func (x *TruncateReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TruncateReq.ProtoReflect.Descriptor instead.
-func (*TruncateReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReloadConfigRes.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *TruncateReq) GetTxID() uint32 {
+func (x *ReloadConfigRes) GetApplied() []string {
 	if x != nil {
-		return x.TxID
+		return x.Applied
 	}
-	return 0
+	return nil
 }
 
-type TruncateRes struct {
+func (x *ReloadConfigRes) GetRestartRequired() []string {
+	if x != nil {
+		return x.RestartRequired
+	}
+	return nil
+}
+
+type BackupChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *TruncateRes) Reset() {
-	*x = TruncateRes{}
+func (x *BackupChunk) Reset() {
+	*x = BackupChunk{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -828,13 +823,13 @@ func (x *TruncateRes) Reset() {
 	}
 }
 
-func (x *TruncateRes) String() string {
+func (x *BackupChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TruncateRes) ProtoMessage() {}
+func (*BackupChunk) ProtoMessage() {}
 
-func (x *TruncateRes) ProtoReflect() protoreflect.Message {
+func (x *BackupChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -846,21 +841,31 @@ func (x *TruncateRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TruncateRes.ProtoReflect.Descriptor instead.
-func (*TruncateRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use BackupChunk.ProtoReflect.Descriptor instead.
+func (*BackupChunk) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{14}
 }
 
-type PreCommitReq struct {
+func (x *BackupChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AddPeerReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxID uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	PeerID       []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Ip           string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	VerifyPeerID bool   `protobuf:"varint,3,opt,name=verifyPeerID,proto3" json:"verifyPeerID,omitempty"`
+	Port         uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
 }
 
-func (x *PreCommitReq) Reset() {
-	*x = PreCommitReq{}
+func (x *AddPeerReq) Reset() {
+	*x = AddPeerReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -868,13 +873,13 @@ func (x *PreCommitReq) Reset() {
 	}
 }
 
-func (x *PreCommitReq) String() string {
+func (x *AddPeerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PreCommitReq) ProtoMessage() {}
+func (*AddPeerReq) ProtoMessage() {}
 
-func (x *PreCommitReq) ProtoReflect() protoreflect.Message {
+func (x *AddPeerReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -886,28 +891,50 @@ func (x *PreCommitReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PreCommitReq.ProtoReflect.Descriptor instead.
-func (*PreCommitReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddPeerReq.ProtoReflect.Descriptor instead.
+func (*AddPeerReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *PreCommitReq) GetTxID() uint32 {
+func (x *AddPeerReq) GetPeerID() []byte {
 	if x != nil {
-		return x.TxID
+		return x.PeerID
+	}
+	return nil
+}
+
+func (x *AddPeerReq) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AddPeerReq) GetVerifyPeerID() bool {
+	if x != nil {
+		return x.VerifyPeerID
+	}
+	return false
+}
+
+func (x *AddPeerReq) GetPort() uint32 {
+	if x != nil {
+		return x.Port
 	}
 	return 0
 }
 
-type PreCommitRes struct {
+type BanPeerReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MerkleRoot []byte `protobuf:"bytes,1,opt,name=merkleRoot,proto3" json:"merkleRoot,omitempty"`
+	Ip         string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	DurationMS uint32 `protobuf:"varint,2,opt,name=durationMS,proto3" json:"durationMS,omitempty"`
 }
 
-func (x *PreCommitRes) Reset() {
-	*x = PreCommitRes{}
+func (x *BanPeerReq) Reset() {
+	*x = BanPeerReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -915,13 +942,13 @@ func (x *PreCommitRes) Reset() {
 	}
 }
 
-func (x *PreCommitRes) String() string {
+func (x *BanPeerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PreCommitRes) ProtoMessage() {}
+func (*BanPeerReq) ProtoMessage() {}
 
-func (x *PreCommitRes) ProtoReflect() protoreflect.Message {
+func (x *BanPeerReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,31 +960,35 @@ func (x *PreCommitRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PreCommitRes.ProtoReflect.Descriptor instead.
-func (*PreCommitRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use BanPeerReq.ProtoReflect.Descriptor instead.
+func (*BanPeerReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *PreCommitRes) GetMerkleRoot() []byte {
+func (x *BanPeerReq) GetIp() string {
 	if x != nil {
-		return x.MerkleRoot
+		return x.Ip
 	}
-	return nil
+	return ""
 }
 
-type CommitReq struct {
+func (x *BanPeerReq) GetDurationMS() uint32 {
+	if x != nil {
+		return x.DurationMS
+	}
+	return 0
+}
+
+type UnbanPeerReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxID      uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
-	Timestamp uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
-	Broadcast bool   `protobuf:"varint,4,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+	Ip string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 }
 
-func (x *CommitReq) Reset() {
-	*x = CommitReq{}
+func (x *UnbanPeerReq) Reset() {
+	*x = UnbanPeerReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -965,13 +996,13 @@ func (x *CommitReq) Reset() {
 	}
 }
 
-func (x *CommitReq) String() string {
+func (x *UnbanPeerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitReq) ProtoMessage() {}
+func (*UnbanPeerReq) ProtoMessage() {}
 
-func (x *CommitReq) ProtoReflect() protoreflect.Message {
+func (x *UnbanPeerReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -983,47 +1014,26 @@ func (x *CommitReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitReq.ProtoReflect.Descriptor instead.
-func (*CommitReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnbanPeerReq.ProtoReflect.Descriptor instead.
+func (*UnbanPeerReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CommitReq) GetTxID() uint32 {
-	if x != nil {
-		return x.TxID
-	}
-	return 0
-}
-
-func (x *CommitReq) GetTimestamp() uint64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-func (x *CommitReq) GetSignature() []byte {
-	if x != nil {
-		return x.Signature
-	}
-	return nil
-}
-
-func (x *CommitReq) GetBroadcast() bool {
+func (x *UnbanPeerReq) GetIp() string {
 	if x != nil {
-		return x.Broadcast
+		return x.Ip
 	}
-	return false
+	return ""
 }
 
-type CommitRes struct {
+type ListPeersReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *CommitRes) Reset() {
-	*x = CommitRes{}
+func (x *ListPeersReq) Reset() {
+	*x = ListPeersReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1031,13 +1041,13 @@ func (x *CommitRes) Reset() {
 	}
 }
 
-func (x *CommitRes) String() string {
+func (x *ListPeersReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitRes) ProtoMessage() {}
+func (*ListPeersReq) ProtoMessage() {}
 
-func (x *CommitRes) ProtoReflect() protoreflect.Message {
+func (x *ListPeersReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1049,23 +1059,30 @@ func (x *CommitRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitRes.ProtoReflect.Descriptor instead.
-func (*CommitRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListPeersReq.ProtoReflect.Descriptor instead.
+func (*ListPeersReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{18}
 }
 
-type ReadAtReq struct {
+type ListPeersRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Len    uint32 `protobuf:"varint,3,opt,name=len,proto3" json:"len,omitempty"`
+	PeerID      []byte          `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Ip          string          `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Banned      bool            `protobuf:"varint,3,opt,name=banned,proto3" json:"banned,omitempty"`
+	Connected   bool            `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`
+	TxBytes     uint64          `protobuf:"varint,5,opt,name=txBytes,proto3" json:"txBytes,omitempty"`
+	RxBytes     uint64          `protobuf:"varint,6,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
+	Whitelisted bool            `protobuf:"varint,7,opt,name=whitelisted,proto3" json:"whitelisted,omitempty"`
+	Port        uint32          `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	Usage       []*MessageUsage `protobuf:"bytes,9,rep,name=usage,proto3" json:"usage,omitempty"`
+	Services    uint64          `protobuf:"varint,10,opt,name=services,proto3" json:"services,omitempty"`
 }
 
-func (x *ReadAtReq) Reset() {
-	*x = ReadAtReq{}
+func (x *ListPeersRes) Reset() {
+	*x = ListPeersRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1073,13 +1090,13 @@ func (x *ReadAtReq) Reset() {
 	}
 }
 
-func (x *ReadAtReq) String() string {
+func (x *ListPeersRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadAtReq) ProtoMessage() {}
+func (*ListPeersRes) ProtoMessage() {}
 
-func (x *ReadAtReq) ProtoReflect() protoreflect.Message {
+func (x *ListPeersRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1091,43 +1108,94 @@ func (x *ReadAtReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadAtReq.ProtoReflect.Descriptor instead.
-func (*ReadAtReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListPeersRes.ProtoReflect.Descriptor instead.
+func (*ListPeersRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ReadAtReq) GetName() string {
+func (x *ListPeersRes) GetPeerID() []byte {
 	if x != nil {
-		return x.Name
+		return x.PeerID
+	}
+	return nil
+}
+
+func (x *ListPeersRes) GetIp() string {
+	if x != nil {
+		return x.Ip
 	}
 	return ""
 }
 
-func (x *ReadAtReq) GetOffset() uint32 {
+func (x *ListPeersRes) GetBanned() bool {
 	if x != nil {
-		return x.Offset
+		return x.Banned
+	}
+	return false
+}
+
+func (x *ListPeersRes) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *ListPeersRes) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
 	}
 	return 0
 }
 
-func (x *ReadAtReq) GetLen() uint32 {
+func (x *ListPeersRes) GetRxBytes() uint64 {
 	if x != nil {
-		return x.Len
+		return x.RxBytes
 	}
 	return 0
 }
 
-type ReadAtRes struct {
+func (x *ListPeersRes) GetWhitelisted() bool {
+	if x != nil {
+		return x.Whitelisted
+	}
+	return false
+}
+
+func (x *ListPeersRes) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListPeersRes) GetUsage() []*MessageUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *ListPeersRes) GetServices() uint64 {
+	if x != nil {
+		return x.Services
+	}
+	return 0
+}
+
+type MessageUsage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Offset uint32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
-	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MessageType string `protobuf:"bytes,1,opt,name=messageType,proto3" json:"messageType,omitempty"`
+	Received    uint64 `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	Dropped     uint64 `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	RxBytes     uint64 `protobuf:"varint,4,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
 }
 
-func (x *ReadAtRes) Reset() {
-	*x = ReadAtRes{}
+func (x *MessageUsage) Reset() {
+	*x = MessageUsage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1135,13 +1203,13 @@ func (x *ReadAtRes) Reset() {
 	}
 }
 
-func (x *ReadAtRes) String() string {
+func (x *MessageUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadAtRes) ProtoMessage() {}
+func (*MessageUsage) ProtoMessage() {}
 
-func (x *ReadAtRes) ProtoReflect() protoreflect.Message {
+func (x *MessageUsage) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1153,35 +1221,50 @@ func (x *ReadAtRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadAtRes.ProtoReflect.Descriptor instead.
-func (*ReadAtRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use MessageUsage.ProtoReflect.Descriptor instead.
+func (*MessageUsage) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ReadAtRes) GetOffset() uint32 {
+func (x *MessageUsage) GetMessageType() string {
 	if x != nil {
-		return x.Offset
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *MessageUsage) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
 	}
 	return 0
 }
 
-func (x *ReadAtRes) GetData() []byte {
+func (x *MessageUsage) GetDropped() uint64 {
 	if x != nil {
-		return x.Data
+		return x.Dropped
 	}
-	return nil
+	return 0
 }
 
-type BlobInfoReq struct {
+func (x *MessageUsage) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+type CheckoutReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TtlSeconds uint32 `protobuf:"varint,2,opt,name=ttlSeconds,proto3" json:"ttlSeconds,omitempty"`
 }
 
-func (x *BlobInfoReq) Reset() {
-	*x = BlobInfoReq{}
+func (x *CheckoutReq) Reset() {
+	*x = CheckoutReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1189,13 +1272,13 @@ func (x *BlobInfoReq) Reset() {
 	}
 }
 
-func (x *BlobInfoReq) String() string {
+func (x *CheckoutReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobInfoReq) ProtoMessage() {}
+func (*CheckoutReq) ProtoMessage() {}
 
-func (x *BlobInfoReq) ProtoReflect() protoreflect.Message {
+func (x *CheckoutReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1207,28 +1290,36 @@ func (x *BlobInfoReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobInfoReq.ProtoReflect.Descriptor instead.
-func (*BlobInfoReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckoutReq.ProtoReflect.Descriptor instead.
+func (*CheckoutReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *BlobInfoReq) GetName() string {
+func (x *CheckoutReq) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-type ListBlobInfoReq struct {
+func (x *CheckoutReq) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type CheckoutRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	TxID      uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	ExpiresAt uint64 `protobuf:"varint,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 }
 
-func (x *ListBlobInfoReq) Reset() {
-	*x = ListBlobInfoReq{}
+func (x *CheckoutRes) Reset() {
+	*x = CheckoutRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1236,13 +1327,13 @@ func (x *ListBlobInfoReq) Reset() {
 	}
 }
 
-func (x *ListBlobInfoReq) String() string {
+func (x *CheckoutRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBlobInfoReq) ProtoMessage() {}
+func (*CheckoutRes) ProtoMessage() {}
 
-func (x *ListBlobInfoReq) ProtoReflect() protoreflect.Message {
+func (x *CheckoutRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1254,36 +1345,37 @@ func (x *ListBlobInfoReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBlobInfoReq.ProtoReflect.Descriptor instead.
-func (*ListBlobInfoReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckoutRes.ProtoReflect.Descriptor instead.
+func (*CheckoutRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ListBlobInfoReq) GetStart() string {
+func (x *CheckoutRes) GetTxID() uint32 {
 	if x != nil {
-		return x.Start
+		return x.TxID
 	}
-	return ""
+	return 0
 }
 
-type BlobInfoRes struct {
+func (x *CheckoutRes) GetExpiresAt() uint64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type WriteAtReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	PublicKey    []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
-	ImportHeight uint32 `protobuf:"varint,3,opt,name=importHeight,proto3" json:"importHeight,omitempty"`
-	Timestamp    uint64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	MerkleRoot   []byte `protobuf:"bytes,5,opt,name=merkleRoot,proto3" json:"merkleRoot,omitempty"`
-	ReservedRoot []byte `protobuf:"bytes,6,opt,name=reservedRoot,proto3" json:"reservedRoot,omitempty"`
-	ReceivedAt   uint64 `protobuf:"varint,7,opt,name=receivedAt,proto3" json:"receivedAt,omitempty"`
-	Signature    []byte `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
-	Timebank     uint32 `protobuf:"varint,9,opt,name=timebank,proto3" json:"timebank,omitempty"`
+	TxID   uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *BlobInfoRes) Reset() {
-	*x = BlobInfoRes{}
+func (x *WriteAtReq) Reset() {
+	*x = WriteAtReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1291,13 +1383,13 @@ func (x *BlobInfoRes) Reset() {
 	}
 }
 
-func (x *BlobInfoRes) String() string {
+func (x *WriteAtReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobInfoRes) ProtoMessage() {}
+func (*WriteAtReq) ProtoMessage() {}
 
-func (x *BlobInfoRes) ProtoReflect() protoreflect.Message {
+func (x *WriteAtReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1309,84 +1401,43 @@ func (x *BlobInfoRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobInfoRes.ProtoReflect.Descriptor instead.
-func (*BlobInfoRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use WriteAtReq.ProtoReflect.Descriptor instead.
+func (*WriteAtReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *BlobInfoRes) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *BlobInfoRes) GetPublicKey() []byte {
-	if x != nil {
-		return x.PublicKey
-	}
-	return nil
-}
-
-func (x *BlobInfoRes) GetImportHeight() uint32 {
-	if x != nil {
-		return x.ImportHeight
-	}
-	return 0
-}
-
-func (x *BlobInfoRes) GetTimestamp() uint64 {
+func (x *WriteAtReq) GetTxID() uint32 {
 	if x != nil {
-		return x.Timestamp
+		return x.TxID
 	}
 	return 0
 }
 
-func (x *BlobInfoRes) GetMerkleRoot() []byte {
-	if x != nil {
-		return x.MerkleRoot
-	}
-	return nil
-}
-
-func (x *BlobInfoRes) GetReservedRoot() []byte {
-	if x != nil {
-		return x.ReservedRoot
-	}
-	return nil
-}
-
-func (x *BlobInfoRes) GetReceivedAt() uint64 {
+func (x *WriteAtReq) GetOffset() uint32 {
 	if x != nil {
-		return x.ReceivedAt
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *BlobInfoRes) GetSignature() []byte {
+func (x *WriteAtReq) GetData() []byte {
 	if x != nil {
-		return x.Signature
+		return x.Data
 	}
 	return nil
 }
 
-func (x *BlobInfoRes) GetTimebank() uint32 {
-	if x != nil {
-		return x.Timebank
-	}
-	return 0
-}
-
-type SendUpdateReq struct {
+type WriteAtRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BytesWritten uint32 `protobuf:"varint,1,opt,name=bytesWritten,proto3" json:"bytesWritten,omitempty"`
+	WriteErr     string `protobuf:"bytes,2,opt,name=writeErr,proto3" json:"writeErr,omitempty"`
 }
 
-func (x *SendUpdateReq) Reset() {
-	*x = SendUpdateReq{}
+func (x *WriteAtRes) Reset() {
+	*x = WriteAtRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1394,13 +1445,13 @@ func (x *SendUpdateReq) Reset() {
 	}
 }
 
-func (x *SendUpdateReq) String() string {
+func (x *WriteAtRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendUpdateReq) ProtoMessage() {}
+func (*WriteAtRes) ProtoMessage() {}
 
-func (x *SendUpdateReq) ProtoReflect() protoreflect.Message {
+func (x *WriteAtRes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1412,28 +1463,35 @@ func (x *SendUpdateReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendUpdateReq.ProtoReflect.Descriptor instead.
-func (*SendUpdateReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use WriteAtRes.ProtoReflect.Descriptor instead.
+func (*WriteAtRes) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SendUpdateReq) GetName() string {
+func (x *WriteAtRes) GetBytesWritten() uint32 {
 	if x != nil {
-		return x.Name
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *WriteAtRes) GetWriteErr() string {
+	if x != nil {
+		return x.WriteErr
 	}
 	return ""
 }
 
-type SendUpdateRes struct {
+type TruncateReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RecipientCount uint32 `protobuf:"varint,1,opt,name=recipientCount,proto3" json:"recipientCount,omitempty"`
+	TxID uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
 }
 
-func (x *SendUpdateRes) Reset() {
-	*x = SendUpdateRes{}
+func (x *TruncateReq) Reset() {
+	*x = TruncateReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1441,13 +1499,13 @@ func (x *SendUpdateRes) Reset() {
 	}
 }
 
-func (x *SendUpdateRes) String() string {
+func (x *TruncateReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendUpdateRes) ProtoMessage() {}
+func (*TruncateReq) ProtoMessage() {}
 
-func (x *SendUpdateRes) ProtoReflect() protoreflect.Message {
+func (x *TruncateReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1459,268 +1517,2011 @@ func (x *SendUpdateRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendUpdateRes.ProtoReflect.Descriptor instead.
-func (*SendUpdateRes) Descriptor() ([]byte, []int) {
+// Deprecated: Use TruncateReq.ProtoReflect.Descriptor instead.
+func (*TruncateReq) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *SendUpdateRes) GetRecipientCount() uint32 {
+func (x *TruncateReq) GetTxID() uint32 {
 	if x != nil {
-		return x.RecipientCount
+		return x.TxID
 	}
 	return 0
 }
 
-var File_api_proto protoreflect.FileDescriptor
+type TruncateRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
 
-var file_api_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x9a, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a,
-	0x09, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x28, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
-	0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74,
-	0x65, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65,
-	0x73, 0x22, 0x39, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3f, 0x0a, 0x0b,
-	0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x58, 0x0a,
-	0x0a, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65,
-	0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x65, 0x65,
-	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x50, 0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x3c, 0x0a, 0x0a, 0x42, 0x61, 0x6e, 0x50, 0x65,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x4d, 0x53, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x4d, 0x53, 0x22, 0x1e, 0x0a, 0x0c, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x50, 0x65,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x0e, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65,
-	0x72, 0x73, 0x52, 0x65, 0x71, 0x22, 0xc2, 0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x16,
-	0x0a, 0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18,
-	0x0a, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x68, 0x69, 0x74,
-	0x65, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77,
-	0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x22, 0x21, 0x0a, 0x0b, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x21, 0x0a,
-	0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44,
-	0x22, 0x4c, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78,
-	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4c,
-	0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c,
-	0x62, 0x79, 0x74, 0x65, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e,
-	0x12, 0x1a, 0x0a, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x22, 0x21, 0x0a, 0x0b,
-	0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x22,
-	0x0d, 0x0a, 0x0b, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x22, 0x22,
-	0x0a, 0x0c, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78,
-	0x49, 0x44, 0x22, 0x2e, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
-	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f,
-	0x6f, 0x74, 0x22, 0x79, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74,
-	0x78, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22, 0x0b, 0x0a,
-	0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x09, 0x52, 0x65,
-	0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x03, 0x6c, 0x65, 0x6e, 0x22, 0x37, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52,
-	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x21,
-	0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x27, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x9f, 0x02, 0x0a, 0x0b, 0x42,
-	0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c,
-	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x22,
-	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x6f,
-	0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
-	0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x22, 0x23, 0x0a, 0x0d,
-	0x53, 0x65, 0x6e, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x37, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x69,
-	0x70, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0xaa, 0x04, 0x0a, 0x0a, 0x46,
-	0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x76, 0x31, 0x12, 0x22, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d,
-	0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a,
-	0x07, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0b, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x65,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1e, 0x0a,
-	0x07, 0x42, 0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0b, 0x2e, 0x42, 0x61, 0x6e, 0x50, 0x65,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x22, 0x0a,
-	0x09, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0d, 0x2e, 0x55, 0x6e, 0x62,
-	0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x12, 0x2b, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x0d,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x26,
-	0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x12, 0x0c, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x07, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41,
-	0x74, 0x12, 0x0b, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0b,
-	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x08, 0x54,
-	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x29, 0x0a,
-	0x09, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x0d, 0x2e, 0x50, 0x72, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x50, 0x72, 0x65, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x12, 0x0a, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0a,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x06, 0x52, 0x65,
-	0x61, 0x64, 0x41, 0x74, 0x12, 0x0a, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71,
-	0x1a, 0x0a, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x0b,
-	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x2e, 0x42, 0x6c,
-	0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x42,
-	0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c,
-	0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x0a, 0x53, 0x65, 0x6e,
-	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x42, 0x04, 0x5a, 0x02, 0x76, 0x31, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TruncateRes) Reset() {
+	*x = TruncateRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_api_proto_rawDescOnce sync.Once
-	file_api_proto_rawDescData = file_api_proto_rawDesc
-)
+func (x *TruncateRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_api_proto_rawDescGZIP() []byte {
-	file_api_proto_rawDescOnce.Do(func() {
-		file_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_rawDescData)
-	})
-	return file_api_proto_rawDescData
+func (*TruncateRes) ProtoMessage() {}
+
+func (x *TruncateRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
-var file_api_proto_goTypes = []interface{}{
-	(*Empty)(nil),           // 0: Empty
-	(*GetStatusRes)(nil),    // 1: GetStatusRes
-	(*GetNamesReq)(nil),     // 2: GetNamesReq
-	(*GetNamesRes)(nil),     // 3: GetNamesRes
-	(*AddPeerReq)(nil),      // 4: AddPeerReq
-	(*BanPeerReq)(nil),      // 5: BanPeerReq
-	(*UnbanPeerReq)(nil),    // 6: UnbanPeerReq
-	(*ListPeersReq)(nil),    // 7: ListPeersReq
-	(*ListPeersRes)(nil),    // 8: ListPeersRes
-	(*CheckoutReq)(nil),     // 9: CheckoutReq
-	(*CheckoutRes)(nil),     // 10: CheckoutRes
-	(*WriteAtReq)(nil),      // 11: WriteAtReq
-	(*WriteAtRes)(nil),      // 12: WriteAtRes
-	(*TruncateReq)(nil),     // 13: TruncateReq
-	(*TruncateRes)(nil),     // 14: TruncateRes
-	(*PreCommitReq)(nil),    // 15: PreCommitReq
-	(*PreCommitRes)(nil),    // 16: PreCommitRes
-	(*CommitReq)(nil),       // 17: CommitReq
-	(*CommitRes)(nil),       // 18: CommitRes
-	(*ReadAtReq)(nil),       // 19: ReadAtReq
-	(*ReadAtRes)(nil),       // 20: ReadAtRes
-	(*BlobInfoReq)(nil),     // 21: BlobInfoReq
-	(*ListBlobInfoReq)(nil), // 22: ListBlobInfoReq
-	(*BlobInfoRes)(nil),     // 23: BlobInfoRes
-	(*SendUpdateReq)(nil),   // 24: SendUpdateReq
-	(*SendUpdateRes)(nil),   // 25: SendUpdateRes
+// Deprecated: Use TruncateRes.ProtoReflect.Descriptor instead.
+func (*TruncateRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{26}
 }
-var file_api_proto_depIdxs = []int32{
-	0,  // 0: Footnotev1.GetStatus:input_type -> Empty
-	4,  // 1: Footnotev1.AddPeer:input_type -> AddPeerReq
-	5,  // 2: Footnotev1.BanPeer:input_type -> BanPeerReq
-	6,  // 3: Footnotev1.UnbanPeer:input_type -> UnbanPeerReq
-	7,  // 4: Footnotev1.ListPeers:input_type -> ListPeersReq
-	9,  // 5: Footnotev1.Checkout:input_type -> CheckoutReq
-	11, // 6: Footnotev1.WriteAt:input_type -> WriteAtReq
-	13, // 7: Footnotev1.Truncate:input_type -> TruncateReq
-	15, // 8: Footnotev1.PreCommit:input_type -> PreCommitReq
-	17, // 9: Footnotev1.Commit:input_type -> CommitReq
-	19, // 10: Footnotev1.ReadAt:input_type -> ReadAtReq
-	21, // 11: Footnotev1.GetBlobInfo:input_type -> BlobInfoReq
-	22, // 12: Footnotev1.ListBlobInfo:input_type -> ListBlobInfoReq
-	24, // 13: Footnotev1.SendUpdate:input_type -> SendUpdateReq
-	1,  // 14: Footnotev1.GetStatus:output_type -> GetStatusRes
-	0,  // 15: Footnotev1.AddPeer:output_type -> Empty
-	0,  // 16: Footnotev1.BanPeer:output_type -> Empty
-	0,  // 17: Footnotev1.UnbanPeer:output_type -> Empty
-	8,  // 18: Footnotev1.ListPeers:output_type -> ListPeersRes
-	10, // 19: Footnotev1.Checkout:output_type -> CheckoutRes
-	12, // 20: Footnotev1.WriteAt:output_type -> WriteAtRes
-	0,  // 21: Footnotev1.Truncate:output_type -> Empty
-	16, // 22: Footnotev1.PreCommit:output_type -> PreCommitRes
-	18, // 23: Footnotev1.Commit:output_type -> CommitRes
-	20, // 24: Footnotev1.ReadAt:output_type -> ReadAtRes
-	23, // 25: Footnotev1.GetBlobInfo:output_type -> BlobInfoRes
-	23, // 26: Footnotev1.ListBlobInfo:output_type -> BlobInfoRes
-	25, // 27: Footnotev1.SendUpdate:output_type -> SendUpdateRes
-	14, // [14:28] is the sub-list for method output_type
-	0,  // [0:14] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+
+type PreCommitReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxID   uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Staged bool   `protobuf:"varint,2,opt,name=staged,proto3" json:"staged,omitempty"`
 }
 
-func init() { file_api_proto_init() }
-func file_api_proto_init() {
-	if File_api_proto != nil {
-		return
+func (x *PreCommitReq) Reset() {
+	*x = PreCommitReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
+}
+
+func (x *PreCommitReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreCommitReq) ProtoMessage() {}
+
+func (x *PreCommitReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreCommitReq.ProtoReflect.Descriptor instead.
+func (*PreCommitReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PreCommitReq) GetTxID() uint32 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+func (x *PreCommitReq) GetStaged() bool {
+	if x != nil {
+		return x.Staged
+	}
+	return false
+}
+
+type PreCommitRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MerkleRoot []byte `protobuf:"bytes,1,opt,name=merkleRoot,proto3" json:"merkleRoot,omitempty"`
+}
+
+func (x *PreCommitRes) Reset() {
+	*x = PreCommitRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreCommitRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreCommitRes) ProtoMessage() {}
+
+func (x *PreCommitRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreCommitRes.ProtoReflect.Descriptor instead.
+func (*PreCommitRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PreCommitRes) GetMerkleRoot() []byte {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return nil
+}
+
+type CommitReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxID      uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Timestamp uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Broadcast bool   `protobuf:"varint,4,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+}
+
+func (x *CommitReq) Reset() {
+	*x = CommitReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReq) ProtoMessage() {}
+
+func (x *CommitReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReq.ProtoReflect.Descriptor instead.
+func (*CommitReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CommitReq) GetTxID() uint32 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+func (x *CommitReq) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CommitReq) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *CommitReq) GetBroadcast() bool {
+	if x != nil {
+		return x.Broadcast
+	}
+	return false
+}
+
+type CommitRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommitRes) Reset() {
+	*x = CommitRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitRes) ProtoMessage() {}
+
+func (x *CommitRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitRes.ProtoReflect.Descriptor instead.
+func (*CommitRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{30}
+}
+
+type KeepAliveTxReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxID       uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	TtlSeconds uint32 `protobuf:"varint,2,opt,name=ttlSeconds,proto3" json:"ttlSeconds,omitempty"`
+}
+
+func (x *KeepAliveTxReq) Reset() {
+	*x = KeepAliveTxReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeepAliveTxReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepAliveTxReq) ProtoMessage() {}
+
+func (x *KeepAliveTxReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepAliveTxReq.ProtoReflect.Descriptor instead.
+func (*KeepAliveTxReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *KeepAliveTxReq) GetTxID() uint32 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+func (x *KeepAliveTxReq) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type KeepAliveTxRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpiresAt uint64 `protobuf:"varint,1,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+}
+
+func (x *KeepAliveTxRes) Reset() {
+	*x = KeepAliveTxRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeepAliveTxRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepAliveTxRes) ProtoMessage() {}
+
+func (x *KeepAliveTxRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepAliveTxRes.ProtoReflect.Descriptor instead.
+func (*KeepAliveTxRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *KeepAliveTxRes) GetExpiresAt() uint64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type AbortTxReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxID uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+}
+
+func (x *AbortTxReq) Reset() {
+	*x = AbortTxReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortTxReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortTxReq) ProtoMessage() {}
+
+func (x *AbortTxReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortTxReq.ProtoReflect.Descriptor instead.
+func (*AbortTxReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AbortTxReq) GetTxID() uint32 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+type TxInfoRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxID             uint32 `protobuf:"varint,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	Name             string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt        uint64 `protobuf:"varint,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt        uint64 `protobuf:"varint,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	TtlSeconds       uint32 `protobuf:"varint,5,opt,name=ttlSeconds,proto3" json:"ttlSeconds,omitempty"`
+	DirtySectorCount uint32 `protobuf:"varint,6,opt,name=dirtySectorCount,proto3" json:"dirtySectorCount,omitempty"`
+	Truncated        bool   `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Persisted        bool   `protobuf:"varint,8,opt,name=persisted,proto3" json:"persisted,omitempty"`
+}
+
+func (x *TxInfoRes) Reset() {
+	*x = TxInfoRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxInfoRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxInfoRes) ProtoMessage() {}
+
+func (x *TxInfoRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxInfoRes.ProtoReflect.Descriptor instead.
+func (*TxInfoRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TxInfoRes) GetTxID() uint32 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+func (x *TxInfoRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TxInfoRes) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *TxInfoRes) GetExpiresAt() uint64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *TxInfoRes) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *TxInfoRes) GetDirtySectorCount() uint32 {
+	if x != nil {
+		return x.DirtySectorCount
+	}
+	return 0
+}
+
+func (x *TxInfoRes) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *TxInfoRes) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+type ReadAtReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Len    uint32 `protobuf:"varint,3,opt,name=len,proto3" json:"len,omitempty"`
+}
+
+func (x *ReadAtReq) Reset() {
+	*x = ReadAtReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadAtReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAtReq) ProtoMessage() {}
+
+func (x *ReadAtReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAtReq.ProtoReflect.Descriptor instead.
+func (*ReadAtReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReadAtReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadAtReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadAtReq) GetLen() uint32 {
+	if x != nil {
+		return x.Len
+	}
+	return 0
+}
+
+type ReadAtRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset uint32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ReadAtRes) Reset() {
+	*x = ReadAtRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadAtRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAtRes) ProtoMessage() {}
+
+func (x *ReadAtRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAtRes.ProtoReflect.Descriptor instead.
+func (*ReadAtRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReadAtRes) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadAtRes) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReadSectorsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StartSectorID uint32 `protobuf:"varint,2,opt,name=startSectorID,proto3" json:"startSectorID,omitempty"`
+	Count         uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ReadSectorsReq) Reset() {
+	*x = ReadSectorsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadSectorsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSectorsReq) ProtoMessage() {}
+
+func (x *ReadSectorsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSectorsReq.ProtoReflect.Descriptor instead.
+func (*ReadSectorsReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReadSectorsReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadSectorsReq) GetStartSectorID() uint32 {
+	if x != nil {
+		return x.StartSectorID
+	}
+	return 0
+}
+
+func (x *ReadSectorsReq) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReadSectorsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey    []byte          `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Timestamp    uint64          `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MerkleRoot   []byte          `protobuf:"bytes,4,opt,name=merkleRoot,proto3" json:"merkleRoot,omitempty"`
+	ReservedRoot []byte          `protobuf:"bytes,5,opt,name=reservedRoot,proto3" json:"reservedRoot,omitempty"`
+	Signature    []byte          `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Sectors      []*ProvenSector `protobuf:"bytes,7,rep,name=sectors,proto3" json:"sectors,omitempty"`
+}
+
+func (x *ReadSectorsRes) Reset() {
+	*x = ReadSectorsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadSectorsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSectorsRes) ProtoMessage() {}
+
+func (x *ReadSectorsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSectorsRes.ProtoReflect.Descriptor instead.
+func (*ReadSectorsRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReadSectorsRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadSectorsRes) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *ReadSectorsRes) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ReadSectorsRes) GetMerkleRoot() []byte {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return nil
+}
+
+func (x *ReadSectorsRes) GetReservedRoot() []byte {
+	if x != nil {
+		return x.ReservedRoot
+	}
+	return nil
+}
+
+func (x *ReadSectorsRes) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *ReadSectorsRes) GetSectors() []*ProvenSector {
+	if x != nil {
+		return x.Sectors
+	}
+	return nil
+}
+
+type ProvenSector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SectorID uint32 `protobuf:"varint,1,opt,name=sectorID,proto3" json:"sectorID,omitempty"`
+	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Proof    []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *ProvenSector) Reset() {
+	*x = ProvenSector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProvenSector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvenSector) ProtoMessage() {}
+
+func (x *ProvenSector) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvenSector.ProtoReflect.Descriptor instead.
+func (*ProvenSector) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ProvenSector) GetSectorID() uint32 {
+	if x != nil {
+		return x.SectorID
+	}
+	return 0
+}
+
+func (x *ProvenSector) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ProvenSector) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type BlobInfoReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *BlobInfoReq) Reset() {
+	*x = BlobInfoReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobInfoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInfoReq) ProtoMessage() {}
+
+func (x *BlobInfoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInfoReq.ProtoReflect.Descriptor instead.
+func (*BlobInfoReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BlobInfoReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListBlobInfoReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+}
+
+func (x *ListBlobInfoReq) Reset() {
+	*x = ListBlobInfoReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBlobInfoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlobInfoReq) ProtoMessage() {}
+
+func (x *ListBlobInfoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlobInfoReq.ProtoReflect.Descriptor instead.
+func (*ListBlobInfoReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListBlobInfoReq) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+type BlobInfoRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey    []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	ImportHeight uint32 `protobuf:"varint,3,opt,name=importHeight,proto3" json:"importHeight,omitempty"`
+	Timestamp    uint64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MerkleRoot   []byte `protobuf:"bytes,5,opt,name=merkleRoot,proto3" json:"merkleRoot,omitempty"`
+	ReservedRoot []byte `protobuf:"bytes,6,opt,name=reservedRoot,proto3" json:"reservedRoot,omitempty"`
+	ReceivedAt   uint64 `protobuf:"varint,7,opt,name=receivedAt,proto3" json:"receivedAt,omitempty"`
+	Signature    []byte `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Timebank     uint32 `protobuf:"varint,9,opt,name=timebank,proto3" json:"timebank,omitempty"`
+}
+
+func (x *BlobInfoRes) Reset() {
+	*x = BlobInfoRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobInfoRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInfoRes) ProtoMessage() {}
+
+func (x *BlobInfoRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInfoRes.ProtoReflect.Descriptor instead.
+func (*BlobInfoRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BlobInfoRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BlobInfoRes) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *BlobInfoRes) GetImportHeight() uint32 {
+	if x != nil {
+		return x.ImportHeight
+	}
+	return 0
+}
+
+func (x *BlobInfoRes) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BlobInfoRes) GetMerkleRoot() []byte {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return nil
+}
+
+func (x *BlobInfoRes) GetReservedRoot() []byte {
+	if x != nil {
+		return x.ReservedRoot
+	}
+	return nil
+}
+
+func (x *BlobInfoRes) GetReceivedAt() uint64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
+func (x *BlobInfoRes) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *BlobInfoRes) GetTimebank() uint32 {
+	if x != nil {
+		return x.Timebank
+	}
+	return 0
+}
+
+type VerifyBlobsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names  []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Repair bool     `protobuf:"varint,2,opt,name=repair,proto3" json:"repair,omitempty"`
+}
+
+func (x *VerifyBlobsReq) Reset() {
+	*x = VerifyBlobsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBlobsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBlobsReq) ProtoMessage() {}
+
+func (x *VerifyBlobsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBlobsReq.ProtoReflect.Descriptor instead.
+func (*VerifyBlobsReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *VerifyBlobsReq) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *VerifyBlobsReq) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+type VerifyBlobRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CorruptSectors []uint32 `protobuf:"varint,2,rep,packed,name=corruptSectors,proto3" json:"corruptSectors,omitempty"`
+	RootMismatch   bool     `protobuf:"varint,3,opt,name=rootMismatch,proto3" json:"rootMismatch,omitempty"`
+	BadSignature   bool     `protobuf:"varint,4,opt,name=badSignature,proto3" json:"badSignature,omitempty"`
+	Orphaned       bool     `protobuf:"varint,5,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	Banned         bool     `protobuf:"varint,6,opt,name=banned,proto3" json:"banned,omitempty"`
+	RepairQueued   bool     `protobuf:"varint,7,opt,name=repairQueued,proto3" json:"repairQueued,omitempty"`
+	Skipped        bool     `protobuf:"varint,8,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *VerifyBlobRes) Reset() {
+	*x = VerifyBlobRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBlobRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBlobRes) ProtoMessage() {}
+
+func (x *VerifyBlobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBlobRes.ProtoReflect.Descriptor instead.
+func (*VerifyBlobRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VerifyBlobRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VerifyBlobRes) GetCorruptSectors() []uint32 {
+	if x != nil {
+		return x.CorruptSectors
+	}
+	return nil
+}
+
+func (x *VerifyBlobRes) GetRootMismatch() bool {
+	if x != nil {
+		return x.RootMismatch
+	}
+	return false
+}
+
+func (x *VerifyBlobRes) GetBadSignature() bool {
+	if x != nil {
+		return x.BadSignature
+	}
+	return false
+}
+
+func (x *VerifyBlobRes) GetOrphaned() bool {
+	if x != nil {
+		return x.Orphaned
+	}
+	return false
+}
+
+func (x *VerifyBlobRes) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+func (x *VerifyBlobRes) GetRepairQueued() bool {
+	if x != nil {
+		return x.RepairQueued
+	}
+	return false
+}
+
+func (x *VerifyBlobRes) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type ExportBundleReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ExportBundleReq) Reset() {
+	*x = ExportBundleReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportBundleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBundleReq) ProtoMessage() {}
+
+func (x *ExportBundleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBundleReq.ProtoReflect.Descriptor instead.
+func (*ExportBundleReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExportBundleReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ExportBundleRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+}
+
+func (x *ExportBundleRes) Reset() {
+	*x = ExportBundleRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportBundleRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBundleRes) ProtoMessage() {}
+
+func (x *ExportBundleRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBundleRes.ProtoReflect.Descriptor instead.
+func (*ExportBundleRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportBundleRes) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type ImportBundleReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle    []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Broadcast bool   `protobuf:"varint,2,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+}
+
+func (x *ImportBundleReq) Reset() {
+	*x = ImportBundleReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportBundleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBundleReq) ProtoMessage() {}
+
+func (x *ImportBundleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBundleReq.ProtoReflect.Descriptor instead.
+func (*ImportBundleReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ImportBundleReq) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ImportBundleReq) GetBroadcast() bool {
+	if x != nil {
+		return x.Broadcast
+	}
+	return false
+}
+
+type SendUpdateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SendUpdateReq) Reset() {
+	*x = SendUpdateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendUpdateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendUpdateReq) ProtoMessage() {}
+
+func (x *SendUpdateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendUpdateReq.ProtoReflect.Descriptor instead.
+func (*SendUpdateReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SendUpdateReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SendUpdateRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecipientCount uint32 `protobuf:"varint,1,opt,name=recipientCount,proto3" json:"recipientCount,omitempty"`
+}
+
+func (x *SendUpdateRes) Reset() {
+	*x = SendUpdateRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendUpdateRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendUpdateRes) ProtoMessage() {}
+
+func (x *SendUpdateRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendUpdateRes.ProtoReflect.Descriptor instead.
+func (*SendUpdateRes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SendUpdateRes) GetRecipientCount() uint32 {
+	if x != nil {
+		return x.RecipientCount
+	}
+	return 0
+}
+
+var File_api_proto protoreflect.FileDescriptor
+
+var file_api_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x9a, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x28, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x22, 0x51, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x22, 0x63, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x21, 0x0a, 0x0b, 0x4e, 0x61, 0x6d,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x77, 0x0a, 0x13,
+	0x4e, 0x61, 0x6d, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x34, 0x0a, 0x15, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x20, 0x0a, 0x0a, 0x42, 0x61, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x55, 0x6e, 0x62, 0x61, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x0a, 0x4e,
+	0x61, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x73, 0x22, 0x53,
+	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x0e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x6f, 0x67,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x22, 0x3e, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x22, 0x55, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0x21, 0x0a, 0x0b, 0x42, 0x61,
+	0x63, 0x6b, 0x75, 0x70, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x6c, 0x0a,
+	0x0a, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x3c, 0x0a, 0x0a, 0x42,
+	0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x53, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x53, 0x22, 0x1e, 0x0a, 0x0c, 0x55, 0x6e, 0x62,
+	0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x0e, 0x0a, 0x0c, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x22, 0x97, 0x02, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65,
+	0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72,
+	0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x74, 0x6c,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74,
+	0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x3f, 0x0a, 0x0b, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x4c, 0x0a, 0x0a, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4c, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x57,
+	0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x22, 0x21, 0x0a, 0x0b, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x22, 0x0d, 0x0a, 0x0b, 0x54, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f,
+	0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x52, 0x6f, 0x6f, 0x74, 0x22, 0x79, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x74, 0x78, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22,
+	0x0b, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0e,
+	0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x78, 0x52, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x78,
+	0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x22, 0x2e, 0x0a, 0x0e, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x54,
+	0x78, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x41, 0x74, 0x22, 0x20, 0x0a, 0x0a, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x54, 0x78, 0x52, 0x65, 0x71,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x78, 0x49, 0x44, 0x22, 0xf7, 0x01, 0x0a, 0x09, 0x54, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x64, 0x69, 0x72, 0x74, 0x79, 0x53,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x64, 0x69, 0x72, 0x74, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x22, 0x49,
+	0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6c, 0x65, 0x6e, 0x22, 0x37, 0x0a, 0x09, 0x52, 0x65, 0x61,
+	0x64, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x60, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0xeb, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x73, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x07, 0x73, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x22, 0x54, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x53, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x21, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x27, 0x0a, 0x0f, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x22, 0x9f, 0x02, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65,
+	0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x22, 0x3e, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22, 0x85, 0x02, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e,
+	0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x53, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x4d, 0x69, 0x73, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x6f, 0x6f, 0x74,
+	0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x61, 0x64, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x62, 0x61, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x6e, 0x6e,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x22, 0x25,
+	0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x29, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x22, 0x47, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x62,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x65, 0x6e,
+	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x37,
+	0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0xa7, 0x0a, 0x0a, 0x0a, 0x46, 0x6f, 0x6f, 0x74,
+	0x6e, 0x6f, 0x74, 0x65, 0x76, 0x31, 0x12, 0x22, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x07, 0x41, 0x64,
+	0x64, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0b, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x07, 0x42, 0x61,
+	0x6e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0b, 0x2e, 0x42, 0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x09, 0x55, 0x6e,
+	0x62, 0x61, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0d, 0x2e, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2b,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x0d, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x26, 0x0a, 0x08, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x12, 0x0c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74,
+	0x52, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x07, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x12, 0x0b,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0b, 0x2e, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x08, 0x54, 0x72, 0x75, 0x6e,
+	0x63, 0x61, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x09, 0x50, 0x72,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x0d, 0x2e, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x50, 0x72, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x0a, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0a, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0b, 0x4b, 0x65, 0x65, 0x70, 0x41,
+	0x6c, 0x69, 0x76, 0x65, 0x54, 0x78, 0x12, 0x0f, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x54, 0x78, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c,
+	0x69, 0x76, 0x65, 0x54, 0x78, 0x52, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x07, 0x41, 0x62, 0x6f, 0x72,
+	0x74, 0x54, 0x78, 0x12, 0x0b, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x54, 0x78, 0x52, 0x65, 0x71,
+	0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x78, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x54, 0x78,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x20, 0x0a, 0x06, 0x52, 0x65, 0x61,
+	0x64, 0x41, 0x74, 0x12, 0x0a, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x71, 0x1a,
+	0x0a, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x74, 0x52, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0b, 0x52,
+	0x65, 0x61, 0x64, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x0f, 0x2e, 0x52, 0x65, 0x61,
+	0x64, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x52, 0x65,
+	0x61, 0x64, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c,
+	0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x30, 0x0a, 0x0b, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x0f, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0c, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x12,
+	0x28, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12,
+	0x10, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x0a, 0x53, 0x65, 0x6e,
+	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x12, 0x29, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12,
+	0x0c, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e,
+	0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x33, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x07, 0x42, 0x61, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x0b, 0x2e,
+	0x42, 0x61, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x22, 0x0a, 0x09, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x0d, 0x2e, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x06,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x42, 0x61, 0x6e, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0b,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x30, 0x01, 0x12, 0x32, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x30,
+	0x01, 0x12, 0x2a, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x12, 0x26, 0x0a,
+	0x0b, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0f, 0x2e, 0x53,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x06, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x12,
+	0x20, 0x0a, 0x06, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0c, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30,
+	0x01, 0x42, 0x04, 0x5a, 0x02, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proto_rawDescOnce sync.Once
+	file_api_proto_rawDescData = file_api_proto_rawDesc
+)
+
+func file_api_proto_rawDescGZIP() []byte {
+	file_api_proto_rawDescOnce.Do(func() {
+		file_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_rawDescData)
+	})
+	return file_api_proto_rawDescData
+}
+
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_api_proto_goTypes = []interface{}{
+	(*Empty)(nil),               // 0: Empty
+	(*GetStatusRes)(nil),        // 1: GetStatusRes
+	(*GetNamesReq)(nil),         // 2: GetNamesReq
+	(*GetNamesRes)(nil),         // 3: GetNamesRes
+	(*NameInfoReq)(nil),         // 4: NameInfoReq
+	(*NameImportStatusRes)(nil), // 5: NameImportStatusRes
+	(*BanNameReq)(nil),          // 6: BanNameReq
+	(*UnbanNameReq)(nil),        // 7: UnbanNameReq
+	(*NameBanRes)(nil),          // 8: NameBanRes
+	(*BlockedContentRes)(nil),   // 9: BlockedContentRes
+	(*ListLogLevelsRes)(nil),    // 10: ListLogLevelsRes
+	(*ModuleLogLevel)(nil),      // 11: ModuleLogLevel
+	(*SetLogLevelReq)(nil),      // 12: SetLogLevelReq
+	(*ReloadConfigRes)(nil),     // 13: ReloadConfigRes
+	(*BackupChunk)(nil),         // 14: BackupChunk
+	(*AddPeerReq)(nil),          // 15: AddPeerReq
+	(*BanPeerReq)(nil),          // 16: BanPeerReq
+	(*UnbanPeerReq)(nil),        // 17: UnbanPeerReq
+	(*ListPeersReq)(nil),        // 18: ListPeersReq
+	(*ListPeersRes)(nil),        // 19: ListPeersRes
+	(*MessageUsage)(nil),        // 20: MessageUsage
+	(*CheckoutReq)(nil),         // 21: CheckoutReq
+	(*CheckoutRes)(nil),         // 22: CheckoutRes
+	(*WriteAtReq)(nil),          // 23: WriteAtReq
+	(*WriteAtRes)(nil),          // 24: WriteAtRes
+	(*TruncateReq)(nil),         // 25: TruncateReq
+	(*TruncateRes)(nil),         // 26: TruncateRes
+	(*PreCommitReq)(nil),        // 27: PreCommitReq
+	(*PreCommitRes)(nil),        // 28: PreCommitRes
+	(*CommitReq)(nil),           // 29: CommitReq
+	(*CommitRes)(nil),           // 30: CommitRes
+	(*KeepAliveTxReq)(nil),      // 31: KeepAliveTxReq
+	(*KeepAliveTxRes)(nil),      // 32: KeepAliveTxRes
+	(*AbortTxReq)(nil),          // 33: AbortTxReq
+	(*TxInfoRes)(nil),           // 34: TxInfoRes
+	(*ReadAtReq)(nil),           // 35: ReadAtReq
+	(*ReadAtRes)(nil),           // 36: ReadAtRes
+	(*ReadSectorsReq)(nil),      // 37: ReadSectorsReq
+	(*ReadSectorsRes)(nil),      // 38: ReadSectorsRes
+	(*ProvenSector)(nil),        // 39: ProvenSector
+	(*BlobInfoReq)(nil),         // 40: BlobInfoReq
+	(*ListBlobInfoReq)(nil),     // 41: ListBlobInfoReq
+	(*BlobInfoRes)(nil),         // 42: BlobInfoRes
+	(*VerifyBlobsReq)(nil),      // 43: VerifyBlobsReq
+	(*VerifyBlobRes)(nil),       // 44: VerifyBlobRes
+	(*ExportBundleReq)(nil),     // 45: ExportBundleReq
+	(*ExportBundleRes)(nil),     // 46: ExportBundleRes
+	(*ImportBundleReq)(nil),     // 47: ImportBundleReq
+	(*SendUpdateReq)(nil),       // 48: SendUpdateReq
+	(*SendUpdateRes)(nil),       // 49: SendUpdateRes
+}
+var file_api_proto_depIdxs = []int32{
+	11, // 0: ListLogLevelsRes.modules:type_name -> ModuleLogLevel
+	20, // 1: ListPeersRes.usage:type_name -> MessageUsage
+	39, // 2: ReadSectorsRes.sectors:type_name -> ProvenSector
+	0,  // 3: Footnotev1.GetStatus:input_type -> Empty
+	15, // 4: Footnotev1.AddPeer:input_type -> AddPeerReq
+	16, // 5: Footnotev1.BanPeer:input_type -> BanPeerReq
+	17, // 6: Footnotev1.UnbanPeer:input_type -> UnbanPeerReq
+	18, // 7: Footnotev1.ListPeers:input_type -> ListPeersReq
+	21, // 8: Footnotev1.Checkout:input_type -> CheckoutReq
+	23, // 9: Footnotev1.WriteAt:input_type -> WriteAtReq
+	25, // 10: Footnotev1.Truncate:input_type -> TruncateReq
+	27, // 11: Footnotev1.PreCommit:input_type -> PreCommitReq
+	29, // 12: Footnotev1.Commit:input_type -> CommitReq
+	31, // 13: Footnotev1.KeepAliveTx:input_type -> KeepAliveTxReq
+	33, // 14: Footnotev1.AbortTx:input_type -> AbortTxReq
+	0,  // 15: Footnotev1.ListTxs:input_type -> Empty
+	35, // 16: Footnotev1.ReadAt:input_type -> ReadAtReq
+	37, // 17: Footnotev1.ReadSectors:input_type -> ReadSectorsReq
+	40, // 18: Footnotev1.GetBlobInfo:input_type -> BlobInfoReq
+	41, // 19: Footnotev1.ListBlobInfo:input_type -> ListBlobInfoReq
+	43, // 20: Footnotev1.VerifyBlobs:input_type -> VerifyBlobsReq
+	45, // 21: Footnotev1.ExportBundle:input_type -> ExportBundleReq
+	47, // 22: Footnotev1.ImportBundle:input_type -> ImportBundleReq
+	48, // 23: Footnotev1.SendUpdate:input_type -> SendUpdateReq
+	4,  // 24: Footnotev1.GetNameInfo:input_type -> NameInfoReq
+	2,  // 25: Footnotev1.ListNames:input_type -> GetNamesReq
+	0,  // 26: Footnotev1.GetNameImportStatus:input_type -> Empty
+	6,  // 27: Footnotev1.BanName:input_type -> BanNameReq
+	7,  // 28: Footnotev1.UnbanName:input_type -> UnbanNameReq
+	0,  // 29: Footnotev1.ListNameBans:input_type -> Empty
+	0,  // 30: Footnotev1.ListBlockedContent:input_type -> Empty
+	0,  // 31: Footnotev1.ListLogLevels:input_type -> Empty
+	12, // 32: Footnotev1.SetLogLevel:input_type -> SetLogLevelReq
+	0,  // 33: Footnotev1.ReloadConfig:input_type -> Empty
+	0,  // 34: Footnotev1.Backup:input_type -> Empty
+	1,  // 35: Footnotev1.GetStatus:output_type -> GetStatusRes
+	0,  // 36: Footnotev1.AddPeer:output_type -> Empty
+	0,  // 37: Footnotev1.BanPeer:output_type -> Empty
+	0,  // 38: Footnotev1.UnbanPeer:output_type -> Empty
+	19, // 39: Footnotev1.ListPeers:output_type -> ListPeersRes
+	22, // 40: Footnotev1.Checkout:output_type -> CheckoutRes
+	24, // 41: Footnotev1.WriteAt:output_type -> WriteAtRes
+	0,  // 42: Footnotev1.Truncate:output_type -> Empty
+	28, // 43: Footnotev1.PreCommit:output_type -> PreCommitRes
+	30, // 44: Footnotev1.Commit:output_type -> CommitRes
+	32, // 45: Footnotev1.KeepAliveTx:output_type -> KeepAliveTxRes
+	0,  // 46: Footnotev1.AbortTx:output_type -> Empty
+	34, // 47: Footnotev1.ListTxs:output_type -> TxInfoRes
+	36, // 48: Footnotev1.ReadAt:output_type -> ReadAtRes
+	38, // 49: Footnotev1.ReadSectors:output_type -> ReadSectorsRes
+	42, // 50: Footnotev1.GetBlobInfo:output_type -> BlobInfoRes
+	42, // 51: Footnotev1.ListBlobInfo:output_type -> BlobInfoRes
+	44, // 52: Footnotev1.VerifyBlobs:output_type -> VerifyBlobRes
+	46, // 53: Footnotev1.ExportBundle:output_type -> ExportBundleRes
+	0,  // 54: Footnotev1.ImportBundle:output_type -> Empty
+	49, // 55: Footnotev1.SendUpdate:output_type -> SendUpdateRes
+	3,  // 56: Footnotev1.GetNameInfo:output_type -> GetNamesRes
+	3,  // 57: Footnotev1.ListNames:output_type -> GetNamesRes
+	5,  // 58: Footnotev1.GetNameImportStatus:output_type -> NameImportStatusRes
+	0,  // 59: Footnotev1.BanName:output_type -> Empty
+	0,  // 60: Footnotev1.UnbanName:output_type -> Empty
+	8,  // 61: Footnotev1.ListNameBans:output_type -> NameBanRes
+	9,  // 62: Footnotev1.ListBlockedContent:output_type -> BlockedContentRes
+	10, // 63: Footnotev1.ListLogLevels:output_type -> ListLogLevelsRes
+	0,  // 64: Footnotev1.SetLogLevel:output_type -> Empty
+	13, // 65: Footnotev1.ReloadConfig:output_type -> ReloadConfigRes
+	14, // 66: Footnotev1.Backup:output_type -> BackupChunk
+	35, // [35:67] is the sub-list for method output_type
+	3,  // [3:35] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_init() }
+func file_api_proto_init() {
+	if File_api_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNamesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNamesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
-		file_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetStatusRes); i {
+		file_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameInfoReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1731,8 +3532,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNamesReq); i {
+		file_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameImportStatusRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1743,8 +3544,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNamesRes); i {
+		file_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BanNameReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1755,7 +3556,103 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnbanNameReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameBanRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockedContentRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLogLevelsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModuleLogLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogLevelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddPeerReq); i {
 			case 0:
 				return &v.state
@@ -1767,7 +3664,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BanPeerReq); i {
 			case 0:
 				return &v.state
@@ -1779,7 +3676,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UnbanPeerReq); i {
 			case 0:
 				return &v.state
@@ -1791,7 +3688,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListPeersReq); i {
 			case 0:
 				return &v.state
@@ -1803,7 +3700,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListPeersRes); i {
 			case 0:
 				return &v.state
@@ -1815,7 +3712,19 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckoutReq); i {
 			case 0:
 				return &v.state
@@ -1827,7 +3736,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckoutRes); i {
 			case 0:
 				return &v.state
@@ -1839,7 +3748,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteAtReq); i {
 			case 0:
 				return &v.state
@@ -1851,7 +3760,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteAtRes); i {
 			case 0:
 				return &v.state
@@ -1863,7 +3772,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TruncateReq); i {
 			case 0:
 				return &v.state
@@ -1875,7 +3784,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TruncateRes); i {
 			case 0:
 				return &v.state
@@ -1887,7 +3796,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PreCommitReq); i {
 			case 0:
 				return &v.state
@@ -1899,7 +3808,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PreCommitRes); i {
 			case 0:
 				return &v.state
@@ -1911,7 +3820,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CommitReq); i {
 			case 0:
 				return &v.state
@@ -1923,7 +3832,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CommitRes); i {
 			case 0:
 				return &v.state
@@ -1935,8 +3844,152 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadAtReq); i {
+		file_api_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveTxReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveTxRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortTxReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxInfoRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadAtReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadAtRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadSectorsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadSectorsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProvenSector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobInfoReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBlobInfoReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobInfoRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBlobsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1947,8 +4000,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadAtRes); i {
+		file_api_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBlobRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1959,8 +4012,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobInfoReq); i {
+		file_api_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportBundleReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1971,8 +4024,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListBlobInfoReq); i {
+		file_api_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportBundleRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1983,8 +4036,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobInfoRes); i {
+		file_api_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportBundleReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1995,7 +4048,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SendUpdateReq); i {
 			case 0:
 				return &v.state
@@ -2007,7 +4060,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SendUpdateRes); i {
 			case 0:
 				return &v.state
@@ -2026,7 +4079,7 @@ func file_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   26,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2062,10 +4115,28 @@ type Footnotev1Client interface {
 	Truncate(ctx context.Context, in *TruncateReq, opts ...grpc.CallOption) (*Empty, error)
 	PreCommit(ctx context.Context, in *PreCommitReq, opts ...grpc.CallOption) (*PreCommitRes, error)
 	Commit(ctx context.Context, in *CommitReq, opts ...grpc.CallOption) (*CommitRes, error)
+	KeepAliveTx(ctx context.Context, in *KeepAliveTxReq, opts ...grpc.CallOption) (*KeepAliveTxRes, error)
+	AbortTx(ctx context.Context, in *AbortTxReq, opts ...grpc.CallOption) (*Empty, error)
+	ListTxs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListTxsClient, error)
 	ReadAt(ctx context.Context, in *ReadAtReq, opts ...grpc.CallOption) (*ReadAtRes, error)
+	ReadSectors(ctx context.Context, in *ReadSectorsReq, opts ...grpc.CallOption) (*ReadSectorsRes, error)
 	GetBlobInfo(ctx context.Context, in *BlobInfoReq, opts ...grpc.CallOption) (*BlobInfoRes, error)
 	ListBlobInfo(ctx context.Context, in *ListBlobInfoReq, opts ...grpc.CallOption) (Footnotev1_ListBlobInfoClient, error)
+	VerifyBlobs(ctx context.Context, in *VerifyBlobsReq, opts ...grpc.CallOption) (Footnotev1_VerifyBlobsClient, error)
+	ExportBundle(ctx context.Context, in *ExportBundleReq, opts ...grpc.CallOption) (*ExportBundleRes, error)
+	ImportBundle(ctx context.Context, in *ImportBundleReq, opts ...grpc.CallOption) (*Empty, error)
 	SendUpdate(ctx context.Context, in *SendUpdateReq, opts ...grpc.CallOption) (*SendUpdateRes, error)
+	GetNameInfo(ctx context.Context, in *NameInfoReq, opts ...grpc.CallOption) (*GetNamesRes, error)
+	ListNames(ctx context.Context, in *GetNamesReq, opts ...grpc.CallOption) (Footnotev1_ListNamesClient, error)
+	GetNameImportStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NameImportStatusRes, error)
+	BanName(ctx context.Context, in *BanNameReq, opts ...grpc.CallOption) (*Empty, error)
+	UnbanName(ctx context.Context, in *UnbanNameReq, opts ...grpc.CallOption) (*Empty, error)
+	ListNameBans(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListNameBansClient, error)
+	ListBlockedContent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListBlockedContentClient, error)
+	ListLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLogLevelsRes, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*Empty, error)
+	ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReloadConfigRes, error)
+	Backup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_BackupClient, error)
 }
 
 type footnotev1Client struct {
@@ -2153,66 +4224,375 @@ func (c *footnotev1Client) Checkout(ctx context.Context, in *CheckoutReq, opts .
 	return out, nil
 }
 
-func (c *footnotev1Client) WriteAt(ctx context.Context, in *WriteAtReq, opts ...grpc.CallOption) (*WriteAtRes, error) {
-	out := new(WriteAtRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/WriteAt", in, out, opts...)
-	if err != nil {
+func (c *footnotev1Client) WriteAt(ctx context.Context, in *WriteAtReq, opts ...grpc.CallOption) (*WriteAtRes, error) {
+	out := new(WriteAtRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/WriteAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) Truncate(ctx context.Context, in *TruncateReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/Truncate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) PreCommit(ctx context.Context, in *PreCommitReq, opts ...grpc.CallOption) (*PreCommitRes, error) {
+	out := new(PreCommitRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/PreCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) Commit(ctx context.Context, in *CommitReq, opts ...grpc.CallOption) (*CommitRes, error) {
+	out := new(CommitRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/Commit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) KeepAliveTx(ctx context.Context, in *KeepAliveTxReq, opts ...grpc.CallOption) (*KeepAliveTxRes, error) {
+	out := new(KeepAliveTxRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/KeepAliveTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) AbortTx(ctx context.Context, in *AbortTxReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/AbortTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ListTxs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[1], "/Footnotev1/ListTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &footnotev1ListTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Footnotev1_ListTxsClient interface {
+	Recv() (*TxInfoRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1ListTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1ListTxsClient) Recv() (*TxInfoRes, error) {
+	m := new(TxInfoRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *footnotev1Client) ReadAt(ctx context.Context, in *ReadAtReq, opts ...grpc.CallOption) (*ReadAtRes, error) {
+	out := new(ReadAtRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ReadAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ReadSectors(ctx context.Context, in *ReadSectorsReq, opts ...grpc.CallOption) (*ReadSectorsRes, error) {
+	out := new(ReadSectorsRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ReadSectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) GetBlobInfo(ctx context.Context, in *BlobInfoReq, opts ...grpc.CallOption) (*BlobInfoRes, error) {
+	out := new(BlobInfoRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/GetBlobInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ListBlobInfo(ctx context.Context, in *ListBlobInfoReq, opts ...grpc.CallOption) (Footnotev1_ListBlobInfoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[2], "/Footnotev1/ListBlobInfo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &footnotev1ListBlobInfoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Footnotev1_ListBlobInfoClient interface {
+	Recv() (*BlobInfoRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1ListBlobInfoClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1ListBlobInfoClient) Recv() (*BlobInfoRes, error) {
+	m := new(BlobInfoRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *footnotev1Client) VerifyBlobs(ctx context.Context, in *VerifyBlobsReq, opts ...grpc.CallOption) (Footnotev1_VerifyBlobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[3], "/Footnotev1/VerifyBlobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &footnotev1VerifyBlobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Footnotev1_VerifyBlobsClient interface {
+	Recv() (*VerifyBlobRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1VerifyBlobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1VerifyBlobsClient) Recv() (*VerifyBlobRes, error) {
+	m := new(VerifyBlobRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *footnotev1Client) ExportBundle(ctx context.Context, in *ExportBundleReq, opts ...grpc.CallOption) (*ExportBundleRes, error) {
+	out := new(ExportBundleRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ExportBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ImportBundle(ctx context.Context, in *ImportBundleReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ImportBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) SendUpdate(ctx context.Context, in *SendUpdateReq, opts ...grpc.CallOption) (*SendUpdateRes, error) {
+	out := new(SendUpdateRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/SendUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) GetNameInfo(ctx context.Context, in *NameInfoReq, opts ...grpc.CallOption) (*GetNamesRes, error) {
+	out := new(GetNamesRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/GetNameInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ListNames(ctx context.Context, in *GetNamesReq, opts ...grpc.CallOption) (Footnotev1_ListNamesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[4], "/Footnotev1/ListNames", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &footnotev1ListNamesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Footnotev1_ListNamesClient interface {
+	Recv() (*GetNamesRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1ListNamesClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1ListNamesClient) Recv() (*GetNamesRes, error) {
+	m := new(GetNamesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *footnotev1Client) GetNameImportStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NameImportStatusRes, error) {
+	out := new(NameImportStatusRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/GetNameImportStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) BanName(ctx context.Context, in *BanNameReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/BanName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) UnbanName(ctx context.Context, in *UnbanNameReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/UnbanName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *footnotev1Client) ListNameBans(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListNameBansClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[5], "/Footnotev1/ListNameBans", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &footnotev1ListNameBansClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Footnotev1_ListNameBansClient interface {
+	Recv() (*NameBanRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1ListNameBansClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1ListNameBansClient) Recv() (*NameBanRes, error) {
+	m := new(NameBanRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *footnotev1Client) Truncate(ctx context.Context, in *TruncateReq, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/Footnotev1/Truncate", in, out, opts...)
+func (c *footnotev1Client) ListBlockedContent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_ListBlockedContentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[6], "/Footnotev1/ListBlockedContent", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &footnotev1ListBlockedContentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *footnotev1Client) PreCommit(ctx context.Context, in *PreCommitReq, opts ...grpc.CallOption) (*PreCommitRes, error) {
-	out := new(PreCommitRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/PreCommit", in, out, opts...)
-	if err != nil {
+type Footnotev1_ListBlockedContentClient interface {
+	Recv() (*BlockedContentRes, error)
+	grpc.ClientStream
+}
+
+type footnotev1ListBlockedContentClient struct {
+	grpc.ClientStream
+}
+
+func (x *footnotev1ListBlockedContentClient) Recv() (*BlockedContentRes, error) {
+	m := new(BlockedContentRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *footnotev1Client) Commit(ctx context.Context, in *CommitReq, opts ...grpc.CallOption) (*CommitRes, error) {
-	out := new(CommitRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/Commit", in, out, opts...)
+func (c *footnotev1Client) ListLogLevels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLogLevelsRes, error) {
+	out := new(ListLogLevelsRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ListLogLevels", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *footnotev1Client) ReadAt(ctx context.Context, in *ReadAtReq, opts ...grpc.CallOption) (*ReadAtRes, error) {
-	out := new(ReadAtRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/ReadAt", in, out, opts...)
+func (c *footnotev1Client) SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Footnotev1/SetLogLevel", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *footnotev1Client) GetBlobInfo(ctx context.Context, in *BlobInfoReq, opts ...grpc.CallOption) (*BlobInfoRes, error) {
-	out := new(BlobInfoRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/GetBlobInfo", in, out, opts...)
+func (c *footnotev1Client) ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReloadConfigRes, error) {
+	out := new(ReloadConfigRes)
+	err := c.cc.Invoke(ctx, "/Footnotev1/ReloadConfig", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *footnotev1Client) ListBlobInfo(ctx context.Context, in *ListBlobInfoReq, opts ...grpc.CallOption) (Footnotev1_ListBlobInfoClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[1], "/Footnotev1/ListBlobInfo", opts...)
+func (c *footnotev1Client) Backup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Footnotev1_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Footnotev1_serviceDesc.Streams[7], "/Footnotev1/Backup", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &footnotev1ListBlobInfoClient{stream}
+	x := &footnotev1BackupClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2222,32 +4602,23 @@ func (c *footnotev1Client) ListBlobInfo(ctx context.Context, in *ListBlobInfoReq
 	return x, nil
 }
 
-type Footnotev1_ListBlobInfoClient interface {
-	Recv() (*BlobInfoRes, error)
+type Footnotev1_BackupClient interface {
+	Recv() (*BackupChunk, error)
 	grpc.ClientStream
 }
 
-type footnotev1ListBlobInfoClient struct {
+type footnotev1BackupClient struct {
 	grpc.ClientStream
 }
 
-func (x *footnotev1ListBlobInfoClient) Recv() (*BlobInfoRes, error) {
-	m := new(BlobInfoRes)
+func (x *footnotev1BackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *footnotev1Client) SendUpdate(ctx context.Context, in *SendUpdateReq, opts ...grpc.CallOption) (*SendUpdateRes, error) {
-	out := new(SendUpdateRes)
-	err := c.cc.Invoke(ctx, "/Footnotev1/SendUpdate", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 // Footnotev1Server is the server API for Footnotev1 service.
 type Footnotev1Server interface {
 	GetStatus(context.Context, *Empty) (*GetStatusRes, error)
@@ -2260,10 +4631,28 @@ type Footnotev1Server interface {
 	Truncate(context.Context, *TruncateReq) (*Empty, error)
 	PreCommit(context.Context, *PreCommitReq) (*PreCommitRes, error)
 	Commit(context.Context, *CommitReq) (*CommitRes, error)
+	KeepAliveTx(context.Context, *KeepAliveTxReq) (*KeepAliveTxRes, error)
+	AbortTx(context.Context, *AbortTxReq) (*Empty, error)
+	ListTxs(*Empty, Footnotev1_ListTxsServer) error
 	ReadAt(context.Context, *ReadAtReq) (*ReadAtRes, error)
+	ReadSectors(context.Context, *ReadSectorsReq) (*ReadSectorsRes, error)
 	GetBlobInfo(context.Context, *BlobInfoReq) (*BlobInfoRes, error)
 	ListBlobInfo(*ListBlobInfoReq, Footnotev1_ListBlobInfoServer) error
+	VerifyBlobs(*VerifyBlobsReq, Footnotev1_VerifyBlobsServer) error
+	ExportBundle(context.Context, *ExportBundleReq) (*ExportBundleRes, error)
+	ImportBundle(context.Context, *ImportBundleReq) (*Empty, error)
 	SendUpdate(context.Context, *SendUpdateReq) (*SendUpdateRes, error)
+	GetNameInfo(context.Context, *NameInfoReq) (*GetNamesRes, error)
+	ListNames(*GetNamesReq, Footnotev1_ListNamesServer) error
+	GetNameImportStatus(context.Context, *Empty) (*NameImportStatusRes, error)
+	BanName(context.Context, *BanNameReq) (*Empty, error)
+	UnbanName(context.Context, *UnbanNameReq) (*Empty, error)
+	ListNameBans(*Empty, Footnotev1_ListNameBansServer) error
+	ListBlockedContent(*Empty, Footnotev1_ListBlockedContentServer) error
+	ListLogLevels(context.Context, *Empty) (*ListLogLevelsRes, error)
+	SetLogLevel(context.Context, *SetLogLevelReq) (*Empty, error)
+	ReloadConfig(context.Context, *Empty) (*ReloadConfigRes, error)
+	Backup(*Empty, Footnotev1_BackupServer) error
 }
 
 // UnimplementedFootnotev1Server can be embedded to have forward compatible implementations.
@@ -2300,281 +4689,677 @@ func (*UnimplementedFootnotev1Server) PreCommit(context.Context, *PreCommitReq)
 func (*UnimplementedFootnotev1Server) Commit(context.Context, *CommitReq) (*CommitRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
 }
+func (*UnimplementedFootnotev1Server) KeepAliveTx(context.Context, *KeepAliveTxReq) (*KeepAliveTxRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeepAliveTx not implemented")
+}
+func (*UnimplementedFootnotev1Server) AbortTx(context.Context, *AbortTxReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortTx not implemented")
+}
+func (*UnimplementedFootnotev1Server) ListTxs(*Empty, Footnotev1_ListTxsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTxs not implemented")
+}
 func (*UnimplementedFootnotev1Server) ReadAt(context.Context, *ReadAtReq) (*ReadAtRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadAt not implemented")
 }
+func (*UnimplementedFootnotev1Server) ReadSectors(context.Context, *ReadSectorsReq) (*ReadSectorsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSectors not implemented")
+}
 func (*UnimplementedFootnotev1Server) GetBlobInfo(context.Context, *BlobInfoReq) (*BlobInfoRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobInfo not implemented")
 }
 func (*UnimplementedFootnotev1Server) ListBlobInfo(*ListBlobInfoReq, Footnotev1_ListBlobInfoServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListBlobInfo not implemented")
 }
+func (*UnimplementedFootnotev1Server) VerifyBlobs(*VerifyBlobsReq, Footnotev1_VerifyBlobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyBlobs not implemented")
+}
+func (*UnimplementedFootnotev1Server) ExportBundle(context.Context, *ExportBundleReq) (*ExportBundleRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportBundle not implemented")
+}
+func (*UnimplementedFootnotev1Server) ImportBundle(context.Context, *ImportBundleReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportBundle not implemented")
+}
 func (*UnimplementedFootnotev1Server) SendUpdate(context.Context, *SendUpdateReq) (*SendUpdateRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendUpdate not implemented")
 }
+func (*UnimplementedFootnotev1Server) GetNameInfo(context.Context, *NameInfoReq) (*GetNamesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNameInfo not implemented")
+}
+func (*UnimplementedFootnotev1Server) ListNames(*GetNamesReq, Footnotev1_ListNamesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListNames not implemented")
+}
+func (*UnimplementedFootnotev1Server) GetNameImportStatus(context.Context, *Empty) (*NameImportStatusRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNameImportStatus not implemented")
+}
+func (*UnimplementedFootnotev1Server) BanName(context.Context, *BanNameReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanName not implemented")
+}
+func (*UnimplementedFootnotev1Server) UnbanName(context.Context, *UnbanNameReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanName not implemented")
+}
+func (*UnimplementedFootnotev1Server) ListNameBans(*Empty, Footnotev1_ListNameBansServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListNameBans not implemented")
+}
+func (*UnimplementedFootnotev1Server) ListBlockedContent(*Empty, Footnotev1_ListBlockedContentServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListBlockedContent not implemented")
+}
+func (*UnimplementedFootnotev1Server) ListLogLevels(context.Context, *Empty) (*ListLogLevelsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogLevels not implemented")
+}
+func (*UnimplementedFootnotev1Server) SetLogLevel(context.Context, *SetLogLevelReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (*UnimplementedFootnotev1Server) ReloadConfig(context.Context, *Empty) (*ReloadConfigRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (*UnimplementedFootnotev1Server) Backup(*Empty, Footnotev1_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+
+func RegisterFootnotev1Server(s *grpc.Server, srv Footnotev1Server) {
+	s.RegisterService(&_Footnotev1_serviceDesc, srv)
+}
+
+func _Footnotev1_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).GetStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).AddPeer(ctx, req.(*AddPeerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/BanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).BanPeer(ctx, req.(*BanPeerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/UnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).UnbanPeer(ctx, req.(*UnbanPeerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_ListPeers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPeersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).ListPeers(m, &footnotev1ListPeersServer{stream})
+}
+
+type Footnotev1_ListPeersServer interface {
+	Send(*ListPeersRes) error
+	grpc.ServerStream
+}
+
+type footnotev1ListPeersServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1ListPeersServer) Send(m *ListPeersRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Footnotev1_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).Checkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/Checkout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).Checkout(ctx, req.(*CheckoutReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_WriteAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteAtReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).WriteAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/WriteAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).WriteAt(ctx, req.(*WriteAtReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_Truncate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TruncateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).Truncate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/Truncate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).Truncate(ctx, req.(*TruncateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_PreCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreCommitReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).PreCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/PreCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).PreCommit(ctx, req.(*PreCommitReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/Commit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).Commit(ctx, req.(*CommitReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func RegisterFootnotev1Server(s *grpc.Server, srv Footnotev1Server) {
-	s.RegisterService(&_Footnotev1_serviceDesc, srv)
+func _Footnotev1_KeepAliveTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepAliveTxReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).KeepAliveTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/KeepAliveTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).KeepAliveTx(ctx, req.(*KeepAliveTxReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Footnotev1_AbortTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortTxReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).GetStatus(ctx, in)
+		return srv.(Footnotev1Server).AbortTx(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/GetStatus",
+		FullMethod: "/Footnotev1/AbortTx",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).GetStatus(ctx, req.(*Empty))
+		return srv.(Footnotev1Server).AbortTx(ctx, req.(*AbortTxReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddPeerReq)
+func _Footnotev1_ListTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).ListTxs(m, &footnotev1ListTxsServer{stream})
+}
+
+type Footnotev1_ListTxsServer interface {
+	Send(*TxInfoRes) error
+	grpc.ServerStream
+}
+
+type footnotev1ListTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1ListTxsServer) Send(m *TxInfoRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Footnotev1_ReadAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAtReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).AddPeer(ctx, in)
+		return srv.(Footnotev1Server).ReadAt(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/AddPeer",
+		FullMethod: "/Footnotev1/ReadAt",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).AddPeer(ctx, req.(*AddPeerReq))
+		return srv.(Footnotev1Server).ReadAt(ctx, req.(*ReadAtReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BanPeerReq)
+func _Footnotev1_ReadSectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSectorsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).BanPeer(ctx, in)
+		return srv.(Footnotev1Server).ReadSectors(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/BanPeer",
+		FullMethod: "/Footnotev1/ReadSectors",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).BanPeer(ctx, req.(*BanPeerReq))
+		return srv.(Footnotev1Server).ReadSectors(ctx, req.(*ReadSectorsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnbanPeerReq)
+func _Footnotev1_GetBlobInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobInfoReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).UnbanPeer(ctx, in)
+		return srv.(Footnotev1Server).GetBlobInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/UnbanPeer",
+		FullMethod: "/Footnotev1/GetBlobInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).UnbanPeer(ctx, req.(*UnbanPeerReq))
+		return srv.(Footnotev1Server).GetBlobInfo(ctx, req.(*BlobInfoReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_ListPeers_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListPeersReq)
+func _Footnotev1_ListBlobInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBlobInfoReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(Footnotev1Server).ListPeers(m, &footnotev1ListPeersServer{stream})
+	return srv.(Footnotev1Server).ListBlobInfo(m, &footnotev1ListBlobInfoServer{stream})
 }
 
-type Footnotev1_ListPeersServer interface {
-	Send(*ListPeersRes) error
+type Footnotev1_ListBlobInfoServer interface {
+	Send(*BlobInfoRes) error
 	grpc.ServerStream
 }
 
-type footnotev1ListPeersServer struct {
+type footnotev1ListBlobInfoServer struct {
 	grpc.ServerStream
 }
 
-func (x *footnotev1ListPeersServer) Send(m *ListPeersRes) error {
+func (x *footnotev1ListBlobInfoServer) Send(m *BlobInfoRes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Footnotev1_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckoutReq)
+func _Footnotev1_VerifyBlobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyBlobsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).VerifyBlobs(m, &footnotev1VerifyBlobsServer{stream})
+}
+
+type Footnotev1_VerifyBlobsServer interface {
+	Send(*VerifyBlobRes) error
+	grpc.ServerStream
+}
+
+type footnotev1VerifyBlobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1VerifyBlobsServer) Send(m *VerifyBlobRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Footnotev1_ExportBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportBundleReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).Checkout(ctx, in)
+		return srv.(Footnotev1Server).ExportBundle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/Checkout",
+		FullMethod: "/Footnotev1/ExportBundle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).Checkout(ctx, req.(*CheckoutReq))
+		return srv.(Footnotev1Server).ExportBundle(ctx, req.(*ExportBundleReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_WriteAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteAtReq)
+func _Footnotev1_ImportBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportBundleReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).WriteAt(ctx, in)
+		return srv.(Footnotev1Server).ImportBundle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/WriteAt",
+		FullMethod: "/Footnotev1/ImportBundle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).WriteAt(ctx, req.(*WriteAtReq))
+		return srv.(Footnotev1Server).ImportBundle(ctx, req.(*ImportBundleReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_Truncate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TruncateReq)
+func _Footnotev1_SendUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendUpdateReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).Truncate(ctx, in)
+		return srv.(Footnotev1Server).SendUpdate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/Truncate",
+		FullMethod: "/Footnotev1/SendUpdate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).Truncate(ctx, req.(*TruncateReq))
+		return srv.(Footnotev1Server).SendUpdate(ctx, req.(*SendUpdateReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_PreCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PreCommitReq)
+func _Footnotev1_GetNameInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameInfoReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).PreCommit(ctx, in)
+		return srv.(Footnotev1Server).GetNameInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/PreCommit",
+		FullMethod: "/Footnotev1/GetNameInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).PreCommit(ctx, req.(*PreCommitReq))
+		return srv.(Footnotev1Server).GetNameInfo(ctx, req.(*NameInfoReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CommitReq)
+func _Footnotev1_ListNames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetNamesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).ListNames(m, &footnotev1ListNamesServer{stream})
+}
+
+type Footnotev1_ListNamesServer interface {
+	Send(*GetNamesRes) error
+	grpc.ServerStream
+}
+
+type footnotev1ListNamesServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1ListNamesServer) Send(m *GetNamesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Footnotev1_GetNameImportStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).Commit(ctx, in)
+		return srv.(Footnotev1Server).GetNameImportStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/Commit",
+		FullMethod: "/Footnotev1/GetNameImportStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).Commit(ctx, req.(*CommitReq))
+		return srv.(Footnotev1Server).GetNameImportStatus(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_ReadAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadAtReq)
+func _Footnotev1_BanName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanNameReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).ReadAt(ctx, in)
+		return srv.(Footnotev1Server).BanName(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/ReadAt",
+		FullMethod: "/Footnotev1/BanName",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).ReadAt(ctx, req.(*ReadAtReq))
+		return srv.(Footnotev1Server).BanName(ctx, req.(*BanNameReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_GetBlobInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BlobInfoReq)
+func _Footnotev1_UnbanName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanNameReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).GetBlobInfo(ctx, in)
+		return srv.(Footnotev1Server).UnbanName(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/GetBlobInfo",
+		FullMethod: "/Footnotev1/UnbanName",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).GetBlobInfo(ctx, req.(*BlobInfoReq))
+		return srv.(Footnotev1Server).UnbanName(ctx, req.(*UnbanNameReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Footnotev1_ListBlobInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListBlobInfoReq)
+func _Footnotev1_ListNameBans_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(Footnotev1Server).ListBlobInfo(m, &footnotev1ListBlobInfoServer{stream})
+	return srv.(Footnotev1Server).ListNameBans(m, &footnotev1ListNameBansServer{stream})
 }
 
-type Footnotev1_ListBlobInfoServer interface {
-	Send(*BlobInfoRes) error
+type Footnotev1_ListNameBansServer interface {
+	Send(*NameBanRes) error
 	grpc.ServerStream
 }
 
-type footnotev1ListBlobInfoServer struct {
+type footnotev1ListNameBansServer struct {
 	grpc.ServerStream
 }
 
-func (x *footnotev1ListBlobInfoServer) Send(m *BlobInfoRes) error {
+func (x *footnotev1ListNameBansServer) Send(m *NameBanRes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Footnotev1_SendUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendUpdateReq)
+func _Footnotev1_ListBlockedContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).ListBlockedContent(m, &footnotev1ListBlockedContentServer{stream})
+}
+
+type Footnotev1_ListBlockedContentServer interface {
+	Send(*BlockedContentRes) error
+	grpc.ServerStream
+}
+
+type footnotev1ListBlockedContentServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1ListBlockedContentServer) Send(m *BlockedContentRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Footnotev1_ListLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Footnotev1Server).SendUpdate(ctx, in)
+		return srv.(Footnotev1Server).ListLogLevels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Footnotev1/SendUpdate",
+		FullMethod: "/Footnotev1/ListLogLevels",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Footnotev1Server).SendUpdate(ctx, req.(*SendUpdateReq))
+		return srv.(Footnotev1Server).ListLogLevels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).SetLogLevel(ctx, req.(*SetLogLevelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Footnotev1_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Footnotev1Server).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Footnotev1/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Footnotev1Server).ReloadConfig(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Footnotev1_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Footnotev1Server).Backup(m, &footnotev1BackupServer{stream})
+}
+
+type Footnotev1_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
+}
+
+type footnotev1BackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *footnotev1BackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Footnotev1_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Footnotev1",
 	HandlerType: (*Footnotev1Server)(nil),
@@ -2615,18 +5400,66 @@ var _Footnotev1_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Commit",
 			Handler:    _Footnotev1_Commit_Handler,
 		},
+		{
+			MethodName: "KeepAliveTx",
+			Handler:    _Footnotev1_KeepAliveTx_Handler,
+		},
+		{
+			MethodName: "AbortTx",
+			Handler:    _Footnotev1_AbortTx_Handler,
+		},
 		{
 			MethodName: "ReadAt",
 			Handler:    _Footnotev1_ReadAt_Handler,
 		},
+		{
+			MethodName: "ReadSectors",
+			Handler:    _Footnotev1_ReadSectors_Handler,
+		},
 		{
 			MethodName: "GetBlobInfo",
 			Handler:    _Footnotev1_GetBlobInfo_Handler,
 		},
+		{
+			MethodName: "ExportBundle",
+			Handler:    _Footnotev1_ExportBundle_Handler,
+		},
+		{
+			MethodName: "ImportBundle",
+			Handler:    _Footnotev1_ImportBundle_Handler,
+		},
 		{
 			MethodName: "SendUpdate",
 			Handler:    _Footnotev1_SendUpdate_Handler,
 		},
+		{
+			MethodName: "GetNameInfo",
+			Handler:    _Footnotev1_GetNameInfo_Handler,
+		},
+		{
+			MethodName: "GetNameImportStatus",
+			Handler:    _Footnotev1_GetNameImportStatus_Handler,
+		},
+		{
+			MethodName: "BanName",
+			Handler:    _Footnotev1_BanName_Handler,
+		},
+		{
+			MethodName: "UnbanName",
+			Handler:    _Footnotev1_UnbanName_Handler,
+		},
+		{
+			MethodName: "ListLogLevels",
+			Handler:    _Footnotev1_ListLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Footnotev1_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Footnotev1_ReloadConfig_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2634,11 +5467,41 @@ var _Footnotev1_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Footnotev1_ListPeers_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListTxs",
+			Handler:       _Footnotev1_ListTxs_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListBlobInfo",
 			Handler:       _Footnotev1_ListBlobInfo_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "VerifyBlobs",
+			Handler:       _Footnotev1_VerifyBlobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListNames",
+			Handler:       _Footnotev1_ListNames_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListNameBans",
+			Handler:       _Footnotev1_ListNameBans_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBlockedContent",
+			Handler:       _Footnotev1_ListBlockedContent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _Footnotev1_Backup_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -0,0 +1,216 @@
+package rpc
+
+import (
+	"context"
+	"fnd/blob"
+	"fnd/crypto"
+	apiv1 "fnd/rpc/v1"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrInvalidBlobSignature  = errors.New("blob signature is invalid")
+	ErrInvalidSectorProof    = errors.New("sector merkle proof is invalid")
+	ErrUnexpectedPublicKey   = errors.New("blob public key does not match expected public key")
+	ErrBlobChangedDuringRead = errors.New("blob changed during read")
+)
+
+// PublicKeyResolver returns the public key that owns a name. It must
+// not consult the node being read from.
+type PublicKeyResolver func(name string) (*btcec.PublicKey, error)
+
+// PinnedPublicKey returns a PublicKeyResolver that always returns pub.
+func PinnedPublicKey(pub *btcec.PublicKey) PublicKeyResolver {
+	return func(string) (*btcec.PublicKey, error) {
+		return pub, nil
+	}
+}
+
+// VerifyingBlobReader reads blobs from untrusted nodes. Every
+// sector is checked against its merkle proof, and the merkle root
+// against the owner's signature over the blob's seal hash. The owner's
+// public key comes from the reader's resolver, never from the node.
+type VerifyingBlobReader struct {
+	client   apiv1.Footnotev1Client
+	name     string
+	off      int64
+	resolver PublicKeyResolver
+	pub      *btcec.PublicKey
+
+	header *VerifiedHeader
+}
+
+type VerifiedHeader struct {
+	Name         string
+	PublicKey    *btcec.PublicKey
+	Timestamp    time.Time
+	MerkleRoot   crypto.Hash
+	ReservedRoot crypto.Hash
+	Signature    crypto.Signature
+}
+
+func NewVerifyingBlobReader(client apiv1.Footnotev1Client, name string, resolver PublicKeyResolver) *VerifyingBlobReader {
+	return &VerifyingBlobReader{
+		client:   client,
+		name:     name,
+		resolver: resolver,
+	}
+}
+
+// Header returns the verified header of the first read, or nil if
+// nothing has been read yet.
+func (b *VerifyingBlobReader) Header() *VerifiedHeader {
+	return b.header
+}
+
+func (b *VerifyingBlobReader) ReadSector(id uint8) (blob.Sector, error) {
+	sectors, err := b.readSectors(id, 1)
+	if err != nil {
+		return blob.ZeroSector, err
+	}
+	return sectors[0], nil
+}
+
+func (b *VerifyingBlobReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= blob.Size {
+		return 0, io.EOF
+	}
+	end := off + int64(len(p))
+	if end > blob.Size {
+		end = blob.Size
+	}
+	if end == off {
+		return 0, nil
+	}
+	start := uint8(off / blob.SectorLen)
+	count := int((end-1)/blob.SectorLen) - int(start) + 1
+	sectors, err := b.readSectors(start, count)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for i, sector := range sectors {
+		sectorStart := (int64(start) + int64(i)) * blob.SectorLen
+		from := off + int64(n) - sectorStart
+		n += copy(p[n:end-off], sector[from:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (b *VerifyingBlobReader) Read(p []byte) (int, error) {
+	if b.off >= blob.Size {
+		return 0, io.EOF
+	}
+	n, err := b.ReadAt(p, b.off)
+	b.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (b *VerifyingBlobReader) readSectors(start uint8, count int) ([]blob.Sector, error) {
+	res, err := b.client.ReadSectors(context.Background(), &apiv1.ReadSectorsReq{
+		Name:          b.name,
+		StartSectorID: uint32(start),
+		Count:         uint32(count),
+	})
+	if err != nil {
+		return nil, err
+	}
+	header, err := b.verifyHeader(res)
+	if err != nil {
+		return nil, err
+	}
+	if b.header == nil {
+		b.header = header
+	} else if b.header.MerkleRoot != header.MerkleRoot || !b.header.Timestamp.Equal(header.Timestamp) {
+		return nil, ErrBlobChangedDuringRead
+	}
+
+	if len(res.Sectors) != count {
+		return nil, errors.New("unexpected sector count")
+	}
+	sectors := make([]blob.Sector, count)
+	for i, provenSector := range res.Sectors {
+		id := start + uint8(i)
+		if provenSector.SectorID != uint32(id) {
+			return nil, errors.New("unexpected sector ID")
+		}
+		if len(provenSector.Data) != blob.SectorLen || len(provenSector.Proof) != blob.MerkleProofLen {
+			return nil, errors.New("malformed sector")
+		}
+		var proof blob.MerkleProof
+		copy(sectors[i][:], provenSector.Data)
+		copy(proof[:], provenSector.Proof)
+		if !blob.VerifySectorProof(sectors[i], id, header.MerkleRoot, proof) {
+			return nil, ErrInvalidSectorProof
+		}
+	}
+	return sectors, nil
+}
+
+func (b *VerifyingBlobReader) publicKey() (*btcec.PublicKey, error) {
+	if b.pub != nil {
+		return b.pub, nil
+	}
+	if b.resolver == nil {
+		return nil, errors.New("no public key resolver")
+	}
+	pub, err := b.resolver(b.name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error resolving public key")
+	}
+	if pub == nil {
+		return nil, errors.New("public key resolver returned no key")
+	}
+	b.pub = pub
+	return pub, nil
+}
+
+func (b *VerifyingBlobReader) verifyHeader(res *apiv1.ReadSectorsRes) (*VerifiedHeader, error) {
+	if res.Name != b.name {
+		return nil, errors.New("unexpected blob name")
+	}
+	pub, err := b.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	resPub, err := btcec.ParsePubKey(res.PublicKey, btcec.S256())
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing public key")
+	}
+	if !pub.IsEqual(resPub) {
+		return nil, ErrUnexpectedPublicKey
+	}
+	merkleRoot, err := crypto.NewHashFromBytes(res.MerkleRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing merkle root")
+	}
+	reservedRoot, err := crypto.NewHashFromBytes(res.ReservedRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing reserved root")
+	}
+	sig, err := crypto.NewSignatureFromBytes(res.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing signature")
+	}
+	ts := time.Unix(int64(res.Timestamp), 0)
+	if !crypto.VerifySigPub(pub, sig, blob.SealHash(b.name, ts, merkleRoot, reservedRoot)) {
+		return nil, ErrInvalidBlobSignature
+	}
+	return &VerifiedHeader{
+		Name:         b.name,
+		PublicKey:    pub,
+		Timestamp:    ts,
+		MerkleRoot:   merkleRoot,
+		ReservedRoot: reservedRoot,
+		Signature:    sig,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fnd/blob"
+	"fnd/crypto"
+	apiv1 "fnd/rpc/v1"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+type readSectorsClient struct {
+	apiv1.Footnotev1Client
+	data   []byte
+	tree   blob.MerkleTree
+	pub    *btcec.PublicKey
+	ts     time.Time
+	sig    crypto.Signature
+	tamper bool
+}
+
+func (r *readSectorsClient) ReadSectors(_ context.Context, req *apiv1.ReadSectorsReq, _ ...grpc.CallOption) (*apiv1.ReadSectorsRes, error) {
+	root := r.tree.Root()
+	res := &apiv1.ReadSectorsRes{
+		Name:         "foo",
+		PublicKey:    r.pub.SerializeCompressed(),
+		Timestamp:    uint64(r.ts.Unix()),
+		MerkleRoot:   root[:],
+		ReservedRoot: crypto.ZeroHash[:],
+		Signature:    r.sig[:],
+	}
+	for i := req.StartSectorID; i < req.StartSectorID+req.Count; i++ {
+		data := make([]byte, blob.SectorLen)
+		copy(data, r.data[int(i)*blob.SectorLen:])
+		if r.tamper {
+			data[0]++
+		}
+		proof := blob.MakeSectorProof(r.tree, uint8(i))
+		res.Sectors = append(res.Sectors, &apiv1.ProvenSector{
+			SectorID: i,
+			Data:     data,
+			Proof:    proof[:],
+		})
+	}
+	return res, nil
+}
+
+func newReadSectorsClient(t *testing.T) *readSectorsClient {
+	data := make([]byte, blob.Size)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	tree, err := blob.Merkleize(bytes.NewReader(data))
+	require.NoError(t, err)
+	pk, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	signer := crypto.NewSECP256k1Signer(pk)
+	ts := time.Unix(100, 0)
+	sig, err := blob.SignSeal(signer, "foo", ts, tree.Root(), crypto.ZeroHash)
+	require.NoError(t, err)
+	return &readSectorsClient{
+		data: data,
+		tree: tree,
+		pub:  signer.Pub(),
+		ts:   ts,
+		sig:  sig,
+	}
+}
+
+func TestVerifyingBlobReader(t *testing.T) {
+	client := newReadSectorsClient(t)
+	rdr := NewVerifyingBlobReader(client, "foo", PinnedPublicKey(client.pub))
+	buf := make([]byte, 10000)
+	n, err := rdr.ReadAt(buf, 4000)
+	require.NoError(t, err)
+	require.Equal(t, len(buf), n)
+	require.Equal(t, client.data[4000:14000], buf)
+	require.Equal(t, client.tree.Root(), rdr.Header().MerkleRoot)
+
+	all, err := ioutil.ReadAll(NewVerifyingBlobReader(client, "foo", PinnedPublicKey(client.pub)))
+	require.NoError(t, err)
+	require.Equal(t, client.data, all)
+
+	n, err = rdr.ReadAt(buf, blob.Size-100)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 100, n)
+}
+
+func TestVerifyingBlobReader_Invalid(t *testing.T) {
+	client := newReadSectorsClient(t)
+	client.tamper = true
+	_, err := NewVerifyingBlobReader(client, "foo", PinnedPublicKey(client.pub)).ReadSector(0)
+	require.Equal(t, ErrInvalidSectorProof, err)
+
+	client = newReadSectorsClient(t)
+	client.ts = time.Unix(101, 0)
+	_, err = NewVerifyingBlobReader(client, "foo", PinnedPublicKey(client.pub)).ReadSector(0)
+	require.Equal(t, ErrInvalidBlobSignature, err)
+
+	// content re-signed by the node's own key is rejected
+	client = newReadSectorsClient(t)
+	pinned := client.pub
+	_, err = NewVerifyingBlobReader(newReadSectorsClient(t), "foo", PinnedPublicKey(pinned)).ReadSector(0)
+	require.Equal(t, ErrUnexpectedPublicKey, err)
+
+	_, err = NewVerifyingBlobReader(client, "foo", nil).ReadSector(0)
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+)
+
+type notReadyError struct {
+	err error
+}
+
+func (e *notReadyError) Error() string {
+	return "not ready: " + e.err.Error()
+}
+
+func (e *notReadyError) Cause() error {
+	return e.err
+}
+
+func (e *notReadyError) Unwrap() error {
+	return e.err
+}
+
+// NotReady marks err as a readiness failure rather than a
+// liveness failure.
+func NotReady(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &notReadyError{err: err}
+}
+
+func IsNotReady(err error) bool {
+	var target *notReadyError
+	return errors.As(err, &target)
+}
+
+type Check struct {
+	Component string
+	Err       error
+}
+
+// Report is the result of checking the health of every component of
+// a node.
+type Report struct {
+	Checks []Check
+}
+
+// Live returns true if no component is broken.
+func (r *Report) Live() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil && !IsNotReady(check.Err) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ready returns true if every component is healthy.
+func (r *Report) Ready() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Component returns the check for the named component, or nil if
+// there is no such component.
+func (r *Report) Component(name string) *Check {
+	for i := range r.Checks {
+		if r.Checks[i].Component == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
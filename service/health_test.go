@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	notReady := errors.Wrap(NotReady(errors.New("syncing")), "importer")
+	require.True(t, IsNotReady(notReady))
+	require.False(t, IsNotReady(errors.New("broken")))
+	require.Nil(t, NotReady(nil))
+
+	report := &Report{
+		Checks: []Check{
+			{Component: "a"},
+			{Component: "b", Err: notReady},
+		},
+	}
+	require.True(t, report.Live())
+	require.False(t, report.Ready())
+	require.Equal(t, notReady, report.Component("b").Err)
+	require.Nil(t, report.Component("c"))
+
+	report.Checks[1].Err = nil
+	require.True(t, report.Ready())
+
+	report.Checks[0].Err = errors.New("broken")
+	require.False(t, report.Live())
+	require.False(t, report.Ready())
+}
@@ -3,4 +3,17 @@ package service
 type Service interface {
 	Start() error
 	Stop() error
+	// Health returns nil if the service is working. Errors wrapped
+	// with NotReady mean the service is alive but can't do its job
+	// yet; any other error means the service is broken.
+	Health() error
+}
+
+// Readier is implemented by services whose Start blocks for as long as
+// they run. Ready is closed once the setup that other services depend
+// on, such as registering handlers or binding listeners, is done.
+// Services that have no such setup should run in the background and
+// return from Start instead; they are ready once Start returns.
+type Readier interface {
+	Ready() <-chan struct{}
 }
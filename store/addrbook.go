@@ -0,0 +1,96 @@
+package store
+
+import (
+	"fnd/crypto"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"net"
+	"strconv"
+	"time"
+)
+
+type AddrBookEntry struct {
+	ID          crypto.Hash `json:"peer_id"`
+	IP          string      `json:"ip"`
+	Port        int         `json:"port"`
+	Services    uint64      `json:"services,omitempty"`
+	Source      string      `json:"source"`
+	Verify      bool        `json:"verify"`
+	Tried       bool        `json:"tried"`
+	Attempts    int         `json:"attempts"`
+	AddedAt     time.Time   `json:"added_at"`
+	LastAttempt time.Time   `json:"last_attempt"`
+	LastSuccess time.Time   `json:"last_success"`
+}
+
+var (
+	addrBookPrefix      = Prefixer(string(peersPrefix("addrbook")))
+	addrBookKeyKey      = addrBookPrefix("key")
+	addrBookEntryPrefix = Prefixer(string(addrBookPrefix("entries")))
+)
+
+func GetAddrBookKey(db *leveldb.DB) (crypto.Hash, error) {
+	b, err := db.Get(addrBookKeyKey, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		key := crypto.Hash(crypto.Rand32())
+		if err := db.Put(addrBookKeyKey, key[:], nil); err != nil {
+			return crypto.ZeroHash, errors.Wrap(err, "error writing address book key")
+		}
+		return key, nil
+	}
+	if err != nil {
+		return crypto.ZeroHash, errors.Wrap(err, "error getting address book key")
+	}
+	return crypto.NewHashFromBytes(b)
+}
+
+func SetAddrBook(db *leveldb.DB, entries []*AddrBookEntry) error {
+	return WithTx(db, func(tx *leveldb.Transaction) error {
+		return SetAddrBookTx(tx, entries)
+	})
+}
+
+func SetAddrBookTx(tx *leveldb.Transaction, entries []*AddrBookEntry) error {
+	iter := tx.NewIterator(util.BytesPrefix(addrBookEntryPrefix()), nil)
+	for iter.Next() {
+		if err := tx.Delete(iter.Key(), nil); err != nil {
+			iter.Release()
+			return errors.Wrap(err, "error deleting address book entry")
+		}
+	}
+	iter.Release()
+	for _, entry := range entries {
+		addr := net.JoinHostPort(entry.IP, strconv.Itoa(entry.Port))
+		if err := tx.Put(addrBookEntryPrefix(addr), mustMarshalJSON(entry), nil); err != nil {
+			return errors.Wrap(err, "error writing address book entry")
+		}
+	}
+	return nil
+}
+
+type AddrBookStream struct {
+	iter iterator.Iterator
+}
+
+func (as *AddrBookStream) Next() (*AddrBookEntry, error) {
+	if !as.iter.Next() {
+		return nil, nil
+	}
+	entry := new(AddrBookEntry)
+	mustUnmarshalJSON(as.iter.Value(), entry)
+	return entry, nil
+}
+
+func (as *AddrBookStream) Close() error {
+	as.iter.Release()
+	return as.iter.Error()
+}
+
+func StreamAddrBook(db *leveldb.DB) (*AddrBookStream, error) {
+	iter := db.NewIterator(util.BytesPrefix(addrBookEntryPrefix()), nil)
+	return &AddrBookStream{
+		iter: iter,
+	}, nil
+}
@@ -99,9 +99,30 @@ var (
 	headersPrefix          = Prefixer("headers")
 	headerCountKey         = Prefixer(string(headersPrefix("count")))()
 	headerMerkleBasePrefix = Prefixer(string(headersPrefix("merkle-base")))
+	headerMerkleTreePrefix = Prefixer(string(headersPrefix("merkle-tree")))
 	headerDataPrefix       = Prefixer(string(headersPrefix("header")))
 )
 
+// HeaderKeys returns the keys that store the header, merkle base, and
+// merkle tree for name.
+func HeaderKeys(name string) [][]byte {
+	return [][]byte{
+		headerDataPrefix(name),
+		headerMerkleBasePrefix(name),
+		headerMerkleTreePrefix(name),
+	}
+}
+
+// HeaderNameFromKey returns the name whose header is stored at key,
+// or false if key does not store a header.
+func HeaderNameFromKey(key []byte) (string, bool) {
+	prefix := headerDataPrefix("")
+	if !bytes.HasPrefix(key, prefix) {
+		return "", false
+	}
+	return string(key[len(prefix):]), true
+}
+
 func GetHeaderCount(db *leveldb.DB) (int, error) {
 	res, err := db.Get(headerCountKey, nil)
 	if errors.Is(err, leveldb.ErrNotFound) {
@@ -150,9 +171,35 @@ func GetMerkleBase(db *leveldb.DB, name string) (blob.MerkleBase, error) {
 	return base, nil
 }
 
-func SetHeaderTx(tx *leveldb.Transaction, header *Header, merkleBase blob.MerkleBase) error {
+// GetMerkleTree returns the full merkle tree for a name. Trees are
+// rebuilt from the merkle base for headers written before full trees
+// were persisted.
+func GetMerkleTree(db *leveldb.DB, name string) (blob.MerkleTree, error) {
+	treeB, err := db.Get(headerMerkleTreePrefix(name), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		base, err := GetMerkleBase(db, name)
+		if err != nil {
+			return nil, err
+		}
+		return blob.MakeTreeFromBase(base), nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting merkle tree")
+	}
+	var tree blob.MerkleTree
+	if err := tree.Decode(bytes.NewReader(treeB)); err != nil {
+		panic(err)
+	}
+	return tree, nil
+}
+
+func SetHeaderTx(tx *leveldb.Transaction, header *Header, tree blob.MerkleTree) error {
 	var buf bytes.Buffer
-	if err := merkleBase.Encode(&buf); err != nil {
+	if err := tree.ProtocolBase().Encode(&buf); err != nil {
+		return errors.Wrap(err, "error encoding merkle base")
+	}
+	var treeBuf bytes.Buffer
+	if err := tree.Encode(&treeBuf); err != nil {
 		return errors.Wrap(err, "error encoding merkle tree")
 	}
 	exists, err := tx.Has(headerDataPrefix(header.Name), nil)
@@ -160,6 +207,9 @@ func SetHeaderTx(tx *leveldb.Transaction, header *Header, merkleBase blob.Merkle
 		return errors.Wrap(err, "error checking header existence")
 	}
 	if err := tx.Put(headerMerkleBasePrefix(header.Name), buf.Bytes(), nil); err != nil {
+		return errors.Wrap(err, "error writing merkle base")
+	}
+	if err := tx.Put(headerMerkleTreePrefix(header.Name), treeBuf.Bytes(), nil); err != nil {
 		return errors.Wrap(err, "error writing merkle tree")
 	}
 	if err := tx.Put(headerDataPrefix(header.Name), mustMarshalJSON(header), nil); err != nil {
@@ -266,6 +316,30 @@ func StreamBlobInfo(db *leveldb.DB, start string) (*BlobInfoStream, error) {
 	}, nil
 }
 
+type HeaderStream struct {
+	iter iterator.Iterator
+}
+
+func (hs *HeaderStream) Next() (*Header, error) {
+	if !hs.iter.Next() {
+		return nil, nil
+	}
+	header := new(Header)
+	mustUnmarshalJSON(hs.iter.Value(), header)
+	return header, nil
+}
+
+func (hs *HeaderStream) Close() error {
+	hs.iter.Release()
+	return hs.iter.Error()
+}
+
+func StreamHeaders(db *leveldb.DB) (*HeaderStream, error) {
+	return &HeaderStream{
+		iter: db.NewIterator(util.BytesPrefix(headerDataPrefix("")), nil),
+	}, nil
+}
+
 func TruncateHeaderStore(db *leveldb.DB) error {
 	err := WithTx(db, func(tx *leveldb.Transaction) error {
 		iter := tx.NewIterator(util.BytesPrefix(headersPrefix()), nil)
@@ -16,6 +16,7 @@ func TestHeaders_GetSet(t *testing.T) {
 	var expMB blob.MerkleBase
 	_, err := rand.Read(expMB[0][:])
 	require.NoError(t, err)
+	expTree := blob.MakeTreeFromBase(expMB)
 
 	var sig crypto.Signature
 	_, err = rand.Read(sig[:])
@@ -33,7 +34,7 @@ func TestHeaders_GetSet(t *testing.T) {
 	_, err = GetHeader(db, "foo")
 	require.Error(t, err)
 	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
-		return SetHeaderTx(tx, expHeader, expMB)
+		return SetHeaderTx(tx, expHeader, expTree)
 	}))
 	actHeader, err := GetHeader(db, "foo")
 	require.NoError(t, err)
@@ -46,6 +47,9 @@ func TestHeaders_GetSet(t *testing.T) {
 	actMB, err := GetMerkleBase(db, "foo")
 	require.NoError(t, err)
 	require.Equal(t, expMB, actMB)
+	actTree, err := GetMerkleTree(db, "foo")
+	require.NoError(t, err)
+	require.Equal(t, expTree, actTree)
 
 	done()
 }
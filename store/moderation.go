@@ -1,8 +1,10 @@
 package store
 
 import (
+	"fnd/crypto"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"time"
 )
@@ -11,6 +13,7 @@ var (
 	lastBanListImportAtKey = []byte("last-ban-list-import-at")
 	bansPrefix             = Prefixer("bans")
 	banPrefix              = Prefixer(string(bansPrefix("ban")))
+	sectorBlockPrefix      = Prefixer(string(bansPrefix("sector")))
 )
 
 func GetLastBanListImportAt(db *leveldb.DB) (time.Time, error) {
@@ -32,6 +35,33 @@ func SetLastBanListImportAt(tx *leveldb.Transaction, t time.Time) error {
 	return nil
 }
 
+const (
+	// LocalBanSource is the source recorded for bans created by the
+	// node operator rather than imported from a ban list.
+	LocalBanSource = "local"
+	// LegacyBanSource is recorded for bans that were imported from ban
+	// lists before sources were tracked. Ban list ingestion drops it.
+	LegacyBanSource = "legacy"
+)
+
+type NameBan struct {
+	Name    string   `json:"name"`
+	Sources []string `json:"sources"`
+}
+
+func (b *NameBan) HasSource(source string) bool {
+	for _, s := range b.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+type getter interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+}
+
 func NameIsBanned(db *leveldb.DB, name string) (bool, error) {
 	has, err := db.Has(banPrefix(name), nil)
 	if err != nil {
@@ -40,6 +70,30 @@ func NameIsBanned(db *leveldb.DB, name string) (bool, error) {
 	return has, nil
 }
 
+// GetNameBan returns the ban for the given name, or nil if the name
+// is not banned.
+func GetNameBan(db *leveldb.DB, name string) (*NameBan, error) {
+	return getNameBan(db, name)
+}
+
+func GetNameBanTx(tx *leveldb.Transaction, name string) (*NameBan, error) {
+	return getNameBan(tx, name)
+}
+
+func ListNameBans(db *leveldb.DB) ([]*NameBan, error) {
+	iter := db.NewIterator(util.BytesPrefix(banPrefix("")), nil)
+	defer iter.Release()
+	var out []*NameBan
+	for iter.Next() {
+		name := string(iter.Key()[len(banPrefix("")):])
+		out = append(out, decodeNameBan(name, iter.Value()))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error iterating name bans")
+	}
+	return out, nil
+}
+
 func TruncateBannedNames(tx *leveldb.Transaction) error {
 	iter := tx.NewIterator(util.BytesPrefix(bansPrefix()), nil)
 	for iter.Next() {
@@ -51,10 +105,133 @@ func TruncateBannedNames(tx *leveldb.Transaction) error {
 	return nil
 }
 
-func BanName(tx *leveldb.Transaction, name string) error {
-	err := tx.Put(banPrefix(name), []byte{0x01}, nil)
+// BanName bans the name on behalf of the given source. A name stays
+// banned until every source that banned it has been removed.
+func BanName(tx *leveldb.Transaction, name string, source string) error {
+	ban, err := getNameBan(tx, name)
+	if err != nil {
+		return err
+	}
+	if ban == nil {
+		ban = &NameBan{
+			Name: name,
+		}
+	}
+	if ban.HasSource(source) {
+		return nil
+	}
+	ban.Sources = append(ban.Sources, source)
+	return SetNameBan(tx, ban)
+}
+
+// UnbanName removes the given source's ban on the name. It returns true
+// if the name is no longer banned by any source.
+func UnbanName(tx *leveldb.Transaction, name string, source string) (bool, error) {
+	ban, err := getNameBan(tx, name)
 	if err != nil {
+		return false, err
+	}
+	if ban == nil {
+		return true, nil
+	}
+	var sources []string
+	for _, s := range ban.Sources {
+		if s != source {
+			sources = append(sources, s)
+		}
+	}
+	ban.Sources = sources
+	if err := SetNameBan(tx, ban); err != nil {
+		return false, err
+	}
+	return len(sources) == 0, nil
+}
+
+// SetNameBan stores the ban, or deletes it if it has no sources.
+func SetNameBan(tx *leveldb.Transaction, ban *NameBan) error {
+	if len(ban.Sources) == 0 {
+		if err := tx.Delete(banPrefix(ban.Name), nil); err != nil {
+			return errors.Wrap(err, "error deleting banned name")
+		}
+		return nil
+	}
+	if err := tx.Put(banPrefix(ban.Name), mustMarshalJSON(ban), nil); err != nil {
 		return errors.Wrap(err, "error inserting banned name")
 	}
 	return nil
 }
+
+func getNameBan(g getter, name string) (*NameBan, error) {
+	res, err := g.Get(banPrefix(name), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting name ban")
+	}
+	return decodeNameBan(name, res), nil
+}
+
+// decodeNameBan tolerates bans written before sources were recorded,
+// which are stored as a single marker byte and have no sources.
+func decodeNameBan(name string, data []byte) *NameBan {
+	ban := &NameBan{
+		Name: name,
+	}
+	if len(data) == 0 || data[0] != '{' {
+		return ban
+	}
+	mustUnmarshalJSON(data, ban)
+	return ban
+}
+
+type SectorBlock struct {
+	Hash    crypto.Hash `json:"hash"`
+	Sources []string    `json:"sources"`
+}
+
+func (b *SectorBlock) HasSource(source string) bool {
+	for _, s := range b.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+func SectorIsBlocked(db *leveldb.DB, hash crypto.Hash) (bool, error) {
+	has, err := db.Has(sectorBlockPrefix(hash.String()), nil)
+	if err != nil {
+		return false, errors.Wrap(err, "error getting sector block state")
+	}
+	return has, nil
+}
+
+func ListSectorBlocks(db *leveldb.DB) ([]*SectorBlock, error) {
+	iter := db.NewIterator(util.BytesPrefix(sectorBlockPrefix("")), nil)
+	defer iter.Release()
+	var out []*SectorBlock
+	for iter.Next() {
+		block := new(SectorBlock)
+		mustUnmarshalJSON(iter.Value(), block)
+		out = append(out, block)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error iterating sector blocks")
+	}
+	return out, nil
+}
+
+// SetSectorBlock stores the block, or deletes it if it has no sources.
+func SetSectorBlock(tx *leveldb.Transaction, block *SectorBlock) error {
+	if len(block.Sources) == 0 {
+		if err := tx.Delete(sectorBlockPrefix(block.Hash.String()), nil); err != nil {
+			return errors.Wrap(err, "error deleting sector block")
+		}
+		return nil
+	}
+	if err := tx.Put(sectorBlockPrefix(block.Hash.String()), mustMarshalJSON(block), nil); err != nil {
+		return errors.Wrap(err, "error inserting sector block")
+	}
+	return nil
+}
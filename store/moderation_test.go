@@ -1,6 +1,7 @@
 package store
 
 import (
+	"fnd/crypto"
 	"github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb"
 	"testing"
@@ -33,10 +34,10 @@ func TestModeration_BanLists(t *testing.T) {
 	require.False(t, isBanned)
 
 	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
-		if err := BanName(tx, "foo"); err != nil {
+		if err := BanName(tx, "foo", LocalBanSource); err != nil {
 			return err
 		}
-		return BanName(tx, "bar")
+		return BanName(tx, "bar", LocalBanSource)
 	}))
 
 	isBanned, err = NameIsBanned(db, "foo")
@@ -58,3 +59,82 @@ func TestModeration_BanLists(t *testing.T) {
 
 	done()
 }
+
+func TestModeration_BanSources(t *testing.T) {
+	db, done := setupLevelDB(t)
+	defer done()
+
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		if err := BanName(tx, "foo", LocalBanSource); err != nil {
+			return err
+		}
+		if err := BanName(tx, "foo", "https://example.com/list"); err != nil {
+			return err
+		}
+		return BanName(tx, "foo", LocalBanSource)
+	}))
+	ban, err := GetNameBan(db, "foo")
+	require.NoError(t, err)
+	require.Equal(t, []string{LocalBanSource, "https://example.com/list"}, ban.Sources)
+
+	var unbanned bool
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		var err error
+		unbanned, err = UnbanName(tx, "foo", LocalBanSource)
+		return err
+	}))
+	require.False(t, unbanned)
+	isBanned, err := NameIsBanned(db, "foo")
+	require.NoError(t, err)
+	require.True(t, isBanned)
+
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		var err error
+		unbanned, err = UnbanName(tx, "foo", "https://example.com/list")
+		return err
+	}))
+	require.True(t, unbanned)
+	ban, err = GetNameBan(db, "foo")
+	require.NoError(t, err)
+	require.Nil(t, ban)
+
+	require.NoError(t, db.Put(banPrefix("legacy"), []byte{0x01}, nil))
+	bans, err := ListNameBans(db)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(bans))
+	require.Equal(t, "legacy", bans[0].Name)
+	require.Empty(t, bans[0].Sources)
+}
+
+func TestModeration_SectorBlocks(t *testing.T) {
+	db, done := setupLevelDB(t)
+	defer done()
+
+	hash := crypto.Blake2B256([]byte("sector"))
+	blocked, err := SectorIsBlocked(db, hash)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	block := &SectorBlock{
+		Hash:    hash,
+		Sources: []string{"https://example.com/list"},
+	}
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		return SetSectorBlock(tx, block)
+	}))
+	blocked, err = SectorIsBlocked(db, hash)
+	require.NoError(t, err)
+	require.True(t, blocked)
+	blocks, err := ListSectorBlocks(db)
+	require.NoError(t, err)
+	require.Equal(t, []*SectorBlock{block}, blocks)
+
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		return SetSectorBlock(tx, &SectorBlock{
+			Hash: hash,
+		})
+	}))
+	blocked, err = SectorIsBlocked(db, hash)
+	require.NoError(t, err)
+	require.False(t, blocked)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -136,6 +137,21 @@ func StreamNameInfo(db *leveldb.DB, start string) (*NameInfoStream, error) {
 	}, nil
 }
 
+// StreamNameInfoPrefix streams names beginning with prefix, in
+// order, starting after the name start.
+func StreamNameInfoPrefix(db *leveldb.DB, prefix string, start string) (*NameInfoStream, error) {
+	iterRange := util.BytesPrefix(nameDataPrefix(prefix))
+	if start != "" {
+		after := append(nameDataPrefix(start), 0x00)
+		if bytes.Compare(after, iterRange.Start) > 0 {
+			iterRange.Start = after
+		}
+	}
+	return &NameInfoStream{
+		iter: db.NewIterator(iterRange, nil),
+	}, nil
+}
+
 func SetNameInfoTx(tx *leveldb.Transaction, name string, key *btcec.PublicKey, height int) error {
 	err := tx.Put(nameDataPrefix(name), mustMarshalJSON(&NameInfo{
 		Name:         name,
@@ -98,3 +98,39 @@ func TestNaming_StreamNameInfo(t *testing.T) {
 
 	done()
 }
+
+func TestNaming_StreamNameInfoPrefix(t *testing.T) {
+	db, done := setupLevelDB(t)
+
+	_, pub := testcrypto.RandKey()
+	names := []string{"bar", "barn", "baz", "foo"}
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		for i, name := range names {
+			require.NoError(t, SetNameInfoTx(tx, name, pub, i))
+		}
+		return nil
+	}))
+
+	collect := func(prefix string, start string) []string {
+		stream, err := StreamNameInfoPrefix(db, prefix, start)
+		require.NoError(t, err)
+		defer stream.Close()
+		var out []string
+		for {
+			item, err := stream.Next()
+			require.NoError(t, err)
+			if item == nil {
+				return out
+			}
+			out = append(out, item.Name)
+		}
+	}
+
+	require.Equal(t, names, collect("", ""))
+	require.Equal(t, []string{"bar", "barn", "baz"}, collect("ba", ""))
+	require.Equal(t, []string{"barn", "baz"}, collect("ba", "bar"))
+	require.Equal(t, []string{"bar", "barn"}, collect("bar", "a"))
+	require.Nil(t, collect("bar", "foo"))
+
+	done()
+}
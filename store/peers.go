@@ -10,12 +10,75 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"math"
+	"net"
+	"strings"
 	"time"
 )
 
+type AddrType int
+
+const (
+	AddrTypeIPv4 AddrType = iota
+	AddrTypeIPv6
+	AddrTypeOnion
+	AddrTypeHostname
+)
+
+func (a AddrType) String() string {
+	switch a {
+	case AddrTypeIPv4:
+		return "ipv4"
+	case AddrTypeIPv6:
+		return "ipv6"
+	case AddrTypeOnion:
+		return "onion"
+	case AddrTypeHostname:
+		return "hostname"
+	default:
+		return "unknown"
+	}
+}
+
+// AddrTypeOf returns the type of a peer's address. Peer addresses
+// are stored as strings, so they may hold onion addresses and
+// hostnames as well as IPs.
+func AddrTypeOf(host string) AddrType {
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.To4() != nil {
+			return AddrTypeIPv4
+		}
+		return AddrTypeIPv6
+	}
+	if IsOnion(host) {
+		return AddrTypeOnion
+	}
+	return AddrTypeHostname
+}
+
+// IsOnion returns true if host is a v2 or v3 Tor onion address.
+func IsOnion(host string) bool {
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, ".onion") {
+		return false
+	}
+	name := strings.TrimSuffix(host, ".onion")
+	if len(name) != 16 && len(name) != 56 {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '2' && c <= '7') {
+			return false
+		}
+	}
+	return true
+}
+
 type Peer struct {
-	ID                  crypto.Hash
+	ID crypto.Hash
+	// IP holds the peer's address, which is an IP for most
+	// peers but may be an onion address or hostname.
 	IP                  string
+	Port                int
 	LastSeen            time.Time
 	Verify              bool
 	InboundBannedUntil  time.Time
@@ -27,11 +90,13 @@ func (p *Peer) MarshalJSON() ([]byte, error) {
 	out := struct {
 		PeerID   string    `json:"peer_id"`
 		IP       string    `json:"ip"`
+		Port     int       `json:"port,omitempty"`
 		LastSeen time.Time `json:"last_seen"`
 		Verify   bool      `json:"verify"`
 	}{
 		p.ID.String(),
 		p.IP,
+		p.Port,
 		p.LastSeen,
 		p.Verify,
 	}
@@ -43,6 +108,7 @@ func (p *Peer) UnmarshalJSON(b []byte) error {
 	out := &struct {
 		PeerID   string    `json:"peer_id"`
 		IP       string    `json:"ip"`
+		Port     int       `json:"port,omitempty"`
 		LastSeen time.Time `json:"last_seen"`
 		Verify   bool      `json:"verify"`
 	}{}
@@ -60,11 +126,16 @@ func (p *Peer) UnmarshalJSON(b []byte) error {
 
 	p.ID = hash
 	p.IP = out.IP
+	p.Port = out.Port
 	p.LastSeen = out.LastSeen
 	p.Verify = out.Verify
 	return nil
 }
 
+func (p *Peer) AddrType() AddrType {
+	return AddrTypeOf(p.IP)
+}
+
 func (p *Peer) IsBanned() bool {
 	now := time.Now()
 	return !p.Whitelisted && (p.InboundBannedUntil.After(now) || p.OutboundBannedUntil.After(now))
@@ -78,16 +149,19 @@ var (
 	whitelistPrefix  = Prefixer(string(peersPrefix("whitelist")))
 )
 
-func SetPeer(db *leveldb.DB, id crypto.Hash, ip string, verify bool) error {
+func SetPeer(db *leveldb.DB, id crypto.Hash, ip string, port int, verify bool) error {
 	return WithTx(db, func(tx *leveldb.Transaction) error {
-		return SetPeerTx(tx, id, ip, verify)
+		return SetPeerTx(tx, id, ip, port, verify)
 	})
 }
 
-func SetPeerTx(batch *leveldb.Transaction, id crypto.Hash, ip string, verify bool) error {
+// SetPeerTx stores a peer. A zero port means that the peer's
+// listening port is unknown, and the standard port is assumed.
+func SetPeerTx(batch *leveldb.Transaction, id crypto.Hash, ip string, port int, verify bool) error {
 	err := batch.Put(peerDataPrefix(id.String()), mustMarshalJSON(&Peer{
 		ID:       id,
 		IP:       ip,
+		Port:     port,
 		LastSeen: time.Now(),
 		Verify:   verify,
 	}), nil)
@@ -24,7 +24,7 @@ func TestPeers(t *testing.T) {
 
 	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
 		for id, ip := range idsIPs {
-			if err := SetPeerTx(tx, id, ip, true); err != nil {
+			if err := SetPeerTx(tx, id, ip, 9098, true); err != nil {
 				return err
 			}
 		}
@@ -40,6 +40,9 @@ func TestPeers(t *testing.T) {
 
 	streamedPeers := getAllPeers(t, db, true)
 	require.Equal(t, len(ids), len(streamedPeers))
+	for _, peer := range streamedPeers {
+		require.Equal(t, 9098, peer.Port)
+	}
 
 	dur := 10 * time.Minute
 	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
@@ -75,10 +78,10 @@ func TestPeers_Whitelisting(t *testing.T) {
 
 	dur := 10 * time.Minute
 	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
-		if err := SetPeerTx(tx, crypto.Rand32(), "127.0.0.1", false); err != nil {
+		if err := SetPeerTx(tx, crypto.Rand32(), "127.0.0.1", 0, false); err != nil {
 			return err
 		}
-		if err := SetPeerTx(tx, crypto.Rand32(), "127.0.0.2", false); err != nil {
+		if err := SetPeerTx(tx, crypto.Rand32(), "127.0.0.2", 0, false); err != nil {
 			return err
 		}
 		if err := WhitelistPeerTx(tx, "127.0.0.1"); err != nil {
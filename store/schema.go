@@ -0,0 +1,199 @@
+package store
+
+import (
+	"bytes"
+	"fnd/blob"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	schemaVersionKey = Prefixer("schema")("version")
+
+	ErrSchemaTooNew = errors.New("database schema is newer than this version of fnd supports")
+)
+
+// Migration upgrades the database to Version. Migrations run in
+// order, each in its own transaction along with the version bump, so
+// a failed migration leaves the database at the previous version.
+// Migrate must be idempotent.
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(tx *leveldb.Transaction) error
+}
+
+// Migrations lists every schema migration in ascending version
+// order. Databases written before versioning was introduced are at
+// version 0.
+var Migrations = []*Migration{
+	{
+		Version:     1,
+		Description: "store merkle trees for headers that only have a merkle base",
+		Migrate:     migrateMerkleTrees,
+	},
+	{
+		Version:     2,
+		Description: "rewrite name bans written before ban sources were recorded",
+		Migrate:     migrateLegacyNameBans,
+	},
+}
+
+// LatestSchemaVersion returns the schema version that this version of
+// fnd writes.
+func LatestSchemaVersion() int {
+	return Migrations[len(Migrations)-1].Version
+}
+
+// GetSchemaVersion returns the schema version of db, which is 0 if
+// no migrations have been applied.
+func GetSchemaVersion(db *leveldb.DB) (int, error) {
+	res, err := db.Get(schemaVersionKey, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error getting schema version")
+	}
+	return mustDecodeInt(res), nil
+}
+
+func SetSchemaVersionTx(tx *leveldb.Transaction, version int) error {
+	if err := tx.Put(schemaVersionKey, mustEncodeInt(version), nil); err != nil {
+		return errors.Wrap(err, "error setting schema version")
+	}
+	return nil
+}
+
+// PendingMigrations returns the migrations that have not been applied
+// to db yet.
+func PendingMigrations(db *leveldb.DB) ([]*Migration, error) {
+	version, err := GetSchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if version > LatestSchemaVersion() {
+		return nil, ErrSchemaTooNew
+	}
+	var out []*Migration
+	for _, m := range Migrations {
+		if m.Version > version {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// Migrate applies every pending migration to db, and returns the ones
+// that were applied.
+func Migrate(db *leveldb.DB) ([]*Migration, error) {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range pending {
+		logger.Info("migrating database", "version", m.Version, "description", m.Description)
+		err := WithTx(db, func(tx *leveldb.Transaction) error {
+			if err := m.Migrate(tx); err != nil {
+				return err
+			}
+			return SetSchemaVersionTx(tx, m.Version)
+		})
+		if err != nil {
+			return pending[:i], errors.Wrapf(err, "error migrating database to version %d", m.Version)
+		}
+	}
+	return pending, nil
+}
+
+// DryRunMigrations applies every pending migration to db in a single
+// transaction that is then discarded, and returns the migrations that
+// would have been applied.
+func DryRunMigrations(db *leveldb.DB) ([]*Migration, error) {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	tx, err := db.OpenTransaction()
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening transaction")
+	}
+	defer tx.Discard()
+	for _, m := range pending {
+		if err := m.Migrate(tx); err != nil {
+			return nil, errors.Wrapf(err, "error migrating database to version %d", m.Version)
+		}
+	}
+	return pending, nil
+}
+
+func migrateMerkleTrees(tx *leveldb.Transaction) error {
+	iter := tx.NewIterator(util.BytesPrefix(headerMerkleBasePrefix("")), nil)
+	bases := make(map[string][]byte)
+	prefixLen := len(headerMerkleBasePrefix(""))
+	for iter.Next() {
+		name := string(iter.Key()[prefixLen:])
+		has, err := tx.Has(headerMerkleTreePrefix(name), nil)
+		if err != nil {
+			iter.Release()
+			return errors.Wrap(err, "error checking merkle tree existence")
+		}
+		if has {
+			continue
+		}
+		bases[name] = append([]byte(nil), iter.Value()...)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "error iterating merkle bases")
+	}
+
+	for name, baseB := range bases {
+		var base blob.MerkleBase
+		if err := base.Decode(bytes.NewReader(baseB)); err != nil {
+			return errors.Wrapf(err, "error decoding merkle base for %s", name)
+		}
+		var buf bytes.Buffer
+		if err := blob.MakeTreeFromBase(base).Encode(&buf); err != nil {
+			return errors.Wrap(err, "error encoding merkle tree")
+		}
+		if err := tx.Put(headerMerkleTreePrefix(name), buf.Bytes(), nil); err != nil {
+			return errors.Wrap(err, "error writing merkle tree")
+		}
+	}
+	return nil
+}
+
+func migrateLegacyNameBans(tx *leveldb.Transaction) error {
+	iter := tx.NewIterator(util.BytesPrefix(banPrefix("")), nil)
+	var legacy []string
+	prefixLen := len(banPrefix(""))
+	for iter.Next() {
+		val := iter.Value()
+		if len(val) > 0 && val[0] == '{' {
+			continue
+		}
+		legacy = append(legacy, string(iter.Key()[prefixLen:]))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "error iterating name bans")
+	}
+
+	// legacy bans came from ban lists, so they stay in place until the
+	// next ingestion replaces them with the lists' current contents
+	for _, name := range legacy {
+		ban := &NameBan{
+			Name:    name,
+			Sources: []string{LegacyBanSource},
+		}
+		if err := SetNameBan(tx, ban); err != nil {
+			return errors.Wrap(err, "error rewriting banned name")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fnd/blob"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"testing"
+)
+
+func TestSchema_Migrate(t *testing.T) {
+	db, done := setupLevelDB(t)
+	defer done()
+
+	// write data the way unversioned databases stored it
+	var base blob.MerkleBase
+	_, err := rand.Read(base[0][:])
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, base.Encode(&buf))
+	require.NoError(t, db.Put(headerMerkleBasePrefix("foo"), buf.Bytes(), nil))
+	require.NoError(t, db.Put(banPrefix("bar"), []byte{0x01}, nil))
+
+	version, err := GetSchemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+	pending, err := PendingMigrations(db)
+	require.NoError(t, err)
+	require.Equal(t, Migrations, pending)
+
+	dryRun, err := DryRunMigrations(db)
+	require.NoError(t, err)
+	require.Equal(t, Migrations, dryRun)
+	has, err := db.Has(headerMerkleTreePrefix("foo"), nil)
+	require.NoError(t, err)
+	require.False(t, has)
+	version, err = GetSchemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+
+	applied, err := Migrate(db)
+	require.NoError(t, err)
+	require.Equal(t, Migrations, applied)
+	version, err = GetSchemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, LatestSchemaVersion(), version)
+
+	treeB, err := db.Get(headerMerkleTreePrefix("foo"), nil)
+	require.NoError(t, err)
+	var tree blob.MerkleTree
+	require.NoError(t, tree.Decode(bytes.NewReader(treeB)))
+	require.Equal(t, blob.MakeTreeFromBase(base).Root(), tree.Root())
+	banB, err := db.Get(banPrefix("bar"), nil)
+	require.NoError(t, err)
+	require.Equal(t, byte('{'), banB[0])
+	ban, err := GetNameBan(db, "bar")
+	require.NoError(t, err)
+	require.Equal(t, []string{LegacyBanSource}, ban.Sources)
+
+	applied, err = Migrate(db)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	require.NoError(t, WithTx(db, func(tx *leveldb.Transaction) error {
+		return SetSchemaVersionTx(tx, LatestSchemaVersion()+1)
+	}))
+	_, err = Migrate(db)
+	require.Equal(t, ErrSchemaTooNew, err)
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"fmt"
+	"fnd/blob"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	stagedTxsPrefix       = Prefixer("staged-txs")
+	stagedTxPrefix        = Prefixer(string(stagedTxsPrefix("tx")))
+	stagedTxSectorsPrefix = Prefixer(string(stagedTxsPrefix("sectors")))
+)
+
+// StagedTx describes an RPC blob transaction that is persisted so that
+// it survives restarts. Its dirty sectors are stored separately.
+type StagedTx struct {
+	ID        uint32        `json:"id"`
+	Name      string        `json:"name"`
+	Truncated bool          `json:"truncated"`
+	CreatedAt time.Time     `json:"created_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+func GetStagedTxs(db *leveldb.DB) ([]*StagedTx, error) {
+	iter := db.NewIterator(util.BytesPrefix(stagedTxPrefix("")), nil)
+	defer iter.Release()
+	var out []*StagedTx
+	for iter.Next() {
+		stx := new(StagedTx)
+		mustUnmarshalJSON(iter.Value(), stx)
+		out = append(out, stx)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error iterating staged transactions")
+	}
+	return out, nil
+}
+
+func SetStagedTxTx(tx *leveldb.Transaction, stx *StagedTx) error {
+	if err := tx.Put(stagedTxPrefix(stagedTxKey(stx.ID)), mustMarshalJSON(stx), nil); err != nil {
+		return errors.Wrap(err, "error writing staged transaction")
+	}
+	return nil
+}
+
+// GetStagedTxSectors returns the dirty sectors of a staged
+// transaction.
+func GetStagedTxSectors(db *leveldb.DB, id uint32) (map[uint8]blob.Sector, error) {
+	prefix := stagedTxSectorsPrefix(stagedTxKey(id), "")
+	iter := db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	out := make(map[uint8]blob.Sector)
+	for iter.Next() {
+		sectorID, err := strconv.ParseUint(strings.TrimPrefix(string(iter.Key()), string(prefix)), 10, 8)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid staged sector key")
+		}
+		var sector blob.Sector
+		copy(sector[:], iter.Value())
+		out[uint8(sectorID)] = sector
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "error iterating staged sectors")
+	}
+	return out, nil
+}
+
+func SetStagedTxSectorTx(tx *leveldb.Transaction, id uint32, sectorID uint8, sector blob.Sector) error {
+	key := stagedTxSectorsPrefix(stagedTxKey(id), strconv.Itoa(int(sectorID)))
+	if err := tx.Put(key, sector[:], nil); err != nil {
+		return errors.Wrap(err, "error writing staged sector")
+	}
+	return nil
+}
+
+// TruncateStagedTxSectorsTx deletes every dirty sector of a staged
+// transaction.
+func TruncateStagedTxSectorsTx(tx *leveldb.Transaction, id uint32) error {
+	iter := tx.NewIterator(util.BytesPrefix(stagedTxSectorsPrefix(stagedTxKey(id), "")), nil)
+	var keys [][]byte
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "error iterating staged sectors")
+	}
+	for _, key := range keys {
+		if err := tx.Delete(key, nil); err != nil {
+			return errors.Wrap(err, "error deleting staged sector")
+		}
+	}
+	return nil
+}
+
+func DeleteStagedTxTx(tx *leveldb.Transaction, id uint32) error {
+	if err := TruncateStagedTxSectorsTx(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Delete(stagedTxPrefix(stagedTxKey(id)), nil); err != nil {
+		return errors.Wrap(err, "error deleting staged transaction")
+	}
+	return nil
+}
+
+func stagedTxKey(id uint32) string {
+	return fmt.Sprintf("%010d", id)
+}
@@ -5,6 +5,7 @@ import (
 	"fnd/p2p"
 	"fnd/testutil"
 	"fnd/testutil/testcrypto"
+	"fnd/wire"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -27,6 +28,10 @@ func ConnectTestPeers(t *testing.T) (*TestPeers, func()) {
 	clientConn, serverConn := testutil.NewTCPConn(t)
 	localPeer := p2p.NewPeer(p2p.Outbound, clientConn)
 	remotePeer := p2p.NewPeer(p2p.Inbound, serverConn)
+	// test peers don't handshake, so give them the services of a
+	// legacy node
+	localPeer.SetCapabilities(wire.LegacyServices, nil)
+	remotePeer.SetCapabilities(wire.LegacyServices, nil)
 
 	localMux := p2p.NewPeerMuxer(testutil.TestMagic, localSigner)
 	require.NoError(t, localMux.AddPeer(crypto.HashPub(remotePub), localPeer))
@@ -61,6 +66,10 @@ func ConnectAdditionalPeer(t *testing.T, localSigner crypto.Signer, localMux *p2
 	clientConn, serverConn := testutil.NewTCPConn(t)
 	localPeer := p2p.NewPeer(p2p.Outbound, clientConn)
 	remotePeer := p2p.NewPeer(p2p.Inbound, serverConn)
+	// test peers don't handshake, so give them the services of a
+	// legacy node
+	localPeer.SetCapabilities(wire.LegacyServices, nil)
+	remotePeer.SetCapabilities(wire.LegacyServices, nil)
 	remoteMux := p2p.NewPeerMuxer(testutil.TestMagic, remoteSigner)
 	require.NoError(t, localMux.AddPeer(crypto.HashPub(remotePub), localPeer))
 	require.NoError(t, remoteMux.AddPeer(crypto.HashPub(localSigner.Pub()), remotePeer))
@@ -64,7 +64,7 @@ func FillBlobReader(t *testing.T, db *leveldb.DB, bs blob.Store, signer crypto.S
 			Signature:    sig,
 			ReservedRoot: crypto.ZeroHash,
 			ReceivedAt:   receivedAt,
-		}, tree.ProtocolBase())
+		}, tree)
 	}))
 	require.NoError(t, tx.Commit())
 	return &wire.Update{
@@ -38,18 +38,22 @@ func NewCache() *Cache {
 	}
 }
 
+// Get returns the value stored under key, or nil if there is none or
+// it has expired.
 func (l *Cache) Get(key string) interface{} {
 	l.entriesMtx.Lock()
-	defer l.entriesMtx.Unlock()
 	entry := l.entries[key]
 	if entry == nil {
+		l.entriesMtx.Unlock()
 		return nil
 	}
 	if time.Now().UnixNano() > entry.expiry {
 		delete(l.entries, key)
+		l.entriesMtx.Unlock()
 		l.ReaperFunc(key, entry.val)
 		return nil
 	}
+	l.entriesMtx.Unlock()
 	return entry.val
 }
 
@@ -58,26 +62,56 @@ func (l *Cache) Set(key string, val interface{}, expMS int64) {
 		panic("cache values cannot be nil")
 	}
 
-	var expiry int64
-	if expMS == 0 {
-		expiry = math.MaxInt16
-	} else {
-		expiry = time.Now().Add(time.Duration(expMS) * time.Millisecond).UnixNano()
-	}
-
 	l.entriesMtx.Lock()
 	defer l.entriesMtx.Unlock()
 	l.entries[key] = &entry{
 		val:    val,
-		expiry: expiry,
+		expiry: expiryFor(expMS),
 	}
 	l.touchReaper()
 }
 
+// Touch resets the expiry of the entry stored under key and returns
+// its value. Expired entries are not renewed, so Touch returns false
+// for them just as for missing ones.
+func (l *Cache) Touch(key string, expMS int64) (interface{}, bool) {
+	l.entriesMtx.Lock()
+	entry := l.entries[key]
+	if entry == nil {
+		l.entriesMtx.Unlock()
+		return nil, false
+	}
+	if time.Now().UnixNano() > entry.expiry {
+		delete(l.entries, key)
+		l.entriesMtx.Unlock()
+		l.ReaperFunc(key, entry.val)
+		return nil, false
+	}
+	entry.expiry = expiryFor(expMS)
+	l.entriesMtx.Unlock()
+	return entry.val, true
+}
+
 func (l *Cache) Has(key string) bool {
 	return l.Get(key) != nil
 }
 
+// Range calls fn for every entry that has not expired, until fn
+// returns false. fn must not call the cache's other methods.
+func (l *Cache) Range(fn func(key string, val interface{}) bool) {
+	l.entriesMtx.Lock()
+	defer l.entriesMtx.Unlock()
+	now := time.Now().UnixNano()
+	for k, entry := range l.entries {
+		if now > entry.expiry {
+			continue
+		}
+		if !fn(k, entry.val) {
+			return
+		}
+	}
+}
+
 func (l *Cache) Del(key string) {
 	l.entriesMtx.Lock()
 	defer l.entriesMtx.Unlock()
@@ -91,35 +125,52 @@ func (l *Cache) touchReaper() {
 		return
 	}
 
-	l.reapTicker = time.NewTicker(100 * time.Millisecond)
+	ticker := time.NewTicker(l.ReapInterval)
+	l.reapTicker = ticker
 	go func() {
 		for {
-			<-l.reapTicker.C
+			<-ticker.C
 			l.entriesMtx.Lock()
-			l.reap()
-			if len(l.entries) == 0 {
+			reaped := l.reap()
+			empty := len(l.entries) == 0
+			if empty {
 				l.reapMtx.Lock()
+				ticker.Stop()
 				l.reapTicker = nil
 				l.reapMtx.Unlock()
-				l.entriesMtx.Unlock()
-				return
 			}
 			l.entriesMtx.Unlock()
+
+			// the reaper may be slow, so it runs without holding
+			// the lock
+			for k, val := range reaped {
+				l.ReaperFunc(k, val)
+			}
+			if empty {
+				return
+			}
 		}
 	}()
 }
 
-func (l *Cache) reap() {
-	var toDelete []string
+// reap removes expired entries and returns them. The caller must hold
+// entriesMtx.
+func (l *Cache) reap() map[string]interface{} {
+	now := time.Now().UnixNano()
+	reaped := make(map[string]interface{})
 	for k, entry := range l.entries {
-		if time.Now().Unix() < entry.expiry {
+		if now < entry.expiry {
 			continue
 		}
-		toDelete = append(toDelete, k)
+		reaped[k] = entry.val
+		delete(l.entries, k)
 	}
+	return reaped
+}
 
-	for _, k := range toDelete {
-		l.ReaperFunc(k, l.entries[k].val)
-		delete(l.entries, k)
+func expiryFor(expMS int64) int64 {
+	if expMS == 0 {
+		return math.MaxInt64
 	}
+	return time.Now().Add(time.Duration(expMS) * time.Millisecond).UnixNano()
 }
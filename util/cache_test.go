@@ -63,6 +63,58 @@ func TestCache_GetSetHas_ProactiveExpiry(t *testing.T) {
 	assert.EqualValues(t, 123, atomic.LoadInt32(&reaped))
 }
 
+func TestCache_Reaper(t *testing.T) {
+	cache := NewCache()
+	cache.ReapInterval = 10 * time.Millisecond
+	reaped := make(chan interface{}, 1)
+	cache.ReaperFunc = func(key string, val interface{}) {
+		// reapers run outside the cache's lock
+		cache.Set("other", val, 0)
+		reaped <- val
+	}
+	cache.Set("test", 123, 10)
+	cache.Set("live", 456, 0)
+	select {
+	case val := <-reaped:
+		assert.EqualValues(t, 123, val)
+	case <-time.After(time.Second):
+		t.Fatal("expired entry was not reaped")
+	}
+	count := 0
+	cache.Range(func(key string, val interface{}) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 2, count)
+	assert.EqualValues(t, 456, cache.Get("live"))
+}
+
+func TestCache_Touch(t *testing.T) {
+	cache := NewCache()
+	var reaped int32
+	cache.ReaperFunc = func(key string, val interface{}) {
+		atomic.AddInt32(&reaped, 1)
+	}
+	cache.Set("test", 123, 50)
+	time.Sleep(30 * time.Millisecond)
+	val, ok := cache.Touch("test", 100)
+	assert.True(t, ok)
+	assert.EqualValues(t, 123, val)
+	time.Sleep(30 * time.Millisecond)
+	assert.EqualValues(t, 123, cache.Get("test"))
+
+	// expired entries are reaped rather than renewed
+	cache.Set("expired", 456, 10)
+	time.Sleep(20 * time.Millisecond)
+	_, ok = cache.Touch("expired", 100)
+	assert.False(t, ok)
+	assert.False(t, cache.Has("expired"))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&reaped))
+
+	_, ok = cache.Touch("missing", 100)
+	assert.False(t, ok)
+}
+
 func TestCache_Del(t *testing.T) {
 	cache := NewCache()
 	cache.Set("test", 123, 0)
@@ -0,0 +1,13 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+var ErrFreeDiskSpaceUnsupported = errors.New("free disk space is not supported on this platform")
+
+// FreeDiskSpace returns the number of bytes available to unprivileged
+// users on the filesystem containing path.
+func FreeDiskSpace(path string) (uint64, error) {
+	return freeDiskSpace(path)
+}
@@ -0,0 +1,5 @@
+package util
+
+func freeDiskSpace(path string) (uint64, error) {
+	return 0, ErrFreeDiskSpaceUnsupported
+}
@@ -2,6 +2,11 @@ package util
 
 import (
 	"sync"
+	"time"
+)
+
+const (
+	lockPollInterval = 10 * time.Millisecond
 )
 
 type refCounter struct {
@@ -84,3 +89,29 @@ func (l *lock) getLocker(key interface{}) *refCounter {
 
 	return res
 }
+
+// LockTimeout write-locks key, waiting up to timeout for the current
+// holders to release it. It returns false if the lock wasn't taken.
+func LockTimeout(l MultiLocker, key interface{}, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !l.TryLock(key) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(lockPollInterval)
+	}
+	return true
+}
+
+// RLockTimeout read-locks key, waiting up to timeout for a writer to
+// release it. It returns false if the lock wasn't taken.
+func RLockTimeout(l MultiLocker, key interface{}, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !l.TryRLock(key) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(lockPollInterval)
+	}
+	return true
+}
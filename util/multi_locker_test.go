@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestMultiLocker(t *testing.T) {
@@ -57,3 +58,21 @@ func TestMultiLocker(t *testing.T) {
 
 	assert.True(t, locker.TryLock(n1))
 }
+
+func TestLockTimeout(t *testing.T) {
+	locker := NewMultiLocker()
+	assert.True(t, locker.TryRLock("foo"))
+	assert.False(t, LockTimeout(locker, "foo", 20*time.Millisecond))
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		locker.RUnlock("foo")
+	}()
+	assert.True(t, LockTimeout(locker, "foo", time.Second))
+	assert.False(t, RLockTimeout(locker, "foo", 20*time.Millisecond))
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		locker.Unlock("foo")
+	}()
+	assert.True(t, RLockTimeout(locker, "foo", time.Second))
+	locker.RUnlock("foo")
+}
@@ -0,0 +1,109 @@
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"fnd.localhost/dwire"
+	"fnd/crypto"
+	"io"
+)
+
+// Compressed wraps a compressed SectorRes, SectorBatchRes or
+// TreeBaseRes. It is only sent to peers that advertise support for
+// Codec in their ExtensionCompression extension.
+type Compressed struct {
+	HashCacher
+
+	Type  MessageType
+	Codec uint8
+	Data  []byte
+}
+
+var _ Message = (*Compressed)(nil)
+
+// IsCompressible returns true if messages of type t can be sent
+// inside Compressed.
+func IsCompressible(t MessageType) bool {
+	return t == MessageTypeSectorRes ||
+		t == MessageTypeSectorBatchRes ||
+		t == MessageTypeTreeBaseRes
+}
+
+func NewCompressed(codec uint8, msg Message) (*Compressed, error) {
+	if !IsCompressible(msg.MsgType()) {
+		return nil, fmt.Errorf("message type %s cannot be compressed", msg.MsgType())
+	}
+	var buf bytes.Buffer
+	if err := msg.Encode(&buf); err != nil {
+		return nil, err
+	}
+	data, err := Compress(codec, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &Compressed{
+		Type:  msg.MsgType(),
+		Codec: codec,
+		Data:  data,
+	}, nil
+}
+
+// Unwrap decompresses and decodes the wrapped message.
+func (c *Compressed) Unwrap() (Message, error) {
+	var msg Message
+	switch c.Type {
+	case MessageTypeSectorRes:
+		msg = &SectorRes{}
+	case MessageTypeSectorBatchRes:
+		msg = &SectorBatchRes{}
+	case MessageTypeTreeBaseRes:
+		msg = &TreeBaseRes{}
+	default:
+		return nil, fmt.Errorf("message type %s cannot be compressed", c.Type)
+	}
+	data, err := Decompress(c.Codec, c.Data, MaxDecompressedLen)
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.Decode(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (c *Compressed) MsgType() MessageType {
+	return MessageTypeCompressed
+}
+
+func (c *Compressed) Equals(other Message) bool {
+	cast, ok := other.(*Compressed)
+	if !ok {
+		return false
+	}
+
+	return c.Type == cast.Type &&
+		c.Codec == cast.Codec &&
+		bytes.Equal(c.Data, cast.Data)
+}
+
+func (c *Compressed) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		c.Type,
+		c.Codec,
+		c.Data,
+	)
+}
+
+func (c *Compressed) Decode(r io.Reader) error {
+	return dwire.DecodeFields(
+		r,
+		&c.Type,
+		&c.Codec,
+		&c.Data,
+	)
+}
+
+func (c *Compressed) Hash() (crypto.Hash, error) {
+	return c.HashCacher.Hash(c)
+}
@@ -0,0 +1,35 @@
+package wire
+
+import (
+	"bytes"
+	"fnd/blob"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompressed_Encoding(t *testing.T) {
+	sectorRes := &SectorRes{
+		Name:     "foobar",
+		SectorID: 1,
+	}
+	copy(sectorRes.Sector[:], "hello")
+	compressed, err := NewCompressed(CompressionZeroRun, sectorRes)
+	require.NoError(t, err)
+	testMessageEncoding(t, "compressed", compressed, &Compressed{})
+
+	unwrapped, err := compressed.Unwrap()
+	require.NoError(t, err)
+	require.True(t, sectorRes.Equals(unwrapped))
+
+	_, err = NewCompressed(CompressionZeroRun, &Ping{})
+	require.Error(t, err)
+	// the wrapped message can't be larger than a sector batch
+	bomb := &Compressed{
+		Type:  MessageTypeTreeBaseRes,
+		Codec: CompressionZeroRun,
+		Data:  bytes.Repeat([]byte{0x00, 0xff, 0xff}, 2),
+	}
+	_, err = bomb.Unwrap()
+	require.Equal(t, ErrDecompressedTooLarge, err)
+	require.True(t, MaxDecompressedLen > MaxSectorBatchResLen*(len(blob.Sector{})+1)+64)
+}
@@ -0,0 +1,190 @@
+package wire
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	CompressionNone uint8 = iota
+	// CompressionZeroRun replaces runs of zero bytes with their
+	// length. It is cheap, and works well on mostly-empty sectors.
+	CompressionZeroRun
+	// CompressionFlate is DEFLATE as specified in RFC 1951.
+	CompressionFlate
+)
+
+// MaxDecompressedLen caps the size of decompressed data, so that
+// peers can't send decompression bombs. It fits a full
+// SectorBatchRes.
+const MaxDecompressedLen = 80 * 1024
+
+const (
+	zeroRunToken    = 0x00
+	literalRunToken = 0x01
+	minZeroRun      = 4
+	maxRunLen       = 0xffff
+)
+
+var ErrDecompressedTooLarge = errors.New("decompressed data is too large")
+
+func CompressionName(codec uint8) string {
+	switch codec {
+	case CompressionNone:
+		return "none"
+	case CompressionZeroRun:
+		return "zero_run"
+	case CompressionFlate:
+		return "flate"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCompression returns the codec with the given name, as
+// returned by CompressionName.
+func ParseCompression(name string) (uint8, error) {
+	for codec := CompressionZeroRun; codec <= CompressionFlate; codec++ {
+		if CompressionName(codec) == name {
+			return codec, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid compression codec: %s", name)
+}
+
+// NegotiateCompression returns the first codec in local that the
+// remote node supports, or CompressionNone if there isn't one.
+func NegotiateCompression(local []uint8, remote []uint8) uint8 {
+	for _, codec := range local {
+		for _, remoteCodec := range remote {
+			if codec == remoteCodec && codec != CompressionNone {
+				return codec
+			}
+		}
+	}
+	return CompressionNone
+}
+
+func Compress(codec uint8, data []byte) ([]byte, error) {
+	switch codec {
+	case CompressionZeroRun:
+		return compressZeroRun(data), nil
+	case CompressionFlate:
+		var buf bytes.Buffer
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating flate writer")
+		}
+		if _, err := fw.Write(data); err != nil {
+			return nil, errors.Wrap(err, "error compressing data")
+		}
+		if err := fw.Close(); err != nil {
+			return nil, errors.Wrap(err, "error compressing data")
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %d", codec)
+	}
+}
+
+// Decompress decompresses data, and returns ErrDecompressedTooLarge
+// if the result would be longer than maxLen.
+func Decompress(codec uint8, data []byte, maxLen int) ([]byte, error) {
+	switch codec {
+	case CompressionZeroRun:
+		return decompressZeroRun(data, maxLen)
+	case CompressionFlate:
+		fr := flate.NewReader(bytes.NewReader(data))
+		defer fr.Close()
+		out, err := ioutil.ReadAll(io.LimitReader(fr, int64(maxLen)+1))
+		if err != nil {
+			return nil, errors.Wrap(err, "error decompressing data")
+		}
+		if len(out) > maxLen {
+			return nil, ErrDecompressedTooLarge
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %d", codec)
+	}
+}
+
+// compressZeroRun encodes data as a series of runs. Each run is a
+// token byte followed by a big-endian uint16 length. Zero runs are
+// expanded to that many zero bytes, and literal runs are followed
+// by that many bytes.
+func compressZeroRun(data []byte) []byte {
+	var buf bytes.Buffer
+	var literalStart int
+	writeRun := func(token byte, n int) {
+		var hdr [3]byte
+		hdr[0] = token
+		binary.BigEndian.PutUint16(hdr[1:], uint16(n))
+		buf.Write(hdr[:])
+	}
+	flushLiteral := func(end int) {
+		for literalStart < end {
+			n := end - literalStart
+			if n > maxRunLen {
+				n = maxRunLen
+			}
+			writeRun(literalRunToken, n)
+			buf.Write(data[literalStart : literalStart+n])
+			literalStart += n
+		}
+	}
+
+	for i := 0; i < len(data); {
+		if data[i] != 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(data) && data[j] == 0 && j-i < maxRunLen {
+			j++
+		}
+		if j-i < minZeroRun {
+			i = j
+			continue
+		}
+		flushLiteral(i)
+		writeRun(zeroRunToken, j-i)
+		i = j
+		literalStart = j
+	}
+	flushLiteral(len(data))
+	return buf.Bytes()
+}
+
+func decompressZeroRun(data []byte, maxLen int) ([]byte, error) {
+	var out []byte
+	for len(data) > 0 {
+		if len(data) < 3 {
+			return nil, errors.New("truncated run header")
+		}
+		token := data[0]
+		n := int(binary.BigEndian.Uint16(data[1:3]))
+		data = data[3:]
+		if len(out)+n > maxLen {
+			return nil, ErrDecompressedTooLarge
+		}
+		switch token {
+		case zeroRunToken:
+			out = append(out, make([]byte, n)...)
+		case literalRunToken:
+			if len(data) < n {
+				return nil, errors.New("truncated literal run")
+			}
+			out = append(out, data[:n]...)
+			data = data[n:]
+		default:
+			return nil, fmt.Errorf("invalid run token: %d", token)
+		}
+	}
+	return out, nil
+}
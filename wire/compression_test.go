@@ -0,0 +1,66 @@
+package wire
+
+import (
+	"bytes"
+	"fnd/blob"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	var sector blob.Sector
+	copy(sector[100:], []byte("some text in a mostly empty sector"))
+	sector[4095] = 0xff
+	inputs := [][]byte{
+		nil,
+		{0x00},
+		{0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		bytes.Repeat([]byte{0x01}, 70000),
+		make([]byte, 70000),
+		sector[:],
+	}
+	for _, codec := range []uint8{CompressionZeroRun, CompressionFlate} {
+		for _, input := range inputs {
+			compressed, err := Compress(codec, input)
+			require.NoError(t, err)
+			out, err := Decompress(codec, compressed, len(input))
+			require.NoError(t, err)
+			require.Equal(t, len(input), len(out))
+			require.True(t, bytes.Equal(input, out))
+		}
+
+		compressed, err := Compress(codec, sector[:])
+		require.NoError(t, err)
+		require.True(t, len(compressed) < 200, "%s compressed to %d bytes", CompressionName(codec), len(compressed))
+		_, err = Decompress(codec, compressed, len(sector)-1)
+		require.Equal(t, ErrDecompressedTooLarge, err)
+	}
+
+	// a small zero run encoding can't expand past the limit
+	_, err := Decompress(CompressionZeroRun, bytes.Repeat([]byte{0x00, 0xff, 0xff}, 1000), MaxDecompressedLen)
+	require.Equal(t, ErrDecompressedTooLarge, err)
+	_, err = Decompress(CompressionZeroRun, []byte{0x01, 0x00, 0x05, 0x01}, MaxDecompressedLen)
+	require.Error(t, err)
+	_, err = Compress(CompressionNone, sector[:])
+	require.Error(t, err)
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	require.Equal(t, CompressionFlate, NegotiateCompression(
+		[]uint8{CompressionFlate, CompressionZeroRun},
+		[]uint8{CompressionZeroRun, CompressionFlate},
+	))
+	require.Equal(t, CompressionZeroRun, NegotiateCompression(
+		[]uint8{CompressionFlate, CompressionZeroRun},
+		[]uint8{CompressionZeroRun},
+	))
+	require.Equal(t, CompressionNone, NegotiateCompression(
+		[]uint8{CompressionFlate},
+		nil,
+	))
+	codec, err := ParseCompression("zero_run")
+	require.NoError(t, err)
+	require.Equal(t, CompressionZeroRun, codec)
+	_, err = ParseCompression("none")
+	require.Error(t, err)
+}
@@ -96,6 +96,16 @@ func (e *Envelope) Decode(r io.Reader) error {
 		msg = &PeerRes{}
 	case MessageTypeUpdateReq:
 		msg = &UpdateReq{}
+	case MessageTypeUpdateInv:
+		msg = &UpdateInv{}
+	case MessageTypeCompressed:
+		msg = &Compressed{}
+	case MessageTypeSectorBatchReq:
+		msg = &SectorBatchReq{}
+	case MessageTypeSectorBatchRes:
+		msg = &SectorBatchRes{}
+	case MessageTypeSectorBatchAck:
+		msg = &SectorBatchAck{}
 	default:
 		return fmt.Errorf("invalid message type: %d", e.MessageType)
 	}
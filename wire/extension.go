@@ -0,0 +1,112 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fnd.localhost/dwire"
+	"io"
+)
+
+const (
+	// ExtensionListenPort holds the port a node accepts inbound
+	// connections on, as a big-endian uint16.
+	ExtensionListenPort uint16 = iota + 1
+	// ExtensionCompression lists the compression codecs a node can
+	// decompress, one byte each, in order of preference.
+	ExtensionCompression
+)
+
+// Extension is an optional, typed field exchanged during the
+// handshake. Nodes ignore extensions they don't understand.
+type Extension struct {
+	Type uint16
+	Data []byte
+}
+
+func (e *Extension) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		e.Type,
+		e.Data,
+	)
+}
+
+func (e *Extension) Decode(r io.Reader) error {
+	return dwire.DecodeFields(
+		r,
+		&e.Type,
+		&e.Data,
+	)
+}
+
+type Extensions []*Extension
+
+// Get returns the data of the last extension with type t.
+func (e Extensions) Get(t uint16) ([]byte, bool) {
+	for i := len(e) - 1; i >= 0; i-- {
+		if e[i].Type == t {
+			return e[i].Data, true
+		}
+	}
+	return nil, false
+}
+
+func (e Extensions) Equals(other Extensions) bool {
+	if len(e) != len(other) {
+		return false
+	}
+	for i := range e {
+		if e[i].Type != other[i].Type || string(e[i].Data) != string(other[i].Data) {
+			return false
+		}
+	}
+	return true
+}
+
+func NewListenPortExtension(port int) *Extension {
+	data := make([]byte, 2)
+	binary.BigEndian.PutUint16(data, uint16(port))
+	return &Extension{
+		Type: ExtensionListenPort,
+		Data: data,
+	}
+}
+
+// ListenPort returns the port from the ExtensionListenPort
+// extension, if there is a valid one.
+func (e Extensions) ListenPort() (int, bool) {
+	data, ok := e.Get(ExtensionListenPort)
+	if !ok || len(data) != 2 {
+		return 0, false
+	}
+	port := int(binary.BigEndian.Uint16(data))
+	return port, port != 0
+}
+
+func NewCompressionExtension(codecs []uint8) *Extension {
+	return &Extension{
+		Type: ExtensionCompression,
+		Data: append([]byte{}, codecs...),
+	}
+}
+
+// Compression returns the codecs from the ExtensionCompression
+// extension.
+func (e Extensions) Compression() []uint8 {
+	data, _ := e.Get(ExtensionCompression)
+	return data
+}
+
+// decodeExtensions decodes a trailing extension list, which is
+// missing in messages from older nodes.
+func decodeExtensions(r io.Reader, exts *Extensions) error {
+	var decoded []*Extension
+	err := dwire.DecodeField(r, &decoded)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	*exts = decoded
+	return nil
+}
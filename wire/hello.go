@@ -15,6 +15,11 @@ type Hello struct {
 	RemoteNonce     [32]byte
 	PublicKey       *btcec.PublicKey
 	UserAgent       string
+	// Services is a bitfield of the optional features the sender
+	// supports. It and Extensions are appended after the other
+	// fields, and only when set, so older nodes ignore them.
+	Services   uint64
+	Extensions Extensions
 }
 
 var _ Message = (*Hello)(nil)
@@ -33,14 +38,16 @@ func (h *Hello) Equals(other Message) bool {
 		h.LocalNonce == cast.LocalNonce &&
 		h.RemoteNonce == cast.RemoteNonce &&
 		h.PublicKey.IsEqual(cast.PublicKey) &&
-		h.UserAgent == cast.UserAgent
+		h.UserAgent == cast.UserAgent &&
+		h.Services == cast.Services &&
+		h.Extensions.Equals(cast.Extensions)
 }
 
 func (h *Hello) Encode(w io.Writer) error {
 	pubEnc := &PublicKeyEncoder{
 		PublicKey: h.PublicKey,
 	}
-	return dwire.EncodeFields(
+	err := dwire.EncodeFields(
 		w,
 		h.ProtocolVersion,
 		h.LocalNonce,
@@ -48,6 +55,19 @@ func (h *Hello) Encode(w io.Writer) error {
 		pubEnc,
 		h.UserAgent,
 	)
+	if err != nil {
+		return err
+	}
+	if h.Services == 0 && len(h.Extensions) == 0 {
+		return nil
+	}
+	if err := dwire.EncodeField(w, h.Services); err != nil {
+		return err
+	}
+	if len(h.Extensions) == 0 {
+		return nil
+	}
+	return dwire.EncodeField(w, []*Extension(h.Extensions))
 }
 
 func (h *Hello) Decode(r io.Reader) error {
@@ -64,7 +84,18 @@ func (h *Hello) Decode(r io.Reader) error {
 		return err
 	}
 	h.PublicKey = pubEnc.PublicKey
-	return nil
+	err = dwire.DecodeField(r, &h.Services)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return decodeExtensions(r, &h.Extensions)
+}
+
+func (h *Hello) HasService(service uint64) bool {
+	return h.Services&service != 0
 }
 
 func (h *Hello) Hash() (crypto.Hash, error) {
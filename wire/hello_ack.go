@@ -10,6 +10,9 @@ type HelloAck struct {
 	HashCacher
 
 	Nonce [32]byte
+	// Extensions are appended after the nonce, and only when set,
+	// so older nodes ignore them.
+	Extensions Extensions
 }
 
 var _ Message = (*HelloAck)(nil)
@@ -24,21 +27,25 @@ func (h *HelloAck) Equals(other Message) bool {
 		return false
 	}
 
-	return h.Nonce == cast.Nonce
+	return h.Nonce == cast.Nonce &&
+		h.Extensions.Equals(cast.Extensions)
 }
 
 func (h *HelloAck) Encode(w io.Writer) error {
-	return dwire.EncodeFields(
-		w,
-		h.Nonce,
-	)
+	if err := dwire.EncodeField(w, h.Nonce); err != nil {
+		return err
+	}
+	if len(h.Extensions) == 0 {
+		return nil
+	}
+	return dwire.EncodeField(w, []*Extension(h.Extensions))
 }
 
 func (h *HelloAck) Decode(r io.Reader) error {
-	return dwire.DecodeFields(
-		r,
-		&h.Nonce,
-	)
+	if err := dwire.DecodeField(r, &h.Nonce); err != nil {
+		return err
+	}
+	return decodeExtensions(r, &h.Extensions)
 }
 
 func (h *HelloAck) Hash() (crypto.Hash, error) {
@@ -11,3 +11,14 @@ func TestHelloAck_Encoding(t *testing.T) {
 
 	testMessageEncoding(t, "hello_ack", helloAck, &HelloAck{})
 }
+
+func TestHelloAck_EncodingExtensions(t *testing.T) {
+	helloAck := &HelloAck{
+		Nonce: fixedHash,
+		Extensions: Extensions{
+			NewListenPortExtension(9098),
+		},
+	}
+
+	testMessageEncoding(t, "hello_ack_extensions", helloAck, &HelloAck{})
+}
@@ -2,6 +2,7 @@ package wire
 
 import (
 	"fnd/testutil/testcrypto"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -16,3 +17,47 @@ func TestHello_Encoding(t *testing.T) {
 	}
 	testMessageEncoding(t, "hello", hello, &Hello{})
 }
+
+func TestHello_EncodingServices(t *testing.T) {
+	_, pub := testcrypto.FixedKey(t)
+	hello := &Hello{
+		ProtocolVersion: 1,
+		LocalNonce:      fixedHash,
+		RemoteNonce:     fixedHash,
+		PublicKey:       pub,
+		UserAgent:       "foobar",
+		Services:        ServiceUpdateInv,
+	}
+	testMessageEncoding(t, "hello_services", hello, &Hello{})
+	require.True(t, hello.HasService(ServiceUpdateInv))
+}
+
+func TestHello_EncodingExtensions(t *testing.T) {
+	_, pub := testcrypto.FixedKey(t)
+	hello := &Hello{
+		ProtocolVersion: 1,
+		LocalNonce:      fixedHash,
+		RemoteNonce:     fixedHash,
+		PublicKey:       pub,
+		UserAgent:       "foobar",
+		Services:        ServiceUpdateInv | ServiceBlobs | ServiceInbound,
+		Extensions: Extensions{
+			NewListenPortExtension(9098),
+			{
+				Type: 0xffff,
+				Data: []byte{0x01, 0x02},
+			},
+		},
+	}
+	testMessageEncoding(t, "hello_extensions", hello, &Hello{})
+
+	port, ok := hello.Extensions.ListenPort()
+	require.True(t, ok)
+	require.Equal(t, 9098, port)
+	data, ok := hello.Extensions.Get(0xffff)
+	require.True(t, ok)
+	require.Equal(t, []byte{0x01, 0x02}, data)
+	_, ok = hello.Extensions.Get(0xfffe)
+	require.False(t, ok)
+	require.Equal(t, []string{"update_inv", "blobs", "inbound"}, ServiceNames(hello.Services))
+}
@@ -1,6 +1,7 @@
 package wire
 
 import (
+	"fmt"
 	"fnd/crypto"
 	"fnd.localhost/dwire"
 	"io"
@@ -29,6 +30,11 @@ const (
 	MessageTypePeerRes
 	MessageTypeUpdateReq
 	MessageTypeNameRes
+	MessageTypeUpdateInv
+	MessageTypeCompressed
+	MessageTypeSectorBatchReq
+	MessageTypeSectorBatchRes
+	MessageTypeSectorBatchAck
 )
 
 func (t MessageType) String() string {
@@ -59,11 +65,32 @@ func (t MessageType) String() string {
 		return "UpdateReq"
 	case MessageTypeNameRes:
 		return "NameRes"
+	case MessageTypeUpdateInv:
+		return "UpdateInv"
+	case MessageTypeCompressed:
+		return "Compressed"
+	case MessageTypeSectorBatchReq:
+		return "SectorBatchReq"
+	case MessageTypeSectorBatchRes:
+		return "SectorBatchRes"
+	case MessageTypeSectorBatchAck:
+		return "SectorBatchAck"
 	default:
 		return "unknown"
 	}
 }
 
+// ParseMessageType returns the message type with the given name, as
+// returned by String.
+func ParseMessageType(name string) (MessageType, error) {
+	for t := MessageTypeHello; t <= MessageTypeSectorBatchAck; t++ {
+		if t.String() == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid message type: %s", name)
+}
+
 func (t MessageType) Encode(w io.Writer) error {
 	return dwire.EncodeField(w, uint16(t))
 }
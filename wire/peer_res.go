@@ -2,6 +2,7 @@ package wire
 
 import (
 	"bytes"
+	"errors"
 	"fnd/crypto"
 	"fnd.localhost/dwire"
 	"io"
@@ -11,12 +12,26 @@ import (
 type Peer struct {
 	IP net.IP
 	ID crypto.Hash
+	// Port, Host and Services are not part of the peer's encoding.
+	// They are sent in trailing lists on PeerRes so that older nodes
+	// can still decode the message. Zero means the standard port.
+	Port uint16
+	// Host is set for peers without an IP, like onion addresses.
+	// The IP of these peers is encoded as the unspecified address.
+	Host string
+	// Services are the peer's last known services, or zero if they
+	// are unknown.
+	Services uint64
 }
 
 func (p *Peer) Encode(w io.Writer) error {
+	ip := p.IP
+	if ip == nil && p.Host != "" {
+		ip = net.IPv6unspecified
+	}
 	return dwire.EncodeFields(
 		w,
-		IPEncoder(p.IP),
+		IPEncoder(ip),
 		p.ID,
 	)
 }
@@ -54,8 +69,11 @@ func (p *PeerRes) Equals(other Message) bool {
 	for i := 0; i < len(p.Peers); i++ {
 		peerA := p.Peers[i]
 		peerB := cast.Peers[i]
-		isEqual := peerA.IP.Equal(peerB.IP) &&
-			peerA.ID == peerB.ID
+		isEqual := (peerA.IP.Equal(peerB.IP) || peerA.Host != "") &&
+			peerA.ID == peerB.ID &&
+			peerA.Port == peerB.Port &&
+			peerA.Host == peerB.Host &&
+			peerA.Services == peerB.Services
 		if !isEqual {
 			return false
 		}
@@ -72,17 +90,95 @@ func (p *PeerRes) Encode(w io.Writer) error {
 		}
 	}
 
-	return dwire.EncodeFields(
-		w,
-		p.Peers,
-	)
+	if err := dwire.EncodeField(w, p.Peers); err != nil {
+		return err
+	}
+
+	// ports, hosts and services are appended after the peer list,
+	// and only up to the last one that is set. nodes that don't know
+	// about them ignore them.
+	var hasPorts bool
+	var hasHosts bool
+	var hasServices bool
+	ports := make([]uint16, len(p.Peers))
+	hosts := make([]string, len(p.Peers))
+	services := make([]uint64, len(p.Peers))
+	for i, peer := range p.Peers {
+		ports[i] = peer.Port
+		hosts[i] = peer.Host
+		services[i] = peer.Services
+		hasPorts = hasPorts || peer.Port != 0
+		hasHosts = hasHosts || peer.Host != ""
+		hasServices = hasServices || peer.Services != 0
+	}
+	if !hasPorts && !hasHosts && !hasServices {
+		return nil
+	}
+	if err := dwire.EncodeField(w, ports); err != nil {
+		return err
+	}
+	if !hasHosts && !hasServices {
+		return nil
+	}
+	if err := dwire.EncodeField(w, hosts); err != nil {
+		return err
+	}
+	if !hasServices {
+		return nil
+	}
+	return dwire.EncodeField(w, services)
 }
 
 func (p *PeerRes) Decode(r io.Reader) error {
-	return dwire.DecodeFields(
-		r,
-		&p.Peers,
-	)
+	if err := dwire.DecodeField(r, &p.Peers); err != nil {
+		return err
+	}
+
+	var ports []uint16
+	err := dwire.DecodeField(r, &ports)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(ports) != len(p.Peers) {
+		return errors.New("peer port count does not match peer count")
+	}
+	for i, port := range ports {
+		p.Peers[i].Port = port
+	}
+
+	var hosts []string
+	err = dwire.DecodeField(r, &hosts)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(hosts) != len(p.Peers) {
+		return errors.New("peer host count does not match peer count")
+	}
+	for i, host := range hosts {
+		p.Peers[i].Host = host
+	}
+
+	var services []uint64
+	err = dwire.DecodeField(r, &services)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(services) != len(p.Peers) {
+		return errors.New("peer services count does not match peer count")
+	}
+	for i, svc := range services {
+		p.Peers[i].Services = svc
+	}
+	return nil
 }
 
 func (p *PeerRes) Hash() (crypto.Hash, error) {
@@ -1,6 +1,10 @@
 package wire
 
 import (
+	"bytes"
+	"fnd.localhost/dwire"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
 	"net"
 	"testing"
 )
@@ -21,3 +25,67 @@ func TestPeerRes_Encoding(t *testing.T) {
 
 	testMessageEncoding(t, "peer_res", peerRes, &PeerRes{})
 }
+
+func TestPeerRes_EncodingPorts(t *testing.T) {
+	peerRes := &PeerRes{
+		Peers: []*Peer{
+			{
+				IP:   net.ParseIP("192.168.0.1"),
+				ID:   fixedHash,
+				Port: 9098,
+			},
+			{
+				IP: net.ParseIP("2001:db8::1"),
+				ID: fixedHash,
+			},
+		},
+	}
+
+	testMessageEncoding(t, "peer_res_ports", peerRes, &PeerRes{})
+}
+
+func TestPeerRes_DecodeWithoutPorts(t *testing.T) {
+	fixtureData, err := ioutil.ReadFile("testdata/peer_res_ports")
+	require.NoError(t, err)
+
+	// simulate an older node that only knows about the peer list
+	var peers []*Peer
+	require.NoError(t, dwire.DecodeField(bytes.NewReader(fixtureData), &peers))
+	require.Len(t, peers, 2)
+	require.True(t, peers[1].IP.Equal(net.ParseIP("2001:db8::1")))
+}
+
+func TestPeerRes_EncodingHosts(t *testing.T) {
+	peerRes := &PeerRes{
+		Peers: []*Peer{
+			{
+				IP: net.ParseIP("192.168.0.1"),
+				ID: fixedHash,
+			},
+			{
+				ID:   fixedHash,
+				Host: "expyuzz4wqqyqhjn.onion",
+			},
+		},
+	}
+
+	testMessageEncoding(t, "peer_res_hosts", peerRes, &PeerRes{})
+}
+
+func TestPeerRes_EncodingServices(t *testing.T) {
+	peerRes := &PeerRes{
+		Peers: []*Peer{
+			{
+				IP:       net.ParseIP("192.168.0.1"),
+				ID:       fixedHash,
+				Services: LegacyServices,
+			},
+			{
+				IP: net.ParseIP("1.1.1.1"),
+				ID: fixedHash,
+			},
+		},
+	}
+
+	testMessageEncoding(t, "peer_res_services", peerRes, &PeerRes{})
+}
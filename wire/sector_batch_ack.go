@@ -0,0 +1,42 @@
+package wire
+
+import (
+	"fnd.localhost/dwire"
+	"fnd/crypto"
+	"io"
+)
+
+// SectorBatchAck acknowledges a SectorBatchRes, which allows the
+// sender to send one more.
+type SectorBatchAck struct {
+	HashCacher
+
+	Name string
+}
+
+var _ Message = (*SectorBatchAck)(nil)
+
+func (s *SectorBatchAck) MsgType() MessageType {
+	return MessageTypeSectorBatchAck
+}
+
+func (s *SectorBatchAck) Equals(other Message) bool {
+	cast, ok := other.(*SectorBatchAck)
+	if !ok {
+		return false
+	}
+
+	return s.Name == cast.Name
+}
+
+func (s *SectorBatchAck) Encode(w io.Writer) error {
+	return dwire.EncodeField(w, s.Name)
+}
+
+func (s *SectorBatchAck) Decode(r io.Reader) error {
+	return dwire.DecodeField(r, &s.Name)
+}
+
+func (s *SectorBatchAck) Hash() (crypto.Hash, error) {
+	return s.HashCacher.Hash(s)
+}
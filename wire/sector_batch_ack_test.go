@@ -0,0 +1,13 @@
+package wire
+
+import (
+	"testing"
+)
+
+func TestSectorBatchAck_Encoding(t *testing.T) {
+	sectorBatchAck := &SectorBatchAck{
+		Name: "testname.",
+	}
+
+	testMessageEncoding(t, "sector_batch_ack", sectorBatchAck, &SectorBatchAck{})
+}
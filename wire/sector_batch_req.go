@@ -0,0 +1,86 @@
+package wire
+
+import (
+	"fnd.localhost/dwire"
+	"fnd/crypto"
+	"io"
+)
+
+// SectorBitmap is a set of sector IDs.
+type SectorBitmap [32]byte
+
+func (b *SectorBitmap) Set(id uint8) {
+	b[id/8] |= 1 << (id % 8)
+}
+
+func (b SectorBitmap) Has(id uint8) bool {
+	return b[id/8]&(1<<(id%8)) != 0
+}
+
+// IDs returns the sector IDs in the set in ascending order.
+func (b SectorBitmap) IDs() []uint8 {
+	var ids []uint8
+	for i := 0; i < len(b)*8; i++ {
+		if b.Has(uint8(i)) {
+			ids = append(ids, uint8(i))
+		}
+	}
+	return ids
+}
+
+// SectorBatchReq requests every sector in Sectors. The sectors are
+// streamed back in SectorBatchRes messages, and at most Window of
+// them are sent before the requester acknowledges one with a
+// SectorBatchAck.
+type SectorBatchReq struct {
+	HashCacher
+
+	Name    string
+	Sectors SectorBitmap
+	Window  uint8
+}
+
+var _ Message = (*SectorBatchReq)(nil)
+
+func (s *SectorBatchReq) MsgType() MessageType {
+	return MessageTypeSectorBatchReq
+}
+
+func (s *SectorBatchReq) Equals(other Message) bool {
+	cast, ok := other.(*SectorBatchReq)
+	if !ok {
+		return false
+	}
+
+	return s.Name == cast.Name &&
+		s.Sectors == cast.Sectors &&
+		s.Window == cast.Window
+}
+
+func (s *SectorBatchReq) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		s.Name,
+		[32]byte(s.Sectors),
+		s.Window,
+	)
+}
+
+func (s *SectorBatchReq) Decode(r io.Reader) error {
+	var sectors [32]byte
+	err := dwire.DecodeFields(
+		r,
+		&s.Name,
+		&sectors,
+		&s.Window,
+	)
+	if err != nil {
+		return err
+	}
+	s.Sectors = sectors
+	return nil
+}
+
+func (s *SectorBatchReq) Hash() (crypto.Hash, error) {
+	return s.HashCacher.Hash(s)
+}
@@ -0,0 +1,22 @@
+package wire
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSectorBatchReq_Encoding(t *testing.T) {
+	var sectors SectorBitmap
+	sectors.Set(0)
+	sectors.Set(9)
+	sectors.Set(255)
+	sectorBatchReq := &SectorBatchReq{
+		Name:    "testname.",
+		Sectors: sectors,
+		Window:  4,
+	}
+
+	testMessageEncoding(t, "sector_batch_req", sectorBatchReq, &SectorBatchReq{})
+	require.Equal(t, []uint8{0, 9, 255}, sectors.IDs())
+	require.False(t, sectors.Has(8))
+}
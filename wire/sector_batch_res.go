@@ -0,0 +1,95 @@
+package wire
+
+import (
+	"errors"
+	"fnd.localhost/dwire"
+	"fnd/blob"
+	"fnd/crypto"
+	"io"
+)
+
+// MaxSectorBatchResLen is the maximum number of sectors in a
+// SectorBatchRes.
+const MaxSectorBatchResLen = 16
+
+type BatchSector struct {
+	ID     uint8
+	Sector blob.Sector
+}
+
+func (b *BatchSector) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		b.ID,
+		b.Sector,
+	)
+}
+
+func (b *BatchSector) Decode(r io.Reader) error {
+	return dwire.DecodeFields(
+		r,
+		&b.ID,
+		&b.Sector,
+	)
+}
+
+// SectorBatchRes carries some of the sectors requested by a
+// SectorBatchReq.
+type SectorBatchRes struct {
+	HashCacher
+
+	Name    string
+	Sectors []*BatchSector
+}
+
+var _ Message = (*SectorBatchRes)(nil)
+
+func (s *SectorBatchRes) MsgType() MessageType {
+	return MessageTypeSectorBatchRes
+}
+
+func (s *SectorBatchRes) Equals(other Message) bool {
+	cast, ok := other.(*SectorBatchRes)
+	if !ok {
+		return false
+	}
+	if s.Name != cast.Name || len(s.Sectors) != len(cast.Sectors) {
+		return false
+	}
+	for i := range s.Sectors {
+		if s.Sectors[i].ID != cast.Sectors[i].ID || s.Sectors[i].Sector != cast.Sectors[i].Sector {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *SectorBatchRes) Encode(w io.Writer) error {
+	if len(s.Sectors) > MaxSectorBatchResLen {
+		return errors.New("too many sectors in batch")
+	}
+	return dwire.EncodeFields(
+		w,
+		s.Name,
+		s.Sectors,
+	)
+}
+
+func (s *SectorBatchRes) Decode(r io.Reader) error {
+	err := dwire.DecodeFields(
+		r,
+		&s.Name,
+		&s.Sectors,
+	)
+	if err != nil {
+		return err
+	}
+	if len(s.Sectors) > MaxSectorBatchResLen {
+		return errors.New("too many sectors in batch")
+	}
+	return nil
+}
+
+func (s *SectorBatchRes) Hash() (crypto.Hash, error) {
+	return s.HashCacher.Hash(s)
+}
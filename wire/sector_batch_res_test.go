@@ -0,0 +1,29 @@
+package wire
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"testing"
+)
+
+func TestSectorBatchRes_Encoding(t *testing.T) {
+	sectorBatchRes := &SectorBatchRes{
+		Name: "testname.",
+		Sectors: []*BatchSector{
+			{
+				ID: 1,
+			},
+			{
+				ID: 16,
+			},
+		},
+	}
+	sectorBatchRes.Sectors[1].Sector[0] = 0xff
+
+	testMessageEncoding(t, "sector_batch_res", sectorBatchRes, &SectorBatchRes{})
+
+	tooLong := &SectorBatchRes{
+		Sectors: make([]*BatchSector, MaxSectorBatchResLen+1),
+	}
+	require.Error(t, tooLong.Encode(ioutil.Discard))
+}
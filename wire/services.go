@@ -0,0 +1,41 @@
+package wire
+
+const (
+	// ServiceUpdateInv is set by nodes that understand UpdateInv
+	// messages. Nodes without it are sent full updates instead.
+	ServiceUpdateInv uint64 = 1 << iota
+	// ServiceBlobs is set by nodes that store and serve every blob.
+	// Nodes without it only relay updates.
+	ServiceBlobs
+	// ServiceInbound is set by nodes that accept inbound connections.
+	ServiceInbound
+	// ServiceSectorBatch is set by nodes that answer SectorBatchReq
+	// messages.
+	ServiceSectorBatch
+)
+
+// LegacyServices are assumed for nodes that don't send a services
+// bitfield. All such nodes store blobs and accept connections.
+const LegacyServices = ServiceBlobs | ServiceInbound
+
+var serviceNames = []struct {
+	service uint64
+	name    string
+}{
+	{ServiceUpdateInv, "update_inv"},
+	{ServiceBlobs, "blobs"},
+	{ServiceInbound, "inbound"},
+	{ServiceSectorBatch, "sector_batch"},
+}
+
+// ServiceNames returns the names of the services set in services.
+// Unknown bits are ignored.
+func ServiceNames(services uint64) []string {
+	var names []string
+	for _, svc := range serviceNames {
+		if services&svc.service != 0 {
+			names = append(names, svc.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,95 @@
+package wire
+
+import (
+	"errors"
+	"fnd.localhost/dwire"
+	"fnd/crypto"
+	"io"
+	"time"
+)
+
+const (
+	MaxUpdateInvEntries = 1000
+)
+
+// UpdateInvEntry announces an update without its signature or
+// roots. Hash is the hash of the full Update message.
+type UpdateInvEntry struct {
+	Name      string
+	Timestamp time.Time
+	Hash      crypto.Hash
+}
+
+func (u *UpdateInvEntry) Encode(w io.Writer) error {
+	return dwire.EncodeFields(
+		w,
+		u.Name,
+		u.Timestamp,
+		u.Hash,
+	)
+}
+
+func (u *UpdateInvEntry) Decode(r io.Reader) error {
+	return dwire.DecodeFields(
+		r,
+		&u.Name,
+		&u.Timestamp,
+		&u.Hash,
+	)
+}
+
+type UpdateInv struct {
+	HashCacher
+
+	Entries []*UpdateInvEntry
+}
+
+var _ Message = (*UpdateInv)(nil)
+
+func (u *UpdateInv) MsgType() MessageType {
+	return MessageTypeUpdateInv
+}
+
+func (u *UpdateInv) Equals(other Message) bool {
+	cast, ok := other.(*UpdateInv)
+	if !ok {
+		return false
+	}
+	if len(u.Entries) != len(cast.Entries) {
+		return false
+	}
+
+	for i := 0; i < len(u.Entries); i++ {
+		entryA := u.Entries[i]
+		entryB := cast.Entries[i]
+		isEqual := entryA.Name == entryB.Name &&
+			entryA.Timestamp.Equal(entryB.Timestamp) &&
+			entryA.Hash == entryB.Hash
+		if !isEqual {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (u *UpdateInv) Encode(w io.Writer) error {
+	if len(u.Entries) > MaxUpdateInvEntries {
+		return errors.New("too many inventory entries")
+	}
+	return dwire.EncodeField(w, u.Entries)
+}
+
+func (u *UpdateInv) Decode(r io.Reader) error {
+	if err := dwire.DecodeField(r, &u.Entries); err != nil {
+		return err
+	}
+	if len(u.Entries) > MaxUpdateInvEntries {
+		return errors.New("too many inventory entries")
+	}
+	return nil
+}
+
+func (u *UpdateInv) Hash() (crypto.Hash, error) {
+	return u.HashCacher.Hash(u)
+}
@@ -0,0 +1,24 @@
+package wire
+
+import (
+	"testing"
+)
+
+func TestUpdateInv_Encoding(t *testing.T) {
+	updateInv := &UpdateInv{
+		Entries: []*UpdateInvEntry{
+			{
+				Name:      "testname",
+				Timestamp: fixedTime,
+				Hash:      fixedHash,
+			},
+			{
+				Name:      "othername",
+				Timestamp: fixedTime,
+				Hash:      fixedHash,
+			},
+		},
+	}
+
+	testMessageEncoding(t, "update_inv", updateInv, &UpdateInv{})
+}